@@ -90,7 +90,9 @@ func runAPIServiceIndefinitely(port int, specDirs []string) {
 	startChan := &runner.StartChannels{RunnerChan: make(chan runner.Runner), ErrorChan: make(chan error), KillChan: make(chan bool)}
 
 	sig := &infoGatherer.SpecInfoGatherer{SpecDirs: specDirs}
-	sig.Init()
+	if err := sig.Init(); err != nil {
+		logger.Errorf("%s", err.Error())
+	}
 	go startAPIServiceWithoutRunner(port, startChan, sig)
 	go checkParentIsAlive(startChan)
 
@@ -139,7 +141,9 @@ func RunInBackground(apiPort string, specDirs []string) {
 
 func Start(specsDir []string) *conn.GaugeConnectionHandler {
 	sig := &infoGatherer.SpecInfoGatherer{SpecDirs: specsDir}
-	sig.Init()
+	if err := sig.Init(); err != nil {
+		logger.Errorf("%s", err.Error())
+	}
 	apiHandler := &gaugeAPIMessageHandler{specInfoGatherer: sig}
 	gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, apiHandler)
 	if err != nil {
@@ -18,12 +18,18 @@
 package api
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/api/infoGatherer"
+	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/parser"
+	"github.com/golang/protobuf/proto"
 	. "gopkg.in/check.v1"
 )
 
@@ -59,3 +65,34 @@ func (s *MySuite) TestCreateSpecsResponseMessageFor(c *C) {
 	c.Assert(len(m.GetDetails()[2].ParseErrors), Equals, 0)
 	c.Assert(m.GetDetails()[2].Spec.GetSpecHeading(), Equals, "Spec heading 2")
 }
+
+func (s *MySuite) TestGetAllConceptsRequestRoundTrip(c *C) {
+	projectRoot, _ := ioutil.TempDir("", "gaugeConcepts")
+	defer os.RemoveAll(projectRoot)
+	specsDir := filepath.Join(projectRoot, "specs")
+	os.MkdirAll(specsDir, common.NewDirectoryPermissions)
+	ioutil.WriteFile(filepath.Join(specsDir, "login.cpt"), []byte("# login as <user>\n* enter <user>\n"), common.NewFilePermissions)
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+	gatherer := infoGatherer.NewSpecInfoGatherer(gauge.NewConceptDictionary())
+	gatherer.SpecDirs = []string{specsDir}
+	c.Assert(gatherer.Init(), IsNil)
+	h := &gaugeAPIMessageHandler{specInfoGatherer: gatherer}
+
+	requestBytes, err := proto.Marshal(&gauge_messages.APIMessage{MessageType: gauge_messages.APIMessage_GetAllConceptsRequest, MessageId: 7, AllConceptsRequest: &gauge_messages.GetAllConceptsRequest{}})
+	c.Assert(err, IsNil)
+	request := &gauge_messages.APIMessage{}
+	c.Assert(proto.Unmarshal(requestBytes, request), IsNil)
+	responseBytes, err := proto.Marshal(h.getAllConceptsRequestResponse(request))
+	c.Assert(err, IsNil)
+	response := &gauge_messages.APIMessage{}
+	c.Assert(proto.Unmarshal(responseBytes, response), IsNil)
+
+	c.Assert(response.GetMessageType(), Equals, gauge_messages.APIMessage_GetAllConceptsResponse)
+	c.Assert(response.GetMessageId(), Equals, int64(7))
+	concepts := response.GetAllConceptsResponse().GetConcepts()
+	c.Assert(len(concepts), Equals, 1)
+	c.Assert(concepts[0].GetStepValue().GetParameterizedStepValue(), Equals, "login as <user>")
+	c.Assert(concepts[0].GetFilepath(), Equals, filepath.Join(specsDir, "login.cpt"))
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import "github.com/getgauge/gauge/logger"
+
+// SpecEventKind is the kind of change that happened to a spec or concept
+type SpecEventKind int
+
+const (
+	Added SpecEventKind = iota
+	Modified
+	Removed
+)
+
+const eventsBufferSize = 100
+
+// SpecEvent is published whenever a spec or concept in the project changes.
+// Payload is the new (or, for Removed, the old) *gauge.Specification or *gauge.Concept.
+type SpecEvent struct {
+	Kind    SpecEventKind
+	Payload interface{}
+}
+
+// Events returns a buffered channel on which all the spec and concept changes are published.
+// Events are never waited on: when a subscriber falls so far behind that its channel is full, the events
+// which do not fit are dropped for that subscriber.
+func (s *SpecInfoGatherer) Events() <-chan SpecEvent {
+	ch := make(chan SpecEvent, eventsBufferSize)
+	s.subscribers.mutex.Lock()
+	defer s.subscribers.mutex.Unlock()
+	s.subscribers.channels = append(s.subscribers.channels, ch)
+	return ch
+}
+
+// Unsubscribe stops publishing to a channel returned by Events and closes it.
+func (s *SpecInfoGatherer) Unsubscribe(events <-chan SpecEvent) {
+	s.subscribers.mutex.Lock()
+	defer s.subscribers.mutex.Unlock()
+	for i, ch := range s.subscribers.channels {
+		if ch == events {
+			s.subscribers.channels = append(s.subscribers.channels[:i], s.subscribers.channels[i+1:]...)
+			close(ch)
+			return
+		}
+	}
+}
+
+// publish sends the event to every subscriber. It should not be called with any of the cache locks held.
+func (s *SpecInfoGatherer) publish(event SpecEvent) {
+	s.subscribers.mutex.Lock()
+	defer s.subscribers.mutex.Unlock()
+	for _, ch := range s.subscribers.channels {
+		select {
+		case ch <- event:
+		default:
+			logger.APILog.Debugf("Dropped a spec event as a subscriber is not keeping up")
+		}
+	}
+}
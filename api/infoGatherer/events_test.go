@@ -0,0 +1,111 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestEventsForSpecFileChanges(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	events := specInfoGatherer.Events()
+
+	file, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	file, _ = filepath.Abs(file)
+	specInfoGatherer.OnSpecFileModify(file)
+	specInfoGatherer.OnSpecFileModify(file)
+	specInfoGatherer.onSpecFileRemove(file)
+
+	for _, kind := range []SpecEventKind{Added, Modified, Removed} {
+		e := nextEvent(c, events)
+		c.Assert(e.Kind, Equals, kind)
+		c.Assert(e.Payload.(*gauge.Specification).FileName, Equals, file)
+	}
+}
+
+func (s *MySuite) TestEventsForConceptFileChanges(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	events := specInfoGatherer.Events()
+
+	file, _ := createFileIn(s.specsDir, "concept1.cpt", concept1)
+	file, _ = filepath.Abs(file)
+	specInfoGatherer.OnConceptFileModify(file)
+	specInfoGatherer.onConceptFileRemove(file)
+
+	for _, kind := range []SpecEventKind{Added, Removed} {
+		e := nextEvent(c, events)
+		c.Assert(e.Kind, Equals, kind)
+		c.Assert(e.Payload.(*gauge.Concept).ConceptStep.Value, Equals, "foo bar")
+	}
+}
+
+func (s *MySuite) TestPublishDropsEventsWhenNoOneReads(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	events := specInfoGatherer.Events()
+
+	published := make(chan bool)
+	go func() {
+		for i := 0; i < eventsBufferSize*3; i++ {
+			specInfoGatherer.publish(SpecEvent{Kind: Modified})
+		}
+		published <- true
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		c.Fatal("publish blocked on a subscriber which does not read")
+	}
+	c.Assert(len(events), Equals, eventsBufferSize)
+}
+
+func (s *MySuite) TestUnsubscribeClosesTheChannel(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	events := specInfoGatherer.Events()
+	other := specInfoGatherer.Events()
+
+	specInfoGatherer.Unsubscribe(events)
+	specInfoGatherer.publish(SpecEvent{Kind: Added})
+
+	_, open := <-events
+	c.Assert(open, Equals, false)
+	c.Assert(nextEvent(c, other).Kind, Equals, Added)
+}
+
+func nextEvent(c *C, events <-chan SpecEvent) SpecEvent {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		c.Fatal("timed out waiting for a spec event")
+	}
+	return SpecEvent{}
+}
@@ -0,0 +1,80 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+)
+
+// changeLog limits the messages logged for file changes. A branch switch can touch thousands of files at once,
+// and logging a line for each of them floods the API log.
+var changeLog = &logLimiter{interval: time.Second, report: logger.APILog.Infof}
+
+// logLimiter allows a limited number of messages to be logged in an interval. Messages over the limit are counted
+// and reported as a single line once the interval ends.
+type logLimiter struct {
+	mutex       sync.Mutex
+	once        sync.Once
+	limit       int
+	interval    time.Duration
+	report      func(format string, args ...interface{})
+	windowStart time.Time
+	logged      int
+	suppressed  int
+}
+
+// allow returns true if a message can be logged now. Otherwise the message is counted towards the summary.
+func (l *logLimiter) allow() bool {
+	l.once.Do(func() {
+		if l.limit == 0 {
+			l.limit = config.APILogRateLimit()
+		}
+	})
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := time.Now()
+	if now.Sub(l.windowStart) >= l.interval {
+		l.flush()
+		l.windowStart = now
+		l.logged = 0
+	}
+	if l.limit == 0 || l.logged < l.limit {
+		l.logged++
+		return true
+	}
+	if l.suppressed == 0 {
+		time.AfterFunc(l.windowStart.Add(l.interval).Sub(now), func() {
+			l.mutex.Lock()
+			defer l.mutex.Unlock()
+			l.flush()
+		})
+	}
+	l.suppressed++
+	return false
+}
+
+func (l *logLimiter) flush() {
+	if l.suppressed > 0 {
+		l.report("Processed %d more file changes in the last %s", l.suppressed, l.interval)
+		l.suppressed = 0
+	}
+}
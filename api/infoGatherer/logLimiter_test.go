@@ -0,0 +1,54 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"fmt"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestLogLimiterSummarisesMessagesOverTheLimit(c *C) {
+	var reports []string
+	limiter := &logLimiter{limit: 2, interval: 50 * time.Millisecond, report: func(format string, args ...interface{}) {
+		reports = append(reports, fmt.Sprintf(format, args...))
+	}}
+
+	var allowed int
+	for i := 0; i < 5; i++ {
+		if limiter.allow() {
+			allowed++
+		}
+	}
+	c.Assert(allowed, Equals, 2)
+
+	time.Sleep(100 * time.Millisecond)
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	c.Assert(reports, DeepEquals, []string{"Processed 3 more file changes in the last 50ms"})
+}
+
+func (s *MySuite) TestLogLimiterAllowsMessagesInNextInterval(c *C) {
+	limiter := &logLimiter{limit: 1, interval: 50 * time.Millisecond, report: func(string, ...interface{}) {}}
+
+	c.Assert(limiter.allow(), Equals, true)
+	c.Assert(limiter.allow(), Equals, false)
+	time.Sleep(60 * time.Millisecond)
+	c.Assert(limiter.allow(), Equals, true)
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getgauge/gauge/config"
+)
+
+// minSpecsForParseFailureCheck keeps a project with a handful of specs, one of which is being written, from failing.
+const minSpecsForParseFailureCheck = 5
+
+// checkParseFailures gives an error if the fraction of cached spec files in which no spec heading could be parsed is more
+// than the threshold. Almost every spec failing to parse points to a problem with the project, like a wrong file encoding
+// or a wrong spec directory, rather than with the specs.
+func (s *SpecInfoGatherer) checkParseFailures() error {
+	threshold := s.ParseFailureThreshold
+	if threshold <= 0 {
+		threshold = config.ParseFailureThreshold()
+	}
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	total := len(s.specsCache.specDetails)
+	if total < minSpecsForParseFailureCheck {
+		return nil
+	}
+	var failed []string
+	for file, detail := range s.specsCache.specDetails {
+		if len(detail.Errs) > 0 && !hasHeading(detail) {
+			failed = append(failed, file)
+		}
+	}
+	if float64(len(failed)) <= threshold*float64(total) {
+		return nil
+	}
+	sort.Strings(failed)
+	return fmt.Errorf("%d of %d spec files failed to parse, e.g. %s: %s\nCheck that the spec directories are correct and the files are UTF-8 encoded.",
+		len(failed), total, failed[0], s.specsCache.specDetails[failed[0]].Errs[0].Error())
+}
+
+// hasHeading is false for a file in which not even the spec heading could be parsed.
+func hasHeading(detail *SpecDetail) bool {
+	return detail.HasSpec() && detail.Spec.Heading.Value != ""
+}
@@ -0,0 +1,68 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"fmt"
+	"os"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestInitReportsWhenMostSpecsFailToParse(c *C) {
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	for i := 0; i < 19; i++ {
+		createFileIn(s.specsDir, fmt.Sprintf("broken%d.spec", i), []byte("\xff\xfeS\x00p\x00e\x00c\x00\n\x00"))
+	}
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+
+	err := specInfoGatherer.Init()
+
+	c.Assert(err, NotNil)
+	c.Assert(err, ErrorMatches, "(?s)19 of 20 spec files failed to parse.*UTF-8 encoded.")
+	c.Assert(len(specInfoGatherer.AllSteps()), Not(Equals), 0)
+}
+
+func (s *MySuite) TestInitDoesNotReportFailuresWithinThreshold(c *C) {
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	for i := 0; i < 9; i++ {
+		createFileIn(s.specsDir, fmt.Sprintf("broken%d.spec", i), []byte("* a step without a spec heading\n"))
+	}
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}, ParseFailureThreshold: 0.95}
+
+	c.Assert(specInfoGatherer.Init(), IsNil)
+}
+
+func (s *MySuite) TestInitReadsParseFailureThresholdFromConfig(c *C) {
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	for i := 0; i < 9; i++ {
+		createFileIn(s.specsDir, fmt.Sprintf("broken%d.spec", i), []byte("* a step without a spec heading\n"))
+	}
+	os.Setenv("parse_failure_threshold", "0.5")
+	defer os.Unsetenv("parse_failure_threshold")
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+
+	c.Assert(specInfoGatherer.Init(), NotNil)
+}
+
+func (s *MySuite) TestInitDoesNotReportAFewBrokenSpecs(c *C) {
+	createFileIn(s.specsDir, "broken.spec", []byte("* a step without a spec heading\n"))
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+
+	c.Assert(specInfoGatherer.Init(), IsNil)
+}
@@ -0,0 +1,88 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/getgauge/common"
+)
+
+type pausedChanges struct {
+	mutex  sync.Mutex
+	paused bool
+	files  map[string]bool
+}
+
+// PauseWatching stops processing file changes until ResumeWatching is called. The changed files are remembered,
+// so a tool making edits to many files can have all of them picked up together once it is done.
+func (s *SpecInfoGatherer) PauseWatching() {
+	s.pausedChanges.mutex.Lock()
+	defer s.pausedChanges.mutex.Unlock()
+	s.pausedChanges.paused = true
+	if s.pausedChanges.files == nil {
+		s.pausedChanges.files = make(map[string]bool)
+	}
+}
+
+// ResumeWatching processes each file which changed while watching was paused once, and then resumes watching.
+// Files which change while these are processed are picked up before watching resumes.
+func (s *SpecInfoGatherer) ResumeWatching() {
+	for {
+		s.pausedChanges.mutex.Lock()
+		files := s.pausedChanges.files
+		s.pausedChanges.files = make(map[string]bool)
+		if len(files) == 0 {
+			s.pausedChanges.paused = false
+			s.pausedChanges.mutex.Unlock()
+			return
+		}
+		s.pausedChanges.mutex.Unlock()
+		s.reconcile(files)
+	}
+}
+
+// deferIfPaused remembers the changed file and returns true if watching is paused.
+func (s *SpecInfoGatherer) deferIfPaused(file string) bool {
+	s.pausedChanges.mutex.Lock()
+	defer s.pausedChanges.mutex.Unlock()
+	if !s.pausedChanges.paused {
+		return false
+	}
+	s.pausedChanges.files[file] = true
+	return true
+}
+
+func (s *SpecInfoGatherer) reconcile(changedFiles map[string]bool) {
+	var files []string
+	for file := range changedFiles {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	s.dirsMutex.Lock()
+	watcher := s.watcher
+	s.dirsMutex.Unlock()
+	for _, file := range files {
+		if common.FileExists(file) {
+			s.onFileAdd(watcher, file)
+		} else {
+			s.onFileRemove(watcher, file)
+		}
+	}
+}
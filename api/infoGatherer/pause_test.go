@@ -0,0 +1,102 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestChangesWhilePausedAreProcessedOnceOnResume(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	events := specInfoGatherer.Events()
+
+	specInfoGatherer.PauseWatching()
+	var files []string
+	for _, name := range []string{"spec1.spec", "spec2.spec", "spec3.spec"} {
+		file, _ := createFileIn(s.specsDir, name, spec1)
+		file, _ = filepath.Abs(file)
+		files = append(files, file)
+		specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Create}, nil)
+		specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Write}, nil)
+		specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Write}, nil)
+	}
+
+	c.Assert(len(events), Equals, 0)
+	_, found := specInfoGatherer.GetSpecForFile(files[0])
+	c.Assert(found, Equals, false)
+
+	specInfoGatherer.ResumeWatching()
+
+	c.Assert(len(events), Equals, 3)
+	for _, file := range files {
+		e := <-events
+		c.Assert(e.Kind, Equals, Added)
+		c.Assert(e.Payload.(*gauge.Specification).FileName, Equals, file)
+	}
+}
+
+func (s *MySuite) TestFileRemovedWhilePausedIsRemovedOnResume(c *C) {
+	file, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	file, _ = filepath.Abs(file)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	events := specInfoGatherer.Events()
+
+	specInfoGatherer.PauseWatching()
+	specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Write}, nil)
+	os.Remove(file)
+	specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Remove}, nil)
+	specInfoGatherer.ResumeWatching()
+
+	c.Assert(len(events), Equals, 1)
+	c.Assert((<-events).Kind, Equals, Removed)
+	_, found := specInfoGatherer.GetSpecForFile(file)
+	c.Assert(found, Equals, false)
+}
+
+func (s *MySuite) TestChangesAfterResumeAreProcessedImmediately(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	events := specInfoGatherer.Events()
+
+	specInfoGatherer.PauseWatching()
+	specInfoGatherer.ResumeWatching()
+	file, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	file, _ = filepath.Abs(file)
+	specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Create}, nil)
+
+	c.Assert(len(events), Equals, 1)
+}
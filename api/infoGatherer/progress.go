@@ -0,0 +1,48 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import "github.com/getgauge/gauge/parser"
+
+// ProgressFunc is told about each file parsed while the caches are built. done counts the files parsed so far,
+// concepts first and then specs, out of total files.
+type ProgressFunc func(done, total int, currentFile string)
+
+type parseProgress struct {
+	report      ProgressFunc
+	done, total int
+}
+
+// startProgress counts the files to parse for the Progress callback. Returns nil if there is no callback.
+func (s *SpecInfoGatherer) startProgress() *parseProgress {
+	if s.Progress == nil {
+		return nil
+	}
+	return &parseProgress{report: s.Progress, total: len(parser.ConceptFiles()) + len(getSpecFiles(s.SpecDirs))}
+}
+
+// onParsed returns the function the parser calls after each file, or nil if progress is not reported.
+func (p *parseProgress) onParsed() func(file string) {
+	if p == nil {
+		return nil
+	}
+	return func(file string) {
+		p.done++
+		p.report(p.done, p.total, file)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestProgressIsReportedForEachParsedFile(c *C) {
+	createFileIn(s.specsDir, "concept1.cpt", concept1)
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	createFileIn(s.specsDir, "spec2.spec", spec2)
+	createFileIn(s.specsDir, "spec3.spec", spec3)
+	var done, totals []int
+	var files []string
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}, Progress: func(d, total int, file string) {
+		done = append(done, d)
+		totals = append(totals, total)
+		files = append(files, file)
+	}}
+
+	progress := specInfoGatherer.startProgress()
+	specInfoGatherer.initConceptsCacheWithProgress(progress)
+	specInfoGatherer.initSpecsCacheWithProgress(progress)
+
+	c.Assert(done, DeepEquals, []int{1, 2, 3, 4})
+	c.Assert(totals, DeepEquals, []int{4, 4, 4, 4})
+	c.Assert(files[0], Matches, ".*concept1.cpt")
+	for _, file := range files[1:] {
+		c.Assert(file, Matches, ".*spec[123].spec")
+	}
+}
+
+func (s *MySuite) TestProgressIsNotReportedWithoutCallback(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+
+	c.Assert(specInfoGatherer.startProgress(), IsNil)
+	c.Assert(specInfoGatherer.startProgress().onParsed(), IsNil)
+}
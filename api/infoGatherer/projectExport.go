@@ -0,0 +1,221 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/manifest"
+)
+
+// ExportedProjectMetadata describes the project an export was taken from.
+type ExportedProjectMetadata struct {
+	ProjectRoot string   `json:"projectRoot"`
+	Language    string   `json:"language"`
+	SpecDirs    []string `json:"specDirs"`
+}
+
+// ExportedSpec is a spec in the project export. Concepts used by the spec are not expanded.
+type ExportedSpec struct {
+	File      string             `json:"file"`
+	Heading   string             `json:"heading"`
+	Tags      []string           `json:"tags"`
+	Contexts  []ExportedStep     `json:"contexts"`
+	Scenarios []ExportedScenario `json:"scenarios"`
+	TearDown  []ExportedStep     `json:"tearDown"`
+	Errors    []string           `json:"errors"`
+}
+
+// ExportedScenario is a scenario of an exported spec.
+type ExportedScenario struct {
+	Heading string         `json:"heading"`
+	LineNo  int            `json:"lineNo"`
+	Tags    []string       `json:"tags"`
+	Steps   []ExportedStep `json:"steps"`
+}
+
+// ExportedStep is a step as written in a spec or concept file.
+type ExportedStep struct {
+	Text   string `json:"text"`
+	Value  string `json:"value"`
+	LineNo int    `json:"lineNo"`
+}
+
+// ExportedConcept is a concept along with the steps it is made of.
+type ExportedConcept struct {
+	File   string         `json:"file"`
+	Text   string         `json:"text"`
+	LineNo int            `json:"lineNo"`
+	Steps  []ExportedStep `json:"steps"`
+}
+
+// ExportProject writes a JSON snapshot of the whole project, i.e. its metadata, specs, concepts and the unique step values.
+// The document is an object with the keys "metadata", "specs", "concepts" and "steps". Specs and concepts are sorted by file
+// and steps by value, so the output is stable across runs.
+//
+// This walks every cached spec and concept, so it is costly for large projects. The specs are written one at a time
+// while holding the read lock of the specs cache, instead of building the whole document in memory first.
+func (s *SpecInfoGatherer) ExportProject(w io.Writer) error {
+	language := ""
+	if m, err := manifest.ProjectManifest(); err == nil {
+		language = m.Language
+	}
+	specDirs := append([]string{}, s.SpecDirs...)
+	if _, err := fmt.Fprint(w, `{"metadata":`); err != nil {
+		return err
+	}
+	if err := writeJSON(w, ExportedProjectMetadata{ProjectRoot: config.ProjectRoot, Language: language, SpecDirs: specDirs}); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, `,"specs":`); err != nil {
+		return err
+	}
+	if err := s.exportSpecs(w); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, `,"concepts":`); err != nil {
+		return err
+	}
+	if err := s.exportConcepts(w); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, `,"steps":`); err != nil {
+		return err
+	}
+	steps := make([]string, 0)
+	for _, step := range s.Steps() {
+		steps = append(steps, step.Value)
+	}
+	sort.Strings(steps)
+	if err := writeJSON(w, steps); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func (s *SpecInfoGatherer) exportSpecs(w io.Writer) error {
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	files := make([]string, 0, len(s.specsCache.specDetails))
+	for file, detail := range s.specsCache.specDetails {
+		if detail.HasSpec() {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return writeJSONArray(w, len(files), func(i int) interface{} {
+		return exportSpec(files[i], s.specsCache.specDetails[files[i]])
+	})
+}
+
+func (s *SpecInfoGatherer) exportConcepts(w io.Writer) error {
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
+	files := make([]string, 0, len(s.conceptsCache.concepts))
+	for file := range s.conceptsCache.concepts {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	var concepts []ExportedConcept
+	for _, file := range files {
+		for _, concept := range s.conceptsCache.concepts[file] {
+			concepts = append(concepts, ExportedConcept{
+				File:   file,
+				Text:   concept.ConceptStep.LineText,
+				LineNo: concept.ConceptStep.LineNo,
+				Steps:  exportSteps(concept.ConceptStep.ConceptSteps),
+			})
+		}
+	}
+	return writeJSONArray(w, len(concepts), func(i int) interface{} { return concepts[i] })
+}
+
+func exportSpec(file string, detail *SpecDetail) ExportedSpec {
+	spec := detail.Spec
+	exported := ExportedSpec{
+		File:      file,
+		Heading:   spec.Heading.Value,
+		Tags:      tagValues(spec.Tags),
+		Contexts:  exportSteps(spec.Contexts),
+		Scenarios: make([]ExportedScenario, 0, len(spec.Scenarios)),
+		TearDown:  exportSteps(spec.TearDownSteps),
+		Errors:    make([]string, 0, len(detail.Errs)),
+	}
+	for _, scenario := range spec.Scenarios {
+		exported.Scenarios = append(exported.Scenarios, ExportedScenario{
+			Heading: scenario.Heading.Value,
+			LineNo:  scenario.Heading.LineNo,
+			Tags:    tagValues(scenario.Tags),
+			Steps:   exportSteps(scenario.Steps),
+		})
+	}
+	for _, e := range detail.Errs {
+		exported.Errors = append(exported.Errors, e.Error())
+	}
+	return exported
+}
+
+func exportSteps(steps []*gauge.Step) []ExportedStep {
+	exported := make([]ExportedStep, 0, len(steps))
+	for _, step := range steps {
+		exported = append(exported, ExportedStep{Text: step.LineText, Value: step.Value, LineNo: step.LineNo})
+	}
+	return exported
+}
+
+func tagValues(tags *gauge.Tags) []string {
+	values := make([]string, 0)
+	if tags != nil {
+		values = append(values, tags.Values()...)
+	}
+	return values
+}
+
+// writeJSONArray writes a JSON array of n elements, encoding each element as it is written.
+func writeJSONArray(w io.Writer, n int, element func(i int) interface{}) error {
+	if _, err := fmt.Fprint(w, "["); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := writeJSON(w, element(i)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "]")
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
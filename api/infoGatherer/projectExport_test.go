@@ -0,0 +1,166 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+// projectSchema describes the shape of the project export. A map lists the keys an object must have, a one element
+// slice the type of every element of an array, and a string the JSON type of a value.
+var projectSchema = map[string]interface{}{
+	"metadata": map[string]interface{}{"projectRoot": "string", "language": "string", "specDirs": []interface{}{"string"}},
+	"specs": []interface{}{map[string]interface{}{
+		"file":     "string",
+		"heading":  "string",
+		"tags":     []interface{}{"string"},
+		"contexts": []interface{}{exportedStepSchema},
+		"scenarios": []interface{}{map[string]interface{}{
+			"heading": "string",
+			"lineNo":  "number",
+			"tags":    []interface{}{"string"},
+			"steps":   []interface{}{exportedStepSchema},
+		}},
+		"tearDown": []interface{}{exportedStepSchema},
+		"errors":   []interface{}{"string"},
+	}},
+	"concepts": []interface{}{map[string]interface{}{
+		"file":   "string",
+		"text":   "string",
+		"lineNo": "number",
+		"steps":  []interface{}{exportedStepSchema},
+	}},
+	"steps": []interface{}{"string"},
+}
+
+var exportedStepSchema = map[string]interface{}{"text": "string", "value": "string", "lineNo": "number"}
+
+func validateAgainstSchema(value interface{}, schema interface{}, path string) error {
+	switch s := schema.(type) {
+	case map[string]interface{}:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %T", path, value)
+		}
+		if len(obj) != len(s) {
+			return fmt.Errorf("%s: expected keys %v, got %v", path, s, obj)
+		}
+		for key, keySchema := range s {
+			v, ok := obj[key]
+			if !ok {
+				return fmt.Errorf("%s: missing key %s", path, key)
+			}
+			if err := validateAgainstSchema(v, keySchema, path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		arr, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %T", path, value)
+		}
+		for i, v := range arr {
+			if err := validateAgainstSchema(v, s[0], fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		var ok bool
+		switch s {
+		case "string":
+			_, ok = value.(string)
+		case "number":
+			_, ok = value.(float64)
+		}
+		if !ok {
+			return fmt.Errorf("%s: expected a %s, got %T", path, s, value)
+		}
+	}
+	return nil
+}
+
+func (s *MySuite) TestExportProject(c *C) {
+	ioutil.WriteFile(filepath.Join(s.projectDir, "manifest.json"), []byte(`{"Language": "java"}`), 0644)
+	specFile, _ := createFileIn(s.specsDir, "spec.spec", []byte(`Specification Heading
+=====================
+tags: foo
+
+Scenario 1
+----------
+tags: bar
+* say hello
+* foo bar
+`))
+	specFile, _ = filepath.Abs(specFile)
+	conceptFile, _ := createFileIn(s.specsDir, "concept1.cpt", concept1)
+	conceptFile, _ = filepath.Abs(conceptFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+
+	b := &bytes.Buffer{}
+	err := specInfoGatherer.ExportProject(b)
+	c.Assert(err, IsNil)
+
+	var got map[string]interface{}
+	c.Assert(json.Unmarshal(b.Bytes(), &got), IsNil)
+	c.Assert(validateAgainstSchema(got, projectSchema, "$"), IsNil)
+
+	var project struct {
+		Metadata ExportedProjectMetadata
+		Specs    []ExportedSpec
+		Concepts []ExportedConcept
+		Steps    []string
+	}
+	c.Assert(json.Unmarshal(b.Bytes(), &project), IsNil)
+	c.Assert(project.Metadata, DeepEquals, ExportedProjectMetadata{ProjectRoot: s.projectDir, Language: "java", SpecDirs: []string{s.specsDir}})
+	c.Assert(len(project.Specs), Equals, 1)
+	c.Assert(project.Specs[0].File, Equals, specFile)
+	c.Assert(project.Specs[0].Tags, DeepEquals, []string{"foo"})
+	c.Assert(project.Specs[0].Scenarios, DeepEquals, []ExportedScenario{{
+		Heading: "Scenario 1",
+		LineNo:  5,
+		Tags:    []string{"bar"},
+		Steps:   []ExportedStep{{Text: "say hello", Value: "say hello", LineNo: 8}, {Text: "foo bar", Value: "foo bar", LineNo: 9}},
+	}})
+	c.Assert(len(project.Concepts), Equals, 1)
+	c.Assert(project.Concepts[0].File, Equals, conceptFile)
+	c.Assert(project.Concepts[0].Text, Equals, "foo bar")
+	c.Assert(len(project.Concepts[0].Steps), Equals, 3)
+	c.Assert(project.Steps, DeepEquals, []string{"a {} step", "first step with {}", "say hello", "say {} to me"})
+}
+
+func (s *MySuite) TestExportProjectWithEmptyCaches(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+
+	b := &bytes.Buffer{}
+	err := specInfoGatherer.ExportProject(b)
+	c.Assert(err, IsNil)
+
+	var got map[string]interface{}
+	c.Assert(json.Unmarshal(b.Bytes(), &got), IsNil)
+	c.Assert(validateAgainstSchema(got, projectSchema, "$"), IsNil)
+	c.Assert(got["specs"], DeepEquals, []interface{}{})
+}
@@ -18,13 +18,20 @@
 package infoGatherer
 
 import (
+	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
@@ -41,7 +48,20 @@ type SpecInfoGatherer struct {
 	stepsCache        stepsCache
 	paramsCache       paramsCache
 	tagsCache         tagsCache
+	subscribers       subscribers
+	pausedChanges     pausedChanges
+	watcher           *fsnotify.Watcher
+	dirsMutex         sync.Mutex
 	SpecDirs          []string
+	// WatchFiles adds a watch on each spec and concept file along with their directories.
+	// This uses more file descriptors, but picks up saves from editors which replace the file.
+	WatchFiles bool
+	// ParseFailureThreshold is the fraction of spec files which may fail to parse before Init reports a problem with
+	// the project. Defaults to the parse_failure_threshold property when not set.
+	ParseFailureThreshold float64
+	// Progress, if set, is called after each file is parsed by Init. The calls are made one at a time from the
+	// goroutine running Init, so the callback need not be safe for concurrent use, but should return quickly.
+	Progress ProgressFunc
 }
 
 type conceptCache struct {
@@ -52,11 +72,13 @@ type conceptCache struct {
 type stepsCache struct {
 	mutex sync.RWMutex
 	steps map[string][]*gauge.Step
+	index map[stepKey][]*gauge.Step
 }
 
 type specsCache struct {
 	mutex       sync.RWMutex
 	specDetails map[string]*SpecDetail
+	modTimes    map[string]time.Time
 }
 
 type paramsCache struct {
@@ -70,9 +92,19 @@ type tagsCache struct {
 	tags  map[string][]string
 }
 
+type subscribers struct {
+	mutex    sync.Mutex
+	channels []chan SpecEvent
+}
+
 type SpecDetail struct {
 	Spec *gauge.Specification
 	Errs []parser.ParseError
+	// File is the path of the spec file the detail was parsed from.
+	File string
+	// ConceptsResolved is false when the spec is stale with respect to the concepts, i.e. it uses a concept
+	// which was added after the spec was parsed, or has a step expanded from a concept which no longer exists.
+	ConceptsResolved bool
 }
 
 func (d *SpecDetail) HasSpec() bool {
@@ -83,17 +115,21 @@ func NewSpecInfoGatherer(conceptDictionary *gauge.ConceptDictionary) *SpecInfoGa
 	return &SpecInfoGatherer{conceptDictionary: conceptDictionary, conceptsCache: conceptCache{concepts: make(map[string][]*gauge.Concept, 0)}}
 }
 
-// Init initializes all the SpecInfoGatherer caches
-func (s *SpecInfoGatherer) Init() {
+// Init initializes all the SpecInfoGatherer caches.
+// Returns an error if more spec files than the ParseFailureThreshold allows fail to parse. The caches are
+// initialized even then, so the error is only meant to be shown to the user.
+func (s *SpecInfoGatherer) Init() error {
 	go s.watchForFileChanges()
 	s.waitGroup.Wait()
 
 	// Concepts parsed first because we need to create a concept dictionary that spec parsing can use
-	s.initConceptsCache()
-	s.initSpecsCache()
+	progress := s.startProgress()
+	s.initConceptsCacheWithProgress(progress)
+	s.initSpecsCacheWithProgress(progress)
 	s.initStepsCache()
 	s.initParamsCache()
 	s.initTagsCache()
+	return s.checkParseFailures()
 }
 func (s *SpecInfoGatherer) initTagsCache() {
 	s.tagsCache.mutex.Lock()
@@ -124,7 +160,11 @@ func (s *SpecInfoGatherer) initParamsCache() {
 }
 
 func (s *SpecInfoGatherer) initSpecsCache() {
-	details := s.getParsedSpecs(getSpecFiles(s.SpecDirs))
+	s.initSpecsCacheWithProgress(nil)
+}
+
+func (s *SpecInfoGatherer) initSpecsCacheWithProgress(progress *parseProgress) {
+	details := s.getParsedSpecsWithProgress(getSpecFiles(s.SpecDirs), progress.onParsed())
 
 	s.specsCache.mutex.Lock()
 	defer s.specsCache.mutex.Unlock()
@@ -147,10 +187,14 @@ func getSpecFiles(specs []string) []string {
 }
 
 func (s *SpecInfoGatherer) initConceptsCache() {
+	s.initConceptsCacheWithProgress(nil)
+}
+
+func (s *SpecInfoGatherer) initConceptsCacheWithProgress(progress *parseProgress) {
 	s.conceptsCache.mutex.Lock()
 	defer s.conceptsCache.mutex.Unlock()
 
-	parsedConcepts := s.getParsedConcepts()
+	parsedConcepts := s.getParsedConceptsWithProgress(progress.onParsed())
 	s.conceptsCache.concepts = make(map[string][]*gauge.Concept, 0)
 	logger.APILog.Infof("Initializing concepts cache with %d concepts", len(parsedConcepts))
 	for _, concept := range parsedConcepts {
@@ -164,6 +208,7 @@ func (s *SpecInfoGatherer) initStepsCache() {
 	defer s.stepsCache.mutex.Unlock()
 
 	s.stepsCache.steps = make(map[string][]*gauge.Step, 0)
+	s.stepsCache.index = make(map[stepKey][]*gauge.Step, 0)
 	stepsFromSpecsMap := s.getStepsFromCachedSpecs()
 	stepsFromConceptsMap := s.getStepsFromCachedConcepts()
 
@@ -213,8 +258,9 @@ func (s *SpecInfoGatherer) addParamsFromSteps(steps []*gauge.Step, file string)
 }
 
 func (s *SpecInfoGatherer) updateTagsCacheFromSpecs(file string, specDetail *SpecDetail) {
+	s.tagsCache.tags[file] = nil
 	if specDetail.Spec.Tags != nil {
-		s.tagsCache.tags[file] = specDetail.Spec.Tags.Values()
+		s.tagsCache.tags[file] = append(s.tagsCache.tags[file], specDetail.Spec.Tags.Values()...)
 	}
 	for _, sce := range specDetail.Spec.Scenarios {
 		if sce.Tags != nil {
@@ -237,6 +283,14 @@ func removeDuplicateTags(tags []string) []string {
 
 func (s *SpecInfoGatherer) addToSpecsCache(key string, value *SpecDetail) {
 	s.specsCache.specDetails[key] = value
+	if s.specsCache.modTimes == nil {
+		s.specsCache.modTimes = make(map[string]time.Time)
+	}
+	if info, err := os.Stat(key); err == nil {
+		s.specsCache.modTimes[key] = info.ModTime()
+	} else {
+		delete(s.specsCache.modTimes, key)
+	}
 }
 
 func (s *SpecInfoGatherer) addToConceptsCache(key string, value *gauge.Concept) {
@@ -253,23 +307,29 @@ func (s *SpecInfoGatherer) deleteFromConceptDictionary(file string) {
 }
 
 func (s *SpecInfoGatherer) addToStepsCache(fileName string, allSteps []*gauge.Step) {
-	s.stepsCache.steps[fileName] = allSteps
+	s.stepsCache.put(fileName, allSteps)
 }
 
 func (s *SpecInfoGatherer) getParsedSpecs(specFiles []string) []*SpecDetail {
+	return s.getParsedSpecsWithProgress(specFiles, nil)
+}
+
+func (s *SpecInfoGatherer) getParsedSpecsWithProgress(specFiles []string, onParsed func(file string)) []*SpecDetail {
 	if s.conceptDictionary == nil {
 		s.conceptDictionary = gauge.NewConceptDictionary()
 	}
-	parsedSpecs, parseResults := parser.ParseSpecFiles(specFiles, s.conceptDictionary, gauge.NewBuildErrors())
+	parsedSpecs, parseResults := parser.ParseSpecFilesWithProgress(specFiles, s.conceptDictionary, gauge.NewBuildErrors(), onParsed)
 	specs := make(map[string]*SpecDetail)
 
 	for _, spec := range parsedSpecs {
-		specs[spec.FileName] = &SpecDetail{Spec: spec}
+		specs[spec.FileName] = &SpecDetail{Spec: spec, File: spec.FileName}
 	}
 	for _, v := range parseResults {
-		_, ok := specs[v.FileName]
+		d, ok := specs[v.FileName]
 		if !ok {
-			specs[v.FileName] = &SpecDetail{Spec: &gauge.Specification{FileName: v.FileName}, Errs: v.ParseErrors}
+			specs[v.FileName] = &SpecDetail{Spec: &gauge.Specification{FileName: v.FileName}, Errs: v.ParseErrors, File: v.FileName}
+		} else {
+			d.Errs = v.ParseErrors
 		}
 	}
 	details := make([]*SpecDetail, 0)
@@ -280,9 +340,13 @@ func (s *SpecInfoGatherer) getParsedSpecs(specFiles []string) []*SpecDetail {
 }
 
 func (s *SpecInfoGatherer) getParsedConcepts() map[string]*gauge.Concept {
+	return s.getParsedConceptsWithProgress(nil)
+}
+
+func (s *SpecInfoGatherer) getParsedConceptsWithProgress(onParsed func(file string)) map[string]*gauge.Concept {
 	var result *parser.ParseResult
 	var err error
-	s.conceptDictionary, result, err = parser.CreateConceptsDictionary()
+	s.conceptDictionary, result, err = parser.CreateConceptsDictionaryWithProgress(onParsed)
 	if err != nil {
 		logger.Fatalf("Unable to parse concepts : %s", err.Error())
 	}
@@ -314,10 +378,13 @@ func (s *SpecInfoGatherer) getStepsFromCachedConcepts() map[string][]*gauge.Step
 }
 
 func (s *SpecInfoGatherer) OnSpecFileModify(file string) {
-	logger.APILog.Infof("Spec file added / modified: %s", file)
+	if changeLog.allow() {
+		logger.APILog.Infof("Spec file added / modified: %s", file)
+	}
 
 	details := s.getParsedSpecs([]string{file})
 	s.specsCache.mutex.Lock()
+	_, exists := s.specsCache.specDetails[file]
 	s.addToSpecsCache(file, details[0])
 	s.specsCache.mutex.Unlock()
 
@@ -339,13 +406,20 @@ func (s *SpecInfoGatherer) OnSpecFileModify(file string) {
 	s.tagsCache.mutex.Lock()
 	s.updateTagsCacheFromSpecs(file, details[0])
 	s.tagsCache.mutex.Unlock()
+
+	if exists {
+		s.publish(SpecEvent{Kind: Modified, Payload: details[0].Spec})
+	} else {
+		s.publish(SpecEvent{Kind: Added, Payload: details[0].Spec})
+	}
 }
 
 func (s *SpecInfoGatherer) OnConceptFileModify(file string) {
+	if changeLog.allow() {
+		logger.APILog.Infof("Concept file added / modified: %s", file)
+	}
 	s.conceptsCache.mutex.Lock()
-	defer s.conceptsCache.mutex.Unlock()
-
-	logger.APILog.Infof("Concept file added / modified: %s", file)
+	_, exists := s.conceptsCache.concepts[file]
 	s.deleteFromConceptDictionary(file)
 	concepts, parseErrors, err := parser.AddConcepts([]string{file}, s.conceptDictionary)
 	if err != nil {
@@ -358,43 +432,80 @@ func (s *SpecInfoGatherer) OnConceptFileModify(file string) {
 		handleParseFailures([]*parser.ParseResult{res})
 	}
 	s.conceptsCache.concepts[file] = make([]*gauge.Concept, 0)
+	var stepsFromConcepts []*gauge.Step
 	for _, concept := range concepts {
 		c := gauge.Concept{ConceptStep: concept, FileName: file}
 		s.addToConceptsCache(file, &c)
-		stepsFromConcept := getStepsFromConcept(&c)
-		s.addToStepsCache(file, stepsFromConcept)
+		stepsFromConcepts = append(stepsFromConcepts, getStepsFromConcept(&c)...)
 	}
+	s.stepsCache.mutex.Lock()
+	s.addToStepsCache(file, stepsFromConcepts)
+	s.stepsCache.mutex.Unlock()
 	s.paramsCache.mutex.Lock()
-	defer s.paramsCache.mutex.Unlock()
 	s.updateParamsCacheFromConcepts(file, s.conceptsCache.concepts[file])
+	s.paramsCache.mutex.Unlock()
+
+	changed := s.conceptsCache.concepts[file]
+	s.conceptsCache.mutex.Unlock()
+
+	kind := Added
+	if exists {
+		kind = Modified
+	}
+	for _, c := range changed {
+		s.publish(SpecEvent{Kind: kind, Payload: c})
+	}
 }
 
 func (s *SpecInfoGatherer) onSpecFileRemove(file string) {
-	logger.APILog.Infof("Spec file removed: %s", file)
+	if changeLog.allow() {
+		logger.APILog.Infof("Spec file removed: %s", file)
+	}
 	s.specsCache.mutex.Lock()
-	defer s.specsCache.mutex.Unlock()
+	detail, exists := s.specsCache.specDetails[file]
 	delete(s.specsCache.specDetails, file)
+	delete(s.specsCache.modTimes, file)
+	s.specsCache.mutex.Unlock()
 	s.removeStepsFromCache(file)
+	s.paramsCache.mutex.Lock()
+	delete(s.paramsCache.staticParams, file)
+	delete(s.paramsCache.dynamicParams, file)
+	s.paramsCache.mutex.Unlock()
+	s.tagsCache.mutex.Lock()
+	delete(s.tagsCache.tags, file)
+	s.tagsCache.mutex.Unlock()
+	if exists {
+		s.publish(SpecEvent{Kind: Removed, Payload: detail.Spec})
+	}
 }
 func (s *SpecInfoGatherer) removeStepsFromCache(fileName string) {
 	s.stepsCache.mutex.Lock()
 	defer s.stepsCache.mutex.Unlock()
-	delete(s.stepsCache.steps, fileName)
+	s.stepsCache.remove(fileName)
 }
 
 func (s *SpecInfoGatherer) onConceptFileRemove(file string) {
-	logger.APILog.Infof("Concept file removed: %s", file)
+	if changeLog.allow() {
+		logger.APILog.Infof("Concept file removed: %s", file)
+	}
 	s.conceptsCache.mutex.Lock()
-	defer s.conceptsCache.mutex.Unlock()
-	for _, c := range s.conceptsCache.concepts[file] {
+	removed := s.conceptsCache.concepts[file]
+	for _, c := range removed {
 		delete(s.conceptDictionary.ConceptsMap, c.ConceptStep.Value)
 	}
 	delete(s.conceptsCache.concepts, file)
+	s.conceptsCache.mutex.Unlock()
+
+	for _, c := range removed {
+		s.publish(SpecEvent{Kind: Removed, Payload: c})
+	}
 }
 
 func (s *SpecInfoGatherer) onFileAdd(watcher *fsnotify.Watcher, file string) {
 	if util.IsDir(file) {
 		addDirToFileWatcher(watcher, file)
+	} else if s.WatchFiles {
+		addFileToWatcher(watcher, file)
 	}
 	s.onFileModify(watcher, file)
 }
@@ -402,14 +513,43 @@ func (s *SpecInfoGatherer) onFileAdd(watcher *fsnotify.Watcher, file string) {
 func (s *SpecInfoGatherer) onFileModify(watcher *fsnotify.Watcher, file string) {
 	if util.IsSpec(file) {
 		s.OnSpecFileModify(file)
+		s.onIncludedSpecChange(file)
 	} else if util.IsConcept(file) {
 		s.OnConceptFileModify(file)
 	}
 }
 
+// onIncludedSpecChange parses again the specs which include the given spec, so that they pick up its context steps.
+func (s *SpecInfoGatherer) onIncludedSpecChange(file string) {
+	var includingSpecs []string
+	s.specsCache.mutex.RLock()
+	for specFile, detail := range s.specsCache.specDetails {
+		if detail == nil || detail.Spec == nil || specFile == file {
+			continue
+		}
+		for _, included := range detail.Spec.Includes {
+			if included == file {
+				includingSpecs = append(includingSpecs, specFile)
+				break
+			}
+		}
+	}
+	s.specsCache.mutex.RUnlock()
+	sort.Strings(includingSpecs)
+	for _, specFile := range includingSpecs {
+		s.OnSpecFileModify(specFile)
+	}
+}
+
 func (s *SpecInfoGatherer) onFileRemove(watcher *fsnotify.Watcher, file string) {
+	if s.WatchFiles && !util.IsDir(file) && common.FileExists(file) {
+		// The file was replaced by an editor's save, so the watch on the old file is lost.
+		s.onFileAdd(watcher, file)
+		return
+	}
 	if util.IsSpec(file) {
 		s.onSpecFileRemove(file)
+		s.onIncludedSpecChange(file)
 	} else if util.IsConcept(file) {
 		s.onConceptFileRemove(file)
 	} else {
@@ -429,7 +569,10 @@ func (s *SpecInfoGatherer) handleEvent(event fsnotify.Event, watcher *fsnotify.W
 		logger.APILog.Errorf("Failed to get abs file path for %s: %s", event.Name, err)
 		return
 	}
-	if util.IsSpec(file) || util.IsConcept(file) || util.IsDir(file) {
+	if util.IsGaugeFile(file) || util.IsDir(file) {
+		if s.deferIfPaused(file) {
+			return
+		}
 		switch event.Op {
 		case fsnotify.Create:
 			s.onFileAdd(watcher, file)
@@ -464,37 +607,274 @@ func (s *SpecInfoGatherer) watchForFileChanges() {
 		}
 	}()
 
-	var allDirsToWatch []string
-	var specDir string
+	s.dirsMutex.Lock()
+	s.watcher = watcher
+	for _, path := range s.pathsToWatch(s.SpecDirs) {
+		addPathToWatcher(watcher, path)
+	}
+	s.dirsMutex.Unlock()
+	s.waitGroup.Done()
+	<-done
+}
 
-	for _, dir := range s.SpecDirs {
-		specDir = filepath.Join(config.ProjectRoot, dir)
+func dirsToWatch(specDirs []string) []string {
+	var allDirsToWatch []string
+	for _, dir := range specDirs {
+		specDir := filepath.Join(config.ProjectRoot, dir)
 		allDirsToWatch = append(allDirsToWatch, specDir)
-		allDirsToWatch = append(allDirsToWatch, util.FindAllNestedDirs(specDir)...)
+		nestedDirs, err := util.FindAllNestedDirs(specDir, util.DefaultMaxNestedDirDepth)
+		if err != nil {
+			logger.APILog.Warning(err.Error())
+		}
+		allDirsToWatch = append(allDirsToWatch, nestedDirs...)
+	}
+	return allDirsToWatch
+}
+
+func (s *SpecInfoGatherer) pathsToWatch(specDirs []string) []string {
+	paths := dirsToWatch(specDirs)
+	if s.WatchFiles {
+		paths = append(paths, filesToWatch(specDirs)...)
 	}
+	return paths
+}
 
-	for _, dir := range allDirsToWatch {
-		addDirToFileWatcher(watcher, dir)
+func filesToWatch(specDirs []string) []string {
+	var files []string
+	for _, dir := range specDirs {
+		specDir := filepath.Join(config.ProjectRoot, dir)
+		files = append(files, util.FindSpecFilesIn(specDir)...)
+		files = append(files, util.FindConceptFilesIn(specDir)...)
 	}
-	s.waitGroup.Done()
-	<-done
+	return files
+}
+
+// UpdateSpecDirs changes the directories from which specs are gathered.
+// Watches on directories which are no longer included are removed and the new directories are watched.
+// Specs from the removed directories are evicted from the caches and specs from the new directories are added.
+func (s *SpecInfoGatherer) UpdateSpecDirs(dirs []string) error {
+	for _, dir := range dirs {
+		if !util.IsDir(dir) && !util.IsDir(filepath.Join(config.ProjectRoot, dir)) {
+			return fmt.Errorf("Spec directory %s does not exist", dir)
+		}
+	}
+	s.dirsMutex.Lock()
+	defer s.dirsMutex.Unlock()
+
+	if s.watcher != nil {
+		oldPaths := make(map[string]bool)
+		for _, path := range s.pathsToWatch(s.SpecDirs) {
+			oldPaths[path] = true
+		}
+		newPaths := make(map[string]bool)
+		for _, path := range s.pathsToWatch(dirs) {
+			newPaths[path] = true
+			if !oldPaths[path] {
+				addPathToWatcher(s.watcher, path)
+			}
+		}
+		for path := range oldPaths {
+			if !newPaths[path] {
+				removeWatcherOn(s.watcher, path)
+			}
+		}
+	}
+	s.SpecDirs = dirs
+
+	newFiles := make(map[string]bool)
+	for _, file := range getSpecFiles(dirs) {
+		newFiles[file] = true
+	}
+	var removed, added []string
+	s.specsCache.mutex.RLock()
+	for file := range s.specsCache.specDetails {
+		if !newFiles[file] {
+			removed = append(removed, file)
+		}
+	}
+	for file := range newFiles {
+		if _, ok := s.specsCache.specDetails[file]; !ok {
+			added = append(added, file)
+		}
+	}
+	s.specsCache.mutex.RUnlock()
+
+	for _, file := range removed {
+		s.onSpecFileRemove(file)
+	}
+	for _, file := range added {
+		s.OnSpecFileModify(file)
+	}
+	return nil
 }
 
-// GetAvailableSpecs returns the list of all the specs in the gauge project
+// GetAvailableSpecDetails returns the details of the given spec files or directories.
+// An empty list means all the cached specs, i.e. the specs in SpecDirs, sorted by file.
+// Each detail carries the parse errors of the spec and whether its concepts are resolved with the current concepts.
 func (s *SpecInfoGatherer) GetAvailableSpecDetails(specs []string) []*SpecDetail {
+	s.specsCache.mutex.RLock()
+	var details []*SpecDetail
 	if len(specs) < 1 {
-		specs = []string{common.SpecsDirectoryName}
+		for _, d := range s.specsCache.specDetails {
+			details = append(details, d)
+		}
+		sort.Slice(details, func(i, j int) bool { return details[i].File < details[j].File })
+	} else {
+		for _, f := range getSpecFiles(specs) {
+			if d, ok := s.specsCache.specDetails[f]; ok {
+				details = append(details, d)
+			}
+		}
 	}
-	specFiles := getSpecFiles(specs)
-	s.specsCache.mutex.RLock()
-	defer s.specsCache.mutex.RUnlock()
+	s.specsCache.mutex.RUnlock()
+	return s.withConceptsResolved(details)
+}
+
+// GetSpecsByTag returns the details of the cached specs which have any of the given tags, on the spec or on one of its scenarios.
+// Only the matching specs are copied, so this is cheaper than GetAvailableSpecDetails for a large project.
+func (s *SpecInfoGatherer) GetSpecsByTag(tags []string) []*SpecDetail {
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		wanted[tag] = true
+	}
+	var files []string
+	s.tagsCache.mutex.RLock()
+	for file, fileTags := range s.tagsCache.tags {
+		for _, tag := range fileTags {
+			if wanted[tag] {
+				files = append(files, file)
+				break
+			}
+		}
+	}
+	s.tagsCache.mutex.RUnlock()
+	sort.Strings(files)
+
 	var details []*SpecDetail
-	for _, f := range specFiles {
-		if d, ok := s.specsCache.specDetails[f]; ok {
+	s.specsCache.mutex.RLock()
+	for _, file := range files {
+		if d, ok := s.specsCache.specDetails[file]; ok {
 			details = append(details, d)
 		}
 	}
-	return details
+	s.specsCache.mutex.RUnlock()
+	return s.withConceptsResolved(details)
+}
+
+// withConceptsResolved returns copies of the details with ConceptsResolved set for the current concepts.
+func (s *SpecInfoGatherer) withConceptsResolved(details []*SpecDetail) []*SpecDetail {
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
+	result := make([]*SpecDetail, 0, len(details))
+	for _, d := range details {
+		detail := *d
+		detail.ConceptsResolved = s.conceptsResolved(d.Spec)
+		result = append(result, &detail)
+	}
+	return result
+}
+
+// GetFilteredSpecs returns copies of the cached specs holding only the scenarios which match the tag expression.
+// Specs without any matching scenario are left out. The cached specs are not modified.
+func (s *SpecInfoGatherer) GetFilteredSpecs(tagExpr string) []*gauge.Specification {
+	specs := make([]*gauge.Specification, 0)
+	for _, d := range s.GetAvailableSpecDetails([]string{}) {
+		if d.Spec == nil {
+			continue
+		}
+		spec := d.Spec.Clone()
+		spec.Filter(filter.NewTagFilter(spec, tagExpr))
+		if len(spec.Scenarios) > 0 {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+func (s *SpecInfoGatherer) conceptsResolved(spec *gauge.Specification) bool {
+	if spec == nil || s.conceptDictionary == nil {
+		return true
+	}
+	var steps []*gauge.Step
+	steps = append(steps, spec.Contexts...)
+	steps = append(steps, spec.TearDownSteps...)
+	for _, scenario := range spec.Scenarios {
+		steps = append(steps, scenario.Steps...)
+		steps = append(steps, scenario.TearDownSteps...)
+	}
+	return s.stepsResolved(steps)
+}
+
+func (s *SpecInfoGatherer) stepsResolved(steps []*gauge.Step) bool {
+	for _, step := range steps {
+		if step.IsConcept != (s.conceptDictionary.Search(step.Value) != nil) {
+			return false
+		}
+		if step.IsConcept && !s.stepsResolved(step.ConceptSteps) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetSpecForFile returns the cached spec for the given file. Returns false if the file is not cached.
+func (s *SpecInfoGatherer) GetSpecForFile(file string) (*gauge.Specification, bool) {
+	f, err := filepath.Abs(file)
+	if err != nil {
+		return nil, false
+	}
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	d, ok := s.specsCache.specDetails[f]
+	if !ok || d.Spec == nil {
+		return nil, false
+	}
+	return d.Spec, true
+}
+
+// ResolveExecutionIdentifier returns the cached scenario, and its spec, which an execution identifier of the form file:line
+// points at. The identifier may also use a file uri. Returns false if the identifier is malformed, the file is not cached,
+// or no scenario spans the line, e.g. because the scenario was removed after the identifier was saved.
+func (s *SpecInfoGatherer) ResolveExecutionIdentifier(id string) (*gauge.Scenario, *gauge.Specification, bool) {
+	id = util.NormalizeExecutionIdentifier(id)
+	i := strings.LastIndex(id, ":")
+	if i <= 0 {
+		return nil, nil, false
+	}
+	line, err := strconv.Atoi(id[i+1:])
+	if err != nil || line < 1 {
+		return nil, nil, false
+	}
+	spec, ok := s.GetSpecForFile(id[:i])
+	if !ok {
+		return nil, nil, false
+	}
+	for _, scenario := range spec.Scenarios {
+		if scenario.Span != nil && scenario.InSpan(line) {
+			return scenario, spec, true
+		}
+	}
+	return nil, nil, false
+}
+
+// DiffWithContent parses the given content of a spec file, like an unsaved editor buffer, and returns how it differs
+// from the cached version of the file. A file which is not cached is compared against an empty spec.
+func (s *SpecInfoGatherer) DiffWithContent(file, content string) (gauge.SpecDiff, error) {
+	s.conceptsCache.mutex.RLock()
+	conceptDictionary := s.conceptDictionary
+	if conceptDictionary == nil {
+		conceptDictionary = gauge.NewConceptDictionary()
+	}
+	spec, res, err := new(parser.SpecParser).Parse(content, conceptDictionary, file)
+	s.conceptsCache.mutex.RUnlock()
+	if err != nil {
+		return gauge.SpecDiff{}, err
+	}
+	if !res.Ok {
+		return gauge.SpecDiff{}, fmt.Errorf("failed to parse %s: %s", file, res.ParseErrors[0].Error())
+	}
+	cached, _ := s.GetSpecForFile(file)
+	return cached.Diff(spec), nil
 }
 
 // Steps returns the list of all the steps in the gauge project. Duplicate steps are filtered
@@ -566,15 +946,138 @@ func (s *SpecInfoGatherer) Tags() []string {
 	return removeDuplicateTags(allTags)
 }
 
+// GetTagCloud returns the number of times each tag is used across all the specs and scenarios.
+// The returned map is a snapshot and is not updated when the specs change.
+func (s *SpecInfoGatherer) GetTagCloud() map[string]int {
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	tagCloud := make(map[string]int)
+	for _, detail := range s.specsCache.specDetails {
+		if detail.Spec == nil {
+			continue
+		}
+		if detail.Spec.Tags != nil {
+			for _, tag := range detail.Spec.Tags.Values() {
+				tagCloud[tag]++
+			}
+		}
+		for _, sce := range detail.Spec.Scenarios {
+			if sce.Tags != nil {
+				for _, tag := range sce.Tags.Values() {
+					tagCloud[tag]++
+				}
+			}
+		}
+	}
+	return tagCloud
+}
+
+// GetLastModifiedSpecs returns the cached specs whose files were modified after the given time.
+// Modification times are recorded when a spec is parsed, so the files are not read again.
+func (s *SpecInfoGatherer) GetLastModifiedSpecs(since time.Time) []*gauge.Specification {
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	var files []string
+	for file, modTime := range s.specsCache.modTimes {
+		if modTime.After(since) {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	var specs []*gauge.Specification
+	for _, file := range files {
+		if d, ok := s.specsCache.specDetails[file]; ok && d.Spec != nil {
+			specs = append(specs, d.Spec)
+		}
+	}
+	return specs
+}
+
+// GetSpecDependencies returns the headings of all the concepts used by each spec, keyed by the spec file.
+// Concepts used within other concepts are included as well.
+func (s *SpecInfoGatherer) GetSpecDependencies() map[string][]string {
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
+	dependencies := make(map[string][]string)
+	if s.conceptDictionary == nil {
+		return dependencies
+	}
+	for file, detail := range s.specsCache.specDetails {
+		if detail.Spec == nil {
+			continue
+		}
+		used := make(map[string]bool)
+		s.collectConcepts(specSteps(detail.Spec), used)
+		var headings []string
+		for heading := range used {
+			headings = append(headings, heading)
+		}
+		sort.Strings(headings)
+		dependencies[file] = headings
+	}
+	return dependencies
+}
+
+// collectConcepts adds the concepts used by the steps to used. The caller must hold the read lock of the concepts cache.
+func (s *SpecInfoGatherer) collectConcepts(steps []*gauge.Step, used map[string]bool) {
+	for _, step := range steps {
+		concept := s.conceptDictionary.Search(step.Value)
+		if concept == nil || used[concept.ConceptStep.LineText] {
+			continue
+		}
+		used[concept.ConceptStep.LineText] = true
+		s.collectConcepts(concept.ConceptStep.ConceptSteps, used)
+	}
+}
+
+// GetUnusedConcepts returns the concepts which are not used by any spec, sorted by file and line number.
+// A concept used only by other concepts counts as used only if one of those concepts is used by a spec.
+func (s *SpecInfoGatherer) GetUnusedConcepts() []*gauge_messages.ConceptInfo {
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
+	used := make(map[string]bool)
+	if s.conceptDictionary != nil {
+		for _, detail := range s.specsCache.specDetails {
+			if detail.Spec != nil {
+				s.collectConcepts(specSteps(detail.Spec), used)
+			}
+		}
+	}
+	var unused []*gauge_messages.ConceptInfo
+	for _, concepts := range s.conceptsCache.concepts {
+		for _, concept := range concepts {
+			if used[concept.ConceptStep.LineText] {
+				continue
+			}
+			stepValue := parser.CreateStepValue(concept.ConceptStep)
+			unused = append(unused, &gauge_messages.ConceptInfo{StepValue: gauge.ConvertToProtoStepValue(&stepValue), Filepath: concept.FileName, LineNumber: int32(concept.ConceptStep.LineNo)})
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].Filepath != unused[j].Filepath {
+			return unused[i].Filepath < unused[j].Filepath
+		}
+		return unused[i].LineNumber < unused[j].LineNumber
+	})
+	return unused
+}
+
 // SearchConceptDictionary searches for a concept in concept dictionary
 func (s *SpecInfoGatherer) SearchConceptDictionary(stepValue string) *gauge.Concept {
 	return s.conceptDictionary.Search(stepValue)
 }
 
+// getStepsFromSpec returns the steps written in the spec itself. Steps of the concepts used by the spec
+// are cached under the concept file, so they are not repeated here for every usage of the concept.
 func getStepsFromSpec(spec *gauge.Specification) []*gauge.Step {
 	steps := filterConcepts(spec.Contexts)
 	for _, scenario := range spec.Scenarios {
 		steps = append(steps, filterConcepts(scenario.Steps)...)
+		steps = append(steps, filterConcepts(scenario.TearDownSteps)...)
 	}
 	steps = append(steps, filterConcepts(spec.TearDownSteps)...)
 	return steps
@@ -606,14 +1109,40 @@ func addDirToFileWatcher(watcher *fsnotify.Watcher, dir string) {
 	err := watcher.Add(dir)
 	if err != nil {
 		logger.APILog.Errorf("Unable to add directory %v to file watcher: %s", dir, err)
-	} else {
+	} else if changeLog.allow() {
 		logger.APILog.Infof("Watching directory: %s", dir)
 		files, _ := ioutil.ReadDir(dir)
 		logger.APILog.Debugf("Found %d files", len(files))
 	}
 }
 
+func addPathToWatcher(watcher *fsnotify.Watcher, path string) {
+	if util.IsDir(path) {
+		addDirToFileWatcher(watcher, path)
+	} else {
+		addFileToWatcher(watcher, path)
+	}
+}
+
+func addFileToWatcher(watcher *fsnotify.Watcher, file string) {
+	if err := watcher.Add(file); err != nil {
+		logger.APILog.Errorf("Unable to add file %v to file watcher: %s", file, err)
+	}
+}
+
 func removeWatcherOn(watcher *fsnotify.Watcher, path string) {
 	logger.APILog.Infof("Removing watcher on : %s", path)
 	watcher.Remove(path)
 }
+
+// GetConceptFiles returns the sorted paths of the concept files whose concepts are cached.
+func (s *SpecInfoGatherer) GetConceptFiles() []string {
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
+	files := make([]string, 0, len(s.conceptsCache.concepts))
+	for file := range s.conceptsCache.concepts {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
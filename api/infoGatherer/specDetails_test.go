@@ -22,10 +22,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	. "gopkg.in/check.v1"
 )
 
@@ -357,6 +360,28 @@ func (s *MySuite) TestGetAvailableSpecDetails(c *C) {
 	c.Assert(details[0].Spec.Heading.Value, Equals, "Specification Heading")
 }
 
+func (s *MySuite) TestGetSpecsByTag(c *C) {
+	sig := &SpecInfoGatherer{
+		specsCache: specsCache{specDetails: map[string]*SpecDetail{
+			"a.spec": {Spec: &gauge.Specification{Heading: &gauge.Heading{Value: "A"}}, File: "a.spec"},
+			"b.spec": {Spec: &gauge.Specification{Heading: &gauge.Heading{Value: "B"}}, File: "b.spec"},
+			"c.spec": {Spec: &gauge.Specification{Heading: &gauge.Heading{Value: "C"}}, File: "c.spec"},
+		}},
+		tagsCache: tagsCache{tags: map[string][]string{
+			"a.spec": {"smoke", "login"},
+			"b.spec": {"regression"},
+			"c.spec": {"login"},
+		}},
+	}
+
+	details := sig.GetSpecsByTag([]string{"login", "unknown"})
+
+	c.Assert(len(details), Equals, 2)
+	c.Assert(details[0].File, Equals, "a.spec")
+	c.Assert(details[1].File, Equals, "c.spec")
+	c.Assert(len(sig.GetSpecsByTag([]string{"unknown"})), Equals, 0)
+}
+
 func (s *MySuite) TestGetAvailableSpecDetailsInDefaultDir(c *C) {
 	_, err := createFileIn(s.specsDir, "spec1.spec", spec1)
 	c.Assert(err, Equals, nil)
@@ -383,6 +408,54 @@ func (s *MySuite) TestGetAvailableSpecDetailsWithEmptyCache(c *C) {
 	c.Assert(len(details), Equals, 0)
 }
 
+func (s *MySuite) TestGetAvailableSpecDetailsForAllAndGivenFiles(c *C) {
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	createFileIn(s.specsDir, "spec2.spec", spec2)
+	sig := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	sig.initConceptsCache()
+	sig.initSpecsCache()
+	specFiles := util.FindSpecFilesIn(s.specsDir)
+
+	all := sig.GetAvailableSpecDetails([]string{})
+	filtered := sig.GetAvailableSpecDetails(specFiles[1:])
+
+	c.Assert(len(all), Equals, 2)
+	c.Assert(all[0].File < all[1].File, Equals, true)
+	for _, d := range all {
+		c.Assert(d.HasSpec(), Equals, true)
+		c.Assert(d.ConceptsResolved, Equals, true)
+	}
+	c.Assert(len(filtered), Equals, 1)
+	c.Assert(filtered[0].File, Equals, specFiles[1])
+}
+
+func (s *MySuite) TestGetAvailableSpecDetailsHasParseErrors(c *C) {
+	createFileIn(s.specsDir, "invalid.spec", []byte("* step without a spec heading\n"))
+	sig := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	sig.initSpecsCache()
+
+	details := sig.GetAvailableSpecDetails([]string{})
+
+	c.Assert(len(details), Equals, 1)
+	c.Assert(len(details[0].Errs) > 0, Equals, true)
+}
+
+func (s *MySuite) TestGetAvailableSpecDetailsWhenConceptAddedAfterParsing(c *C) {
+	createFileIn(s.specsDir, "spec.spec", []byte("# Spec\n## Scenario\n* foo bar\n"))
+	sig := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	sig.initConceptsCache()
+	sig.initSpecsCache()
+	sig.initStepsCache()
+	sig.initParamsCache()
+	c.Assert(sig.GetAvailableSpecDetails([]string{})[0].ConceptsResolved, Equals, true)
+
+	file, _ := createFileIn(s.specsDir, "concept1.cpt", concept1)
+	file, _ = filepath.Abs(file)
+	sig.OnConceptFileModify(file)
+
+	c.Assert(sig.GetAvailableSpecDetails([]string{})[0].ConceptsResolved, Equals, false)
+}
+
 func (s *MySuite) TestParamsForStepFile(c *C) {
 	file, _ := createFileIn(s.specsDir, "spec3.spec", spec3)
 	file, _ = filepath.Abs(file)
@@ -481,3 +554,539 @@ func createDirIn(dir string, dirName string) (string, error) {
 	err = os.Rename(tempDir, fullDirName)
 	return fullDirName, err
 }
+
+func (s *MySuite) TestGetSpecForFile(c *C) {
+	f, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	f, _ = filepath.Abs(f)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	spec, ok := specInfoGatherer.GetSpecForFile(f)
+
+	c.Assert(ok, Equals, true)
+	c.Assert(spec.FileName, Equals, f)
+	c.Assert(spec.Heading.Value, Equals, "Specification Heading")
+}
+
+func (s *MySuite) TestResolveExecutionIdentifier(c *C) {
+	f, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	f, _ = filepath.Abs(f)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	for _, id := range []string{f + ":3", f + ":5", string(util.ConvertPathToURI(lsp.DocumentURI(f))) + ":3"} {
+		scenario, spec, ok := specInfoGatherer.ResolveExecutionIdentifier(id)
+
+		c.Assert(ok, Equals, true, Commentf(id))
+		c.Assert(scenario.Heading.Value, Equals, "Scenario 1")
+		c.Assert(spec.FileName, Equals, f)
+	}
+}
+
+func (s *MySuite) TestResolveStaleExecutionIdentifier(c *C) {
+	f, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	f, _ = filepath.Abs(f)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+
+	createFileIn(s.specsDir, "spec1.spec", []byte("Specification Heading\n=====================\n* say hello\n"))
+	specInfoGatherer.OnSpecFileModify(f)
+
+	_, _, ok := specInfoGatherer.ResolveExecutionIdentifier(f + ":3")
+	c.Assert(ok, Equals, false)
+	_, _, ok = specInfoGatherer.ResolveExecutionIdentifier(filepath.Join(s.specsDir, "unknown.spec") + ":3")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestResolveMalformedExecutionIdentifier(c *C) {
+	f, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	f, _ = filepath.Abs(f)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	for _, id := range []string{f, f + ":", f + ":abc", f + ":0", ":3", ""} {
+		_, _, ok := specInfoGatherer.ResolveExecutionIdentifier(id)
+
+		c.Assert(ok, Equals, false, Commentf(id))
+	}
+}
+
+func (s *MySuite) TestGetSpecForFileWhichIsNotCached(c *C) {
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	spec, ok := specInfoGatherer.GetSpecForFile(filepath.Join(s.specsDir, "unknown.spec"))
+
+	c.Assert(ok, Equals, false)
+	c.Assert(spec, IsNil)
+}
+
+func (s *MySuite) TestAllStepsDoesNotRepeatStepsOfAConceptUsedTwice(c *C) {
+	specUsingConcept := []byte(`Specification Heading
+=====================
+Scenario 1
+----------
+* say hello
+* foo bar
+
+Scenario 2
+----------
+* foo bar
+`)
+	f, _ := createFileIn(s.specsDir, "spec.spec", specUsingConcept)
+	f, _ = filepath.Abs(f)
+	createFileIn(s.specsDir, "concept1.cpt", concept1)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+
+	stepsFromSpecsMap := specInfoGatherer.getStepsFromCachedSpecs()
+
+	c.Assert(len(stepsFromSpecsMap[f]), Equals, 1)
+	c.Assert(stepsFromSpecsMap[f][0].Value, Equals, "say hello")
+	c.Assert(len(specInfoGatherer.AllSteps()), Equals, 4)
+}
+
+func (s *MySuite) TestOnSpecFileModifyRemovesStepsOfOldVersion(c *C) {
+	file, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	file, _ = filepath.Abs(file)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+
+	createFileIn(s.specsDir, "spec1.spec", []byte(`Specification Heading
+=====================
+Scenario 1
+----------
+* a new step
+`))
+	specInfoGatherer.OnSpecFileModify(file)
+
+	steps := specInfoGatherer.AllSteps()
+	c.Assert(len(steps), Equals, 1)
+	c.Assert(steps[0].Value, Equals, "a new step")
+}
+
+func (s *MySuite) TestModifyingIncludedSpecUpdatesIncludingSpecs(c *C) {
+	shared, _ := createFileIn(s.specsDir, "shared.spec", []byte(`# Shared
+* login
+`))
+	shared, _ = filepath.Abs(shared)
+	main, _ := createFileIn(s.specsDir, "main.spec", []byte(`# Main
+## include: shared.spec
+
+## Scenario
+* a step
+`))
+	main, _ = filepath.Abs(main)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	c.Assert(len(specInfoGatherer.specsCache.specDetails[main].Spec.Contexts), Equals, 1)
+
+	createFileIn(s.specsDir, "shared.spec", []byte(`# Shared
+* login
+* open dashboard
+`))
+	specInfoGatherer.onFileModify(nil, shared)
+
+	contexts := specInfoGatherer.specsCache.specDetails[main].Spec.Contexts
+	c.Assert(len(contexts), Equals, 2)
+	c.Assert(contexts[1].Value, Equals, "open dashboard")
+}
+
+func (s *MySuite) TestOnConceptFileModifyRemovesStepsOfOldVersion(c *C) {
+	file, _ := createFileIn(s.specsDir, "concept.cpt", append(append([]byte{}, concept1...), concept2...))
+	file, _ = filepath.Abs(file)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+
+	specInfoGatherer.OnConceptFileModify(file)
+	c.Assert(len(specInfoGatherer.AllSteps()), Equals, 6)
+
+	createFileIn(s.specsDir, "concept.cpt", []byte(`# new concept
+* a new step
+`))
+	specInfoGatherer.OnConceptFileModify(file)
+
+	steps := specInfoGatherer.AllSteps()
+	c.Assert(len(steps), Equals, 1)
+	c.Assert(steps[0].Value, Equals, "a new step")
+}
+
+func (s *MySuite) TestGetTagCloud(c *C) {
+	createFileIn(s.specsDir, "specWithTags.spec", specWithTags)
+	createFileIn(s.specsDir, "spec2WithTags.spec", spec2WithTags)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	tagCloud := specInfoGatherer.GetTagCloud()
+
+	c.Assert(tagCloud, DeepEquals, map[string]int{
+		"foo":     2,
+		"bar":     1,
+		"hello":   1,
+		"another": 1,
+		"simple":  2,
+		"complex": 2,
+	})
+}
+
+func (s *MySuite) TestGetConceptFiles(c *C) {
+	f2, _ := createFileIn(s.specsDir, "concept2.cpt", concept2)
+	f1, _ := createFileIn(s.specsDir, "concept1.cpt", concept1)
+	f1, _ = filepath.Abs(f1)
+	f2, _ = filepath.Abs(f2)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+
+	files := specInfoGatherer.GetConceptFiles()
+
+	c.Assert(files, DeepEquals, []string{f1, f2})
+}
+
+func (s *MySuite) TestGetSpecDependencies(c *C) {
+	specUsingConcepts := []byte(`Specification Heading
+=====================
+Scenario 1
+----------
+* say hello
+* nested concept
+* bar
+`)
+	nestedConcept := []byte(`# nested concept
+* foo bar
+`)
+	f, _ := createFileIn(s.specsDir, "spec.spec", specUsingConcepts)
+	f, _ = filepath.Abs(f)
+	f1, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	f1, _ = filepath.Abs(f1)
+	createFileIn(s.specsDir, "concept1.cpt", concept1)
+	createFileIn(s.specsDir, "concept2.cpt", concept2)
+	createFileIn(s.specsDir, "nested.cpt", nestedConcept)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+
+	dependencies := specInfoGatherer.GetSpecDependencies()
+
+	c.Assert(dependencies[f], DeepEquals, []string{"bar", "foo bar", "nested concept"})
+	c.Assert(len(dependencies[f1]), Equals, 0)
+}
+
+func (s *MySuite) TestGetUnusedConcepts(c *C) {
+	specUsingConcepts := []byte(`Specification Heading
+=====================
+Scenario 1
+----------
+* nested concept
+`)
+	concepts := []byte(`# nested concept
+* foo bar
+
+# orphan
+* bar
+`)
+	createFileIn(s.specsDir, "spec.spec", specUsingConcepts)
+	createFileIn(s.specsDir, "concept1.cpt", concept1)
+	createFileIn(s.specsDir, "concept2.cpt", concept2)
+	createFileIn(s.specsDir, "nested.cpt", concepts)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+
+	unused := specInfoGatherer.GetUnusedConcepts()
+
+	var values []string
+	for _, concept := range unused {
+		values = append(values, concept.StepValue.ParameterizedStepValue)
+	}
+	c.Assert(values, DeepEquals, []string{"bar", "orphan"})
+}
+
+func (s *MySuite) TestGetLastModifiedSpecs(c *C) {
+	oldFile, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	newFile, _ := createFileIn(s.specsDir, "spec2.spec", spec2)
+	newFile, _ = filepath.Abs(newFile)
+	since := time.Now().Add(-time.Minute)
+	os.Chtimes(oldFile, since.Add(-time.Hour), since.Add(-time.Hour))
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	specs := specInfoGatherer.GetLastModifiedSpecs(since)
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(specs[0].FileName, Equals, newFile)
+}
+
+func (s *MySuite) TestGetLastModifiedSpecsDoesNotIncludeRemovedSpecs(c *C) {
+	file, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	file, _ = filepath.Abs(file)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+
+	specInfoGatherer.onSpecFileRemove(file)
+
+	c.Assert(len(specInfoGatherer.GetLastModifiedSpecs(time.Time{})), Equals, 0)
+}
+
+func (s *MySuite) TestUpdateSpecDirs(c *C) {
+	oldDir := filepath.Join(s.projectDir, "old")
+	newDir := filepath.Join(s.projectDir, "new")
+	oldFile, _ := createFileIn(oldDir, "spec1.spec", spec1)
+	oldFile, _ = filepath.Abs(oldFile)
+	newFile, _ := createFileIn(newDir, "specWithTags.spec", specWithTags)
+	newFile, _ = filepath.Abs(newFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{oldDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+
+	err := specInfoGatherer.UpdateSpecDirs([]string{newDir})
+
+	c.Assert(err, IsNil)
+	c.Assert(specInfoGatherer.SpecDirs, DeepEquals, []string{newDir})
+	_, ok := specInfoGatherer.GetSpecForFile(oldFile)
+	c.Assert(ok, Equals, false)
+	spec, ok := specInfoGatherer.GetSpecForFile(newFile)
+	c.Assert(ok, Equals, true)
+	c.Assert(spec.FileName, Equals, newFile)
+	c.Assert(len(specInfoGatherer.Tags()), Equals, 5)
+}
+
+func (s *MySuite) TestUpdateSpecDirsWithNonExistingDir(c *C) {
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	err := specInfoGatherer.UpdateSpecDirs([]string{filepath.Join(s.projectDir, "unknown")})
+
+	c.Assert(err, NotNil)
+	c.Assert(specInfoGatherer.SpecDirs, DeepEquals, []string{s.specsDir})
+	c.Assert(len(specInfoGatherer.GetAvailableSpecDetails([]string{s.specsDir})), Equals, 1)
+}
+
+func (s *MySuite) TestWatchFilesDetectsSaveWhichReplacesTheFile(c *C) {
+	specFile, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	specFile, _ = filepath.Abs(specFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}, WatchFiles: true}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+	watcher, err := fsnotify.NewWatcher()
+	c.Assert(err, IsNil)
+	defer watcher.Close()
+	addFileToWatcher(watcher, specFile)
+
+	tmpFile, _ := createFileIn(s.projectDir, "spec1.spec.tmp", []byte("Replaced Heading\n================\nScenario\n--------\n* a step\n"))
+	c.Assert(os.Rename(tmpFile, specFile), IsNil)
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-watcher.Events:
+			specInfoGatherer.handleEvent(event, watcher)
+		case <-timeout:
+			c.Fatal("Modification of the replaced spec file was not detected")
+		}
+		if spec, ok := specInfoGatherer.GetSpecForFile(specFile); ok && spec.Heading.Value == "Replaced Heading" {
+			return
+		}
+	}
+}
+
+func (s *MySuite) TestModifyingFilesTwiceDoesNotLeaveStaleEntries(c *C) {
+	specWithScenarioTags := []byte(`Specification Heading
+=====================
+Scenario with tags
+------------------
+tags: foo, bar
+* say hello
+`)
+	specFile, _ := createFileIn(s.specsDir, "spec.spec", specWithScenarioTags)
+	specFile, _ = filepath.Abs(specFile)
+	conceptFile, _ := createFileIn(s.specsDir, "concept1.cpt", concept1)
+	conceptFile, _ = filepath.Abs(conceptFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+
+	for i := 0; i < 2; i++ {
+		specInfoGatherer.OnSpecFileModify(specFile)
+		specInfoGatherer.OnConceptFileModify(conceptFile)
+	}
+
+	c.Assert(len(specInfoGatherer.GetAvailableSpecDetails([]string{s.specsDir})), Equals, 1)
+	c.Assert(len(specInfoGatherer.Concepts()), Equals, 1)
+	c.Assert(specInfoGatherer.tagsCache.tags[specFile], DeepEquals, []string{"foo", "bar"})
+}
+
+func (s *MySuite) TestMarkdownSpecIsCachedAndUpdatedLikeSpecFile(c *C) {
+	for _, name := range []string{"spec1.spec", "spec1.md"} {
+		file, _ := createFileIn(s.specsDir, name, spec1)
+		file, _ = filepath.Abs(file)
+		specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+		specInfoGatherer.initConceptsCache()
+		specInfoGatherer.initSpecsCache()
+		specInfoGatherer.initStepsCache()
+		specInfoGatherer.initParamsCache()
+		specInfoGatherer.initTagsCache()
+
+		spec, ok := specInfoGatherer.GetSpecForFile(file)
+		c.Assert(ok, Equals, true, Commentf("%s was not cached at init", name))
+		c.Assert(spec.Heading.Value, Equals, "Specification Heading")
+
+		createFileIn(s.specsDir, name, []byte("Modified Heading\n================\nScenario 1\n----------\n* a new step\n"))
+		specInfoGatherer.handleEvent(fsnotify.Event{Name: file, Op: fsnotify.Write}, nil)
+
+		spec, ok = specInfoGatherer.GetSpecForFile(file)
+		c.Assert(ok, Equals, true)
+		c.Assert(spec.Heading.Value, Equals, "Modified Heading", Commentf("%s was not updated on edit", name))
+		steps := specInfoGatherer.AllSteps()
+		c.Assert(len(steps), Equals, 1)
+		c.Assert(steps[0].Value, Equals, "a new step")
+		os.Remove(file)
+	}
+}
+
+func (s *MySuite) TestDiffWithContent(c *C) {
+	specFile, _ := createFileIn(s.specsDir, "spec1.spec", spec1)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	diff, err := specInfoGatherer.DiffWithContent(specFile, `Specification Heading
+=====================
+Scenario 1
+----------
+* say hello
+* say "bye" to me
+
+Scenario 2
+----------
+* say hello
+
+Scenario 3
+----------
+* say hello
+`)
+
+	c.Assert(err, IsNil)
+	c.Assert(diff.HeadingChanged, Equals, false)
+	c.Assert(len(diff.AddedScenarios), Equals, 2)
+	c.Assert(diff.AddedScenarios[0].Heading.Value, Equals, "Scenario 2")
+	c.Assert(diff.AddedScenarios[1].Heading.Value, Equals, "Scenario 3")
+	c.Assert(len(diff.RemovedScenarios), Equals, 0)
+	c.Assert(len(diff.ModifiedScenarios), Equals, 1)
+	c.Assert(diff.ModifiedScenarios[0].Heading.Value, Equals, "Scenario 1")
+}
+
+func (s *MySuite) TestDiffWithContentForFileNotCached(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	diff, err := specInfoGatherer.DiffWithContent(filepath.Join(s.specsDir, "new.spec"), string(spec1))
+
+	c.Assert(err, IsNil)
+	c.Assert(diff.HeadingChanged, Equals, true)
+	c.Assert(len(diff.AddedScenarios), Equals, 1)
+	c.Assert(len(diff.RemovedScenarios), Equals, 0)
+}
+
+func (s *MySuite) TestDiffWithContentWhichDoesNotParse(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	_, err := specInfoGatherer.DiffWithContent(filepath.Join(s.specsDir, "new.spec"), "* step without a heading")
+
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestGetFilteredSpecs(c *C) {
+	createFileIn(s.specsDir, "smoke.spec", []byte(`Smoke Spec
+==========
+Smoke scenario
+--------------
+tags: smoke
+* say hello
+
+Other scenario
+--------------
+tags: regression
+* say hello
+`))
+	createFileIn(s.specsDir, "spec1.spec", spec1)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initSpecsCache()
+
+	specs := specInfoGatherer.GetFilteredSpecs("smoke")
+
+	c.Assert(len(specs), Equals, 1)
+	c.Assert(len(specs[0].Scenarios), Equals, 1)
+	c.Assert(specs[0].Scenarios[0].Heading.Value, Equals, "Smoke scenario")
+	for _, d := range specInfoGatherer.GetAvailableSpecDetails([]string{}) {
+		if d.Spec.Heading.Value == "Smoke Spec" {
+			c.Assert(len(d.Spec.Scenarios), Equals, 2)
+		}
+	}
+}
+
+func (s *MySuite) TestFindStepUsagesDistinguishesStepsByArity(c *C) {
+	specFile, _ := createFileIn(s.specsDir, "users.spec", []byte(`Users
+=====
+Scenario
+--------
+* the user "bob"
+* the user "bob" with role "admin"
+* the user "alice"
+`))
+	specFile, _ = filepath.Abs(specFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+	specInfoGatherer.initParamsCache()
+	specInfoGatherer.initTagsCache()
+
+	oneArg := specInfoGatherer.FindStepUsages("the user {}", 1)
+	twoArgs := specInfoGatherer.FindStepUsages("the user {} with role {}", 2)
+
+	c.Assert(len(oneArg), Equals, 2)
+	c.Assert(oneArg[0].LineText, Equals, `the user "bob"`)
+	c.Assert(oneArg[1].LineText, Equals, `the user "alice"`)
+	c.Assert(len(twoArgs), Equals, 1)
+	c.Assert(twoArgs[0].LineText, Equals, `the user "bob" with role "admin"`)
+	c.Assert(len(specInfoGatherer.FindStepUsages("the user {}", 2)), Equals, 0)
+
+	createFileIn(s.specsDir, "users.spec", []byte(`Users
+=====
+Scenario
+--------
+* the user "bob" with role "admin"
+`))
+	specInfoGatherer.OnSpecFileModify(specFile)
+
+	c.Assert(len(specInfoGatherer.FindStepUsages("the user {}", 1)), Equals, 0)
+	c.Assert(len(specInfoGatherer.FindStepUsages("the user {} with role {}", 2)), Equals, 1)
+}
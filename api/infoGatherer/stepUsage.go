@@ -0,0 +1,101 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/getgauge/gauge/gauge"
+)
+
+const (
+	specUsage    = "spec"
+	conceptUsage = "concept"
+)
+
+// StepUsage holds all the places where a step is used in the project
+type StepUsage struct {
+	StepValue string          `json:"stepValue"`
+	Count     int             `json:"count"`
+	Usages    []StepUsageInfo `json:"usages"`
+}
+
+// StepUsageInfo is a single usage of a step. Kind is either "spec" or "concept".
+type StepUsageInfo struct {
+	File   string `json:"file"`
+	LineNo int    `json:"lineNo"`
+	Kind   string `json:"kind"`
+}
+
+// ExportStepUsage writes every unique step in the project along with its usages as a JSON array.
+// Steps are sorted by step value and usages by file and line number, so the output is stable across runs.
+func (s *SpecInfoGatherer) ExportStepUsage(w io.Writer) error {
+	usages := make(map[string]*StepUsage)
+	add := func(steps []*gauge.Step, file string, kind string) {
+		for _, step := range steps {
+			u, ok := usages[step.Value]
+			if !ok {
+				u = &StepUsage{StepValue: step.Value, Usages: make([]StepUsageInfo, 0)}
+				usages[step.Value] = u
+			}
+			u.Count++
+			u.Usages = append(u.Usages, StepUsageInfo{File: file, LineNo: step.LineNo, Kind: kind})
+		}
+	}
+
+	s.specsCache.mutex.RLock()
+	for file, detail := range s.specsCache.specDetails {
+		if detail.Spec != nil {
+			add(specSteps(detail.Spec), file, specUsage)
+		}
+	}
+	s.specsCache.mutex.RUnlock()
+
+	s.conceptsCache.mutex.RLock()
+	for file, concepts := range s.conceptsCache.concepts {
+		for _, concept := range concepts {
+			add(concept.ConceptStep.ConceptSteps, file, conceptUsage)
+		}
+	}
+	s.conceptsCache.mutex.RUnlock()
+
+	result := make([]*StepUsage, 0, len(usages))
+	for _, u := range usages {
+		sort.Slice(u.Usages, func(i, j int) bool {
+			if u.Usages[i].File == u.Usages[j].File {
+				return u.Usages[i].LineNo < u.Usages[j].LineNo
+			}
+			return u.Usages[i].File < u.Usages[j].File
+		})
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StepValue < result[j].StepValue })
+	return json.NewEncoder(w).Encode(result)
+}
+
+// specSteps returns the steps as written in the spec, without expanding concepts.
+func specSteps(spec *gauge.Specification) []*gauge.Step {
+	steps := append([]*gauge.Step{}, spec.Contexts...)
+	for _, scenario := range spec.Scenarios {
+		steps = append(steps, scenario.Steps...)
+		steps = append(steps, scenario.TearDownSteps...)
+	}
+	return append(steps, spec.TearDownSteps...)
+}
@@ -0,0 +1,64 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestExportStepUsage(c *C) {
+	specFile, _ := createFileIn(s.specsDir, "spec2.spec", spec2)
+	specFile, _ = filepath.Abs(specFile)
+	conceptFile, _ := createFileIn(s.specsDir, "concept1.cpt", concept1)
+	conceptFile, _ = filepath.Abs(conceptFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+
+	b := &bytes.Buffer{}
+	err := specInfoGatherer.ExportStepUsage(b)
+	c.Assert(err, IsNil)
+
+	var got []StepUsage
+	c.Assert(json.Unmarshal(b.Bytes(), &got), IsNil)
+	want := []StepUsage{
+		{StepValue: "a {} step", Count: 1, Usages: []StepUsageInfo{{File: conceptFile, LineNo: 4, Kind: "concept"}}},
+		{StepValue: "first step with {}", Count: 1, Usages: []StepUsageInfo{{File: conceptFile, LineNo: 2, Kind: "concept"}}},
+		{StepValue: "say hello", Count: 1, Usages: []StepUsageInfo{{File: specFile, LineNo: 5, Kind: "spec"}}},
+		{StepValue: "say {} to me", Count: 3, Usages: []StepUsageInfo{
+			{File: conceptFile, LineNo: 3, Kind: "concept"},
+			{File: specFile, LineNo: 6, Kind: "spec"},
+			{File: specFile, LineNo: 7, Kind: "spec"},
+		}},
+	}
+	c.Assert(got, DeepEquals, want)
+}
+
+func (s *MySuite) TestExportStepUsageWithEmptyCaches(c *C) {
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+
+	b := &bytes.Buffer{}
+	err := specInfoGatherer.ExportStepUsage(b)
+
+	c.Assert(err, IsNil)
+	c.Assert(b.String(), Equals, "[]\n")
+}
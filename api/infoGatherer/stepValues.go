@@ -0,0 +1,85 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+)
+
+// StepParam is a parameter of a step, as it appears in the step text.
+type StepParam struct {
+	Name string
+	Type gauge.ArgType
+}
+
+// StepValueDetail holds a step value along with its parameters, in the order they appear in the step.
+type StepValueDetail struct {
+	StepValue gauge.StepValue
+	Params    []StepParam
+}
+
+// StepValues returns the distinct step values used in the gauge project, sorted by step value.
+func (s *SpecInfoGatherer) StepValues() []*StepValueDetail {
+	var details []*StepValueDetail
+	for _, step := range s.Steps() {
+		detail := &StepValueDetail{StepValue: parser.CreateStepValue(step)}
+		for _, arg := range step.Args {
+			detail.Params = append(detail.Params, StepParam{Name: arg.ArgValue(), Type: arg.ArgType})
+		}
+		details = append(details, detail)
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].StepValue.StepValue < details[j].StepValue.StepValue
+	})
+	return details
+}
+
+// StepCompletionContext returns the step values whose parameterized text starts with the given prefix, ignoring case,
+// along with the longest prefix they have in common. An editor can extend the typed text to the common prefix, as all
+// the matches start with it. The common prefix is the whole step when there is a single match, and empty when
+// there is none.
+func (s *SpecInfoGatherer) StepCompletionContext(prefix string) (matches []*gauge.StepValue, commonPrefix string) {
+	lowerPrefix := strings.ToLower(prefix)
+	for _, detail := range s.StepValues() {
+		stepValue := detail.StepValue
+		if strings.HasPrefix(strings.ToLower(stepValue.ParameterizedStepValue), lowerPrefix) {
+			matches = append(matches, &stepValue)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, ""
+	}
+	commonPrefix = matches[0].ParameterizedStepValue
+	for _, match := range matches[1:] {
+		commonPrefix = longestCommonPrefix(commonPrefix, match.ParameterizedStepValue)
+	}
+	return matches, commonPrefix
+}
+
+func longestCommonPrefix(a, b string) string {
+	ra, rb := []rune(a), []rune(b)
+	i := 0
+	for i < len(ra) && i < len(rb) && ra[i] == rb[i] {
+		i++
+	}
+	return string(ra[:i])
+}
@@ -0,0 +1,87 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestStepValuesExposesParamsInOrder(c *C) {
+	createFileIn(s.specsDir, "params.spec", []byte(`Specification Heading
+=====================
+
+|name|city|
+|----|----|
+|john|pune|
+
+Scenario 1
+----------
+* say <name> from "home" in <city>
+`))
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+
+	stepValues := specInfoGatherer.StepValues()
+
+	c.Assert(len(stepValues), Equals, 1)
+	c.Assert(stepValues[0].StepValue.StepValue, Equals, "say {} from {} in {}")
+	c.Assert(stepValues[0].Params, DeepEquals, []StepParam{
+		{Name: "name", Type: gauge.Dynamic},
+		{Name: "home", Type: gauge.Static},
+		{Name: "city", Type: gauge.Dynamic},
+	})
+}
+
+func (s *MySuite) TestStepCompletionContext(c *C) {
+	createFileIn(s.specsDir, "steps.spec", []byte(`Specification Heading
+=====================
+
+Scenario 1
+----------
+* Go to the login page
+* Go to the logout page
+* Go to the home page
+* Say "hello" to "gauge"
+`))
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	specInfoGatherer.initStepsCache()
+
+	matches, commonPrefix := specInfoGatherer.StepCompletionContext("go to the lo")
+
+	c.Assert(len(matches), Equals, 2)
+	c.Assert(matches[0].StepValue, Equals, "Go to the login page")
+	c.Assert(matches[1].StepValue, Equals, "Go to the logout page")
+	c.Assert(commonPrefix, Equals, "Go to the log")
+
+	matches, commonPrefix = specInfoGatherer.StepCompletionContext("Go to")
+	c.Assert(len(matches), Equals, 3)
+	c.Assert(commonPrefix, Equals, "Go to the ")
+
+	matches, commonPrefix = specInfoGatherer.StepCompletionContext("Say")
+	c.Assert(len(matches), Equals, 1)
+	c.Assert(commonPrefix, Equals, "Say <hello> to <gauge>")
+
+	matches, commonPrefix = specInfoGatherer.StepCompletionContext("Open")
+	c.Assert(len(matches), Equals, 0)
+	c.Assert(commonPrefix, Equals, "")
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import "github.com/getgauge/gauge/gauge"
+
+// stepKey identifies a step by its template and the number of arguments it takes, so that steps which share
+// a textual prefix, like "the user {}" and "the user {} with role {}", are never taken for one another.
+type stepKey struct {
+	value    string
+	argCount int
+}
+
+func keyOf(step *gauge.Step) stepKey {
+	return stepKey{value: step.Value, argCount: len(step.Args)}
+}
+
+// put replaces the steps cached for the file, keeping the index in sync. The caller holds the lock.
+func (c *stepsCache) put(fileName string, steps []*gauge.Step) {
+	c.remove(fileName)
+	c.steps[fileName] = steps
+	if c.index == nil {
+		c.index = make(map[stepKey][]*gauge.Step)
+	}
+	for _, step := range steps {
+		k := keyOf(step)
+		c.index[k] = append(c.index[k], step)
+	}
+}
+
+// remove drops the steps cached for the file, keeping the index in sync. The caller holds the lock.
+func (c *stepsCache) remove(fileName string) {
+	for _, step := range c.steps[fileName] {
+		k := keyOf(step)
+		usages := c.index[k][:0]
+		for _, s := range c.index[k] {
+			if s != step {
+				usages = append(usages, s)
+			}
+		}
+		if len(usages) == 0 {
+			delete(c.index, k)
+		} else {
+			c.index[k] = usages
+		}
+	}
+	delete(c.steps, fileName)
+}
+
+// FindStepUsages returns the usages of the step with the given value which take exactly argCount arguments.
+func (s *SpecInfoGatherer) FindStepUsages(stepValue string, argCount int) []*gauge.Step {
+	s.stepsCache.mutex.RLock()
+	defer s.stepsCache.mutex.RUnlock()
+	usages := s.stepsCache.index[stepKey{value: stepValue, argCount: argCount}]
+	return append([]*gauge.Step(nil), usages...)
+}
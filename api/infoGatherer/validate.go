@@ -0,0 +1,106 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+)
+
+// ValidateAll validates all the cached specs and returns the errors found, keyed by spec file.
+// Specs without any errors are not part of the result. The parse errors are reported only for specs which could not be parsed,
+// the specs which could be parsed are checked by ValidateAll itself.
+func (s *SpecInfoGatherer) ValidateAll() map[string][]error {
+	s.specsCache.mutex.RLock()
+	defer s.specsCache.mutex.RUnlock()
+	s.conceptsCache.mutex.RLock()
+	defer s.conceptsCache.mutex.RUnlock()
+	report := make(map[string][]error)
+	for file, detail := range s.specsCache.specDetails {
+		var errs []error
+		if detail.HasSpec() {
+			errs = s.validateSpec(detail.Spec)
+		} else {
+			for _, e := range detail.Errs {
+				errs = append(errs, e)
+			}
+		}
+		if len(errs) > 0 {
+			report[file] = errs
+		}
+	}
+	return report
+}
+
+func (s *SpecInfoGatherer) validateSpec(spec *gauge.Specification) []error {
+	var errs []error
+	newError := func(lineNo int, lineText, format string, args ...interface{}) error {
+		return parser.ParseError{FileName: spec.FileName, LineNo: lineNo, Message: fmt.Sprintf(format, args...), LineText: lineText}
+	}
+	if len(spec.Scenarios) == 0 {
+		errs = append(errs, newError(spec.Heading.LineNo, spec.Heading.Value, "Spec does not have any scenarios"))
+	}
+	headings := make(map[string]bool)
+	for _, scenario := range spec.Scenarios {
+		if scenario.Heading == nil {
+			continue
+		}
+		if headings[scenario.Heading.Value] {
+			errs = append(errs, newError(scenario.Heading.LineNo, scenario.Heading.Value, "Duplicate scenario definition '%s' found in the same specification", scenario.Heading.Value))
+		}
+		headings[scenario.Heading.Value] = true
+	}
+	steps := specSteps(spec)
+	for _, step := range steps {
+		if step.IsConcept && (s.conceptDictionary == nil || s.conceptDictionary.Search(step.Value) == nil) {
+			errs = append(errs, newError(step.LineNo, step.LineText, "Concept '%s' is not defined", step.LineText))
+		}
+	}
+	if spec.DataTable.IsInitialized() {
+		used := usedDynamicParams(steps)
+		for _, header := range spec.DataTable.Table.Headers {
+			if !used[header] {
+				errs = append(errs, newError(spec.DataTable.Table.LineNo, header, "Data table column '%s' is not used by any step", header))
+			}
+		}
+	}
+	return errs
+}
+
+func usedDynamicParams(steps []*gauge.Step) map[string]bool {
+	used := make(map[string]bool)
+	for _, step := range steps {
+		for _, arg := range step.Args {
+			switch arg.ArgType {
+			case gauge.Dynamic:
+				used[arg.Value] = true
+			case gauge.TableArg:
+				for _, column := range arg.Table.Columns {
+					for _, cell := range column {
+						if cell.CellType == gauge.Dynamic {
+							used[cell.Value] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return used
+}
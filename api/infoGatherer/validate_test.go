@@ -0,0 +1,94 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package infoGatherer
+
+import (
+	"path/filepath"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestValidateAll(c *C) {
+	createFileIn(s.specsDir, "concept1.cpt", concept1)
+	valid, _ := createFileIn(s.specsDir, "valid.spec", []byte(`Valid
+=====
+
+|name|
+|----|
+|john|
+
+Scenario 1
+----------
+* say hello to <name>
+* foo bar
+`))
+	empty, _ := createFileIn(s.specsDir, "empty.spec", []byte(`Empty
+=====
+* a context step
+`))
+	unusedColumn, _ := createFileIn(s.specsDir, "unusedColumn.spec", []byte(`Unused Column
+=============
+
+|name|city|
+|----|----|
+|john|pune|
+
+Scenario 1
+----------
+* say hello to <name>
+`))
+	valid, _ = filepath.Abs(valid)
+	empty, _ = filepath.Abs(empty)
+	unusedColumn, _ = filepath.Abs(unusedColumn)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+
+	report := specInfoGatherer.ValidateAll()
+
+	c.Assert(len(report), Equals, 2)
+	_, ok := report[valid]
+	c.Assert(ok, Equals, false)
+	c.Assert(len(report[empty]), Equals, 1)
+	c.Assert(strings.Contains(report[empty][0].Error(), "Spec does not have any scenarios"), Equals, true)
+	c.Assert(len(report[unusedColumn]), Equals, 1)
+	c.Assert(strings.Contains(report[unusedColumn][0].Error(), "Data table column 'city' is not used by any step"), Equals, true)
+}
+
+func (s *MySuite) TestValidateAllReportsConceptsNoLongerDefined(c *C) {
+	createFileIn(s.specsDir, "concept1.cpt", concept1)
+	specFile, _ := createFileIn(s.specsDir, "spec.spec", []byte(`Spec
+====
+
+Scenario 1
+----------
+* foo bar
+`))
+	specFile, _ = filepath.Abs(specFile)
+	specInfoGatherer := &SpecInfoGatherer{SpecDirs: []string{s.specsDir}}
+	specInfoGatherer.initConceptsCache()
+	specInfoGatherer.initSpecsCache()
+	c.Assert(len(specInfoGatherer.ValidateAll()), Equals, 0)
+
+	specInfoGatherer.conceptDictionary.Remove("foo bar")
+	report := specInfoGatherer.ValidateAll()
+
+	c.Assert(len(report[specFile]), Equals, 1)
+	c.Assert(strings.Contains(report[specFile][0].Error(), "Concept 'foo bar' is not defined"), Equals, true)
+}
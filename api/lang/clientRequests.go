@@ -20,6 +20,7 @@ package lang
 import (
 	"context"
 
+	"github.com/getgauge/gauge/logger"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -28,6 +29,42 @@ func showErrorMessageOnClient(ctx context.Context, conn jsonrpc2.JSONRPC2, err e
 	conn.Notify(ctx, "window/showMessage", lsp.ShowMessageParams{Type: lsp.MTError, Message: err.Error()})
 }
 
+type showDocumentParams struct {
+	URI       lsp.DocumentURI `json:"uri"`
+	External  bool            `json:"external,omitempty"`
+	TakeFocus bool            `json:"takeFocus,omitempty"`
+	Selection *lsp.Range      `json:"selection,omitempty"`
+}
+
+type showDocumentResult struct {
+	Success bool `json:"success"`
+}
+
+func showDocumentOnClient(ctx context.Context, conn jsonrpc2.JSONRPC2, params showDocumentParams) {
+	var result showDocumentResult
+	if err := conn.Call(ctx, "window/showDocument", params, &result); err != nil {
+		logger.APILog.Debugf("failed to show %s on the client. %s", params.URI, err.Error())
+	}
+}
+
+type applyWorkspaceEditParams struct {
+	Edit lsp.WorkspaceEdit `json:"edit"`
+}
+
+type applyWorkspaceEditResult struct {
+	Applied bool `json:"applied"`
+}
+
+// applyEditOnClient asks the client to apply the edit and reports whether it did.
+func applyEditOnClient(ctx context.Context, conn jsonrpc2.JSONRPC2, edit lsp.WorkspaceEdit) bool {
+	var result applyWorkspaceEditResult
+	if err := conn.Call(ctx, "workspace/applyEdit", applyWorkspaceEditParams{Edit: edit}, &result); err != nil {
+		logger.APILog.Debugf("failed to apply the edit on the client. %s", err.Error())
+		return false
+	}
+	return result.Applied
+}
+
 func sendSaveFilesRequest(ctx context.Context, conn jsonrpc2.JSONRPC2) error {
 	if clientCapabilities.SaveFiles {
 		var result interface{}
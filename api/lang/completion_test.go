@@ -67,7 +67,39 @@ type dummyInfoProvider struct {
 func (p dummyInfoProvider) GetAvailableSpecDetails(specs []string) []*infoGatherer.SpecDetail {
 	return p.specsFunc(specs)
 }
-func (p dummyInfoProvider) Init() {}
+func (p dummyInfoProvider) GetSpecsByTag(tags []string) []*infoGatherer.SpecDetail {
+	var details []*infoGatherer.SpecDetail
+	for _, d := range p.specsFunc([]string{}) {
+		if d.Spec.Tags != nil && containsAny(d.Spec.Tags.Values(), tags) {
+			details = append(details, d)
+		}
+	}
+	return details
+}
+func containsAny(values, wanted []string) bool {
+	for _, v := range values {
+		for _, w := range wanted {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+func (p dummyInfoProvider) GetSpecForFile(file string) (*gauge.Specification, bool) {
+	if p.specsFunc == nil {
+		return nil, false
+	}
+	details := p.specsFunc([]string{file})
+	if len(details) == 0 {
+		return nil, false
+	}
+	return details[0].Spec, true
+}
+func (p dummyInfoProvider) Events() <-chan infoGatherer.SpecEvent {
+	return make(chan infoGatherer.SpecEvent)
+}
+func (p dummyInfoProvider) Init() error { return nil }
 func (p dummyInfoProvider) Steps() []*gauge.Step {
 	return []*gauge.Step{{
 		FileName: "foo.spec",
@@ -87,6 +119,16 @@ func (p dummyInfoProvider) AllSteps() []*gauge.Step {
 	}}
 }
 
+func (p dummyInfoProvider) FindStepUsages(stepValue string, argCount int) []*gauge.Step {
+	var usages []*gauge.Step
+	for _, s := range p.AllSteps() {
+		if s.Value == stepValue && len(s.Args) == argCount {
+			usages = append(usages, s)
+		}
+	}
+	return usages
+}
+
 func (p dummyInfoProvider) Concepts() []*gm.ConceptInfo {
 	return []*gm.ConceptInfo{
 		{
@@ -116,7 +158,7 @@ func (p dummyInfoProvider) SearchConceptDictionary(stepValue string) *gauge.Conc
 }
 
 func TestCompletion(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add("uri", " * ")
 	position := lsp.Position{Line: 0, Character: len(" * ")}
 	want := completionList{IsIncomplete: false, Items: []completionItem{
@@ -164,7 +206,7 @@ func TestCompletion(t *testing.T) {
 }
 
 func TestCompletionForLineWithText(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add("uri", " * step")
 	position := lsp.Position{Line: 0, Character: len(` *`)}
 	wantStartPos := lsp.Position{Line: position.Line, Character: len(` *`)}
@@ -210,7 +252,7 @@ func TestCompletionForLineWithText(t *testing.T) {
 }
 
 func TestCompletionInBetweenLine(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add("uri", "* step")
 	position := lsp.Position{Line: 0, Character: len(`* s`)}
 	wantStartPos := lsp.Position{Line: position.Line, Character: len(`* `)}
@@ -256,7 +298,7 @@ func TestCompletionInBetweenLine(t *testing.T) {
 }
 
 func TestCompletionInBetweenLineHavingParams(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	line := "*step with a <param> and more"
 	openFilesCache.add("uri", line)
 	position := lsp.Position{Line: 0, Character: len(`*step with a <param> and`)}
@@ -303,7 +345,7 @@ func TestCompletionInBetweenLineHavingParams(t *testing.T) {
 }
 
 func TestCompletionInBetweenLineHavingSpecialParams(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	line := "*step with a <file:test.txt> and more"
 	openFilesCache.add("uri", line)
 	position := lsp.Position{Line: 0, Character: len(`*step with a <file:test.txt>`)}
@@ -350,7 +392,7 @@ func TestCompletionInBetweenLineHavingSpecialParams(t *testing.T) {
 }
 
 func TestParamCompletion(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	line := ` * step with a "param`
 	openFilesCache.add("uri", line)
 	position := lsp.Position{Line: 0, Character: len(` * step with a "pa`)}
@@ -481,7 +523,7 @@ tags: blah,abc
 * step
 `
 	uri := lsp.DocumentURI("foo.spec")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, specText)
 	got := isInTagsContext(2, uri)
 	if !got {
@@ -501,7 +543,7 @@ tags: blah,abc
 * step
 `
 	uri := lsp.DocumentURI("foo.spec")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, specText)
 	got := isInTagsContext(3, uri)
 	if !got {
@@ -520,7 +562,7 @@ tags: blah,abc
 * step
 `
 	uri := lsp.DocumentURI("foo.spec")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, specText)
 	got := isInTagsContext(3, uri)
 	if got {
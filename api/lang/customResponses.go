@@ -18,13 +18,14 @@
 package lang
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/gauge"
 	gm "github.com/getgauge/gauge/gauge_messages"
-	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/util"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
@@ -38,8 +39,10 @@ type ScenarioInfo struct {
 }
 
 type specInfo struct {
-	Heading             string `json:"heading"`
-	ExecutionIdentifier string `json:"executionIdentifier"`
+	Heading             string   `json:"heading"`
+	ExecutionIdentifier string   `json:"executionIdentifier"`
+	ScenarioCount       int      `json:"scenarioCount"`
+	Tags                []string `json:"tags"`
 }
 
 type stubImpl struct {
@@ -47,11 +50,46 @@ type stubImpl struct {
 	Codes                  []string `json:"codes"`
 }
 
-func specs() (interface{}, error) {
-	specDetails := provider.GetAvailableSpecDetails([]string{})
+type stepStubs struct {
+	ImplementationFilePath string          `json:"implementationFilePath"`
+	Steps                  []string        `json:"steps"`
+	URI                    lsp.DocumentURI `json:"uri"`
+}
+
+type specsParams struct {
+	Tags []string `json:"tags"`
+}
+
+type stubImpls struct {
+	Stubs []stepStubs `json:"stubs"`
+}
+
+// specs gives all the specs in the project, or only the ones having any of the tags in the optional params.
+func specs(req *jsonrpc2.Request) (interface{}, error) {
+	var params specsParams
+	if req.Params != nil {
+		if err := unmarshalParams(req, &params, `{"tags": [string]}`); err != nil {
+			return nil, err
+		}
+	}
+	var specDetails []*infoGatherer.SpecDetail
+	if len(params.Tags) > 0 {
+		specDetails = provider.GetSpecsByTag(params.Tags)
+	} else {
+		specDetails = provider.GetAvailableSpecDetails([]string{})
+	}
 	specs := make([]specInfo, 0)
 	for _, d := range specDetails {
-		specs = append(specs, specInfo{Heading: d.Spec.Heading.Value, ExecutionIdentifier: d.Spec.FileName})
+		tags := make([]string, 0)
+		if d.Spec.Tags != nil {
+			tags = append(tags, d.Spec.Tags.Values()...)
+		}
+		specs = append(specs, specInfo{
+			Heading:             d.Spec.Heading.Value,
+			ExecutionIdentifier: d.Spec.FileName,
+			ScenarioCount:       len(d.Spec.Scenarios),
+			Tags:                tags,
+		})
 	}
 	return specs, nil
 }
@@ -67,10 +105,9 @@ func getImplFiles() (interface{}, error) {
 	return implementationFileListResponse.ImplementationFilePaths, nil
 }
 
-func putStubImpl(req *jsonrpc2.Request) (interface{}, error) {
+func putStubImpl(ctx context.Context, conn jsonrpc2.JSONRPC2, req *jsonrpc2.Request) (interface{}, error) {
 	var stubImplParams stubImpl
-	if err := json.Unmarshal(*req.Params, &stubImplParams); err != nil {
-		logger.APILog.Debugf("failed to parse request %s", err.Error())
+	if err := unmarshalParams(req, &stubImplParams, `{"implementationFilePath": string, "codes": [string]}`); err != nil {
 		return nil, err
 	}
 	if lRunner.runner == nil {
@@ -80,8 +117,154 @@ func putStubImpl(req *jsonrpc2.Request) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	edit := getWorkspaceEditForStubImpl(fileChanges, stubImplParams.ImplementationFilePath)
+	params := showNewImplFileParams(fileChanges, stubImplParams.Codes)
+	if params == nil || !clientCapabilities.Workspace.ApplyEdit || !clientCapabilities.Window.ShowDocument.Support {
+		return edit, nil
+	}
+	// The new file has to be created before it can be shown, so the edit is applied here instead of being returned.
+	if !applyEditOnClient(ctx, conn, edit) {
+		return edit, nil
+	}
+	showDocumentOnClient(ctx, conn, *params)
+	return lsp.WorkspaceEdit{Changes: make(map[string][]lsp.TextEdit, 0)}, nil
+}
+
+// showNewImplFileParams gives the params to open the implementation file with the first generated stub selected.
+// Returns nil if the stubs were added to a file which already exists.
+func showNewImplFileParams(fileChanges *gm.FileChanges, codes []string) *showDocumentParams {
+	if fileChanges == nil || fileChanges.FileName == "" || common.FileExists(fileChanges.FileName) {
+		return nil
+	}
+	selection := lsp.Range{}
+	if len(codes) > 0 {
+		if index := strings.Index(fileChanges.FileContent, codes[0]); index >= 0 {
+			startLine := strings.Count(fileChanges.FileContent[:index], "\n")
+			lines := strings.Split(strings.TrimRight(codes[0], "\n"), "\n")
+			selection = lsp.Range{
+				Start: lsp.Position{Line: startLine, Character: 0},
+				End:   lsp.Position{Line: startLine + len(lines) - 1, Character: len(lines[len(lines)-1])},
+			}
+		}
+	}
+	return &showDocumentParams{
+		URI:       util.ConvertPathToURI(lsp.DocumentURI(fileChanges.FileName)),
+		TakeFocus: true,
+		Selection: &selection,
+	}
+}
+
+// putStubImpls generates stubs for several undefined steps in one go. Each entry names the implementation file the stubs
+// go to and the step texts to implement, or the uri of a spec whose undefined steps should all be implemented.
+// Steps which already have an implementation are skipped and the stubs for every file are returned as one edit.
+func putStubImpls(req *jsonrpc2.Request) (interface{}, error) {
+	var params stubImpls
+	if err := unmarshalParams(req, &params, `{"stubs": [{"implementationFilePath": string, "steps": [string], "uri": string}]}`); err != nil {
+		return nil, err
+	}
+	codes, files, err := stubCodesByFile(params.Stubs)
+	if err != nil {
+		return nil, err
+	}
+	result := lsp.WorkspaceEdit{Changes: make(map[string][]lsp.TextEdit, 0)}
+	for _, file := range files {
+		fileChanges, err := putStubImplementation(file, codes[file])
+		if err != nil {
+			return nil, err
+		}
+		for uri, edits := range getWorkspaceEditForStubImpl(fileChanges, file).Changes {
+			result.Changes[uri] = append(result.Changes[uri], edits...)
+		}
+	}
+	return result, nil
+}
+
+func stubCodesByFile(stubs []stepStubs) (map[string][]string, []string, error) {
+	codes := make(map[string][]string)
+	var files []string
+	seen := make(map[string]bool)
+	for _, s := range stubs {
+		stepValues, err := stepValuesToStub(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, stepValue := range stepValues {
+			if seen[stepValue.StepValue] {
+				continue
+			}
+			seen[stepValue.StepValue] = true
+			code, err := stubCodeFor(stepValue)
+			if err != nil {
+				return nil, nil, err
+			}
+			if code == "" {
+				continue
+			}
+			if _, ok := codes[s.ImplementationFilePath]; !ok {
+				files = append(files, s.ImplementationFilePath)
+			}
+			codes[s.ImplementationFilePath] = append(codes[s.ImplementationFilePath], code)
+		}
+	}
+	return codes, files, nil
+}
+
+func stepValuesToStub(s stepStubs) ([]gauge.StepValue, error) {
+	var stepValues []gauge.StepValue
+	for _, text := range s.Steps {
+		stepValue, err := parser.ExtractStepValueAndParams(text, false)
+		if err != nil {
+			return nil, err
+		}
+		stepValues = append(stepValues, *stepValue)
+	}
+	if s.URI == "" {
+		return stepValues, nil
+	}
+	file := string(util.ConvertURItoFilePath(s.URI))
+	content := getContent(s.URI)
+	if !isOpen(s.URI) {
+		var err error
+		if content, err = common.ReadFileContents(file); err != nil {
+			return nil, err
+		}
+	}
+	spec, parseResult, err := new(parser.SpecParser).Parse(content, gauge.NewConceptDictionary(), file)
+	if err != nil {
+		return nil, err
+	}
+	if !parseResult.Ok {
+		return nil, fmt.Errorf("parsing failed")
+	}
+	concepts := make(map[string]bool)
+	for _, c := range provider.Concepts() {
+		concepts[c.GetStepValue().GetStepValue()] = true
+	}
+	spec.WalkSteps(func(step *gauge.Step, depth int) {
+		if !concepts[step.Value] {
+			stepValues = append(stepValues, parser.CreateStepValue(step))
+		}
+	})
+	return stepValues, nil
+}
 
-	return getWorkspaceEditForStubImpl(fileChanges, stubImplParams.ImplementationFilePath), nil
+// stubCodeFor asks the runner to validate the step and returns its suggested stub, or an empty string when the step
+// is already implemented.
+func stubCodeFor(stepValue gauge.StepValue) (string, error) {
+	m := &gm.Message{MessageType: gm.Message_StepValidateRequest, StepValidateRequest: &gm.StepValidateRequest{
+		StepText:           stepValue.StepValue,
+		NumberOfParameters: int32(len(stepValue.Args)),
+		StepValue:          gauge.ConvertToProtoStepValue(&stepValue),
+	}}
+	response, err := GetResponseFromRunner(m)
+	if err != nil {
+		return "", err
+	}
+	res := response.GetStepValidateResponse()
+	if res.GetIsValid() || res.GetErrorType() != gm.StepValidateResponse_STEP_IMPLEMENTATION_NOT_FOUND {
+		return "", nil
+	}
+	return res.GetSuggestion(), nil
 }
 
 func getWorkspaceEditForStubImpl(fileChanges *gm.FileChanges, filePath string) lsp.WorkspaceEdit {
@@ -92,10 +275,8 @@ func getWorkspaceEditForStubImpl(fileChanges *gm.FileChanges, filePath string) l
 
 	var lastLineNo int
 	contents, err := common.ReadFileContents(filePath)
-	if err != nil {
-		lastLineNo = 0
-	} else {
-		lastLineNo = len(contents)
+	if err == nil {
+		lastLineNo = lineCount(contents)
 	}
 
 	textEdit := lsp.TextEdit{
@@ -109,45 +290,73 @@ func getWorkspaceEditForStubImpl(fileChanges *gm.FileChanges, filePath string) l
 	return result
 }
 
+// lineCount returns the number of lines in the content, counting a last line which does not end with a newline.
+func lineCount(content string) int {
+	count := strings.Count(content, "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		count++
+	}
+	return count
+}
+
+// allScenariosLine is the line sent to gauge/scenarios to get all the scenarios of the spec instead of the one at the cursor.
+const allScenariosLine = -1
+
 func scenarios(req *jsonrpc2.Request) (interface{}, error) {
 	var params lsp.TextDocumentPositionParams
 	var err error
-	if err = json.Unmarshal(*req.Params, &params); err != nil {
-		logger.APILog.Debugf("failed to parse request %s", err.Error())
+	if err = unmarshalParams(req, &params, `{"textDocument": {"uri": string}, "position": {"line": number, "character": number}}`); err != nil {
 		return nil, err
 	}
 	file := util.ConvertURItoFilePath(params.TextDocument.URI)
-	content := ""
+	var spec *gauge.Specification
 	if !isOpen(params.TextDocument.URI) {
-		specDetails := provider.GetAvailableSpecDetails([]string{string(file)})
-		return getScenarioAt(specDetails[0].Spec.Scenarios, file, params.Position.Line), nil
+		var ok bool
+		if spec, ok = provider.GetSpecForFile(string(file)); !ok {
+			return nil, fmt.Errorf("spec file %s not found", file)
+		}
+	} else {
+		var parseResult *parser.ParseResult
+		spec, parseResult, err = new(parser.SpecParser).Parse(getContent(params.TextDocument.URI), gauge.NewConceptDictionary(), string(file))
+		if err != nil {
+			return nil, err
+		}
+		if !parseResult.Ok {
+			return nil, fmt.Errorf("parsing failed")
+		}
 	}
-	content = getContent(params.TextDocument.URI)
-	spec, parseResult, err := new(parser.SpecParser).Parse(content, gauge.NewConceptDictionary(), string(file))
-	if err != nil {
-		return nil, err
+	if params.Position.Line == allScenariosLine {
+		return getAllScenarios(spec.Scenarios, file), nil
 	}
-	if !parseResult.Ok {
-		return nil, fmt.Errorf("parsing failed")
+	if info := getScenarioAt(spec.Scenarios, file, params.Position.Line); info != nil {
+		return *info, nil
 	}
-	return getScenarioAt(spec.Scenarios, file, params.Position.Line), nil
+	return nil, nil
 }
 
-func getScenarioAt(scenarios []*gauge.Scenario, file lsp.DocumentURI, line int) interface{} {
-	var ifs []ScenarioInfo
+// getScenarioAt returns the scenario whose span has the given zero based line, or nil if the line is not in a scenario.
+func getScenarioAt(scenarios []*gauge.Scenario, file lsp.DocumentURI, line int) *ScenarioInfo {
 	for _, sce := range scenarios {
-		info := getScenarioInfo(sce, file)
 		if sce.InSpan(line + 1) {
-			return info
+			info := getScenarioInfo(sce, file)
+			return &info
 		}
-		ifs = append(ifs, info)
 	}
-	return ifs
+	return nil
 }
+
+func getAllScenarios(scenarios []*gauge.Scenario, file lsp.DocumentURI) []ScenarioInfo {
+	infos := make([]ScenarioInfo, 0, len(scenarios))
+	for _, sce := range scenarios {
+		infos = append(infos, getScenarioInfo(sce, file))
+	}
+	return infos
+}
+
 func getScenarioInfo(sce *gauge.Scenario, file lsp.DocumentURI) ScenarioInfo {
 	return ScenarioInfo{
 		Heading:             sce.Heading.Value,
 		LineNo:              sce.Heading.LineNo,
-		ExecutionIdentifier: fmt.Sprintf("%s:%d", file, sce.Heading.LineNo),
+		ExecutionIdentifier: util.NormalizeExecutionIdentifier(fmt.Sprintf("%s:%d", file, sce.Heading.LineNo)),
 	}
 }
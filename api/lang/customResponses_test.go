@@ -18,11 +18,19 @@
 package lang
 
 import (
+	"context"
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
 
 	"reflect"
 
@@ -46,7 +54,7 @@ Scenario Heading2
 * Step text`
 
 	uri := lsp.DocumentURI("foo.spec")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, specText)
 
 	position := lsp.Position{Line: 5, Character: 1}
@@ -72,7 +80,50 @@ Scenario Heading2
 	openFilesCache.remove(uri)
 }
 
-func TestGetScenariosShouldGiveTheScenariosIfCursorPositionIsNotInSpan(t *testing.T) {
+func TestGetScenariosShouldGiveNilIfCursorPositionIsNotInAScenario(t *testing.T) {
+	specText := `Specification Heading
+=====================
+
+Scenario Heading
+----------------
+
+* Step text
+`
+
+	uri := lsp.DocumentURI("foo.spec")
+	openFilesCache = newDocumentStore()
+	openFilesCache.add(uri, specText)
+	defer openFilesCache.remove(uri)
+
+	position := lsp.Position{Line: 1, Character: 1}
+	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: position})
+	p := json.RawMessage(b)
+
+	got, err := scenarios(&jsonrpc2.Request{Params: &p})
+
+	if err != nil {
+		t.Errorf("expected errror to be nil. Got: \n%v", err.Error())
+	}
+	if got != nil {
+		t.Errorf("expected no scenario outside a scenario span. Got: %v", got)
+	}
+}
+
+func TestGetScenarioInfoGivesExecutionIdentifierWithFilePath(t *testing.T) {
+	if util.IsWindows() {
+		t.Skip("unix file uri")
+	}
+	sce := &gauge.Scenario{Heading: &gauge.Heading{Value: "Scenario Heading", LineNo: 4}}
+
+	got := getScenarioInfo(sce, lsp.DocumentURI("file:///project/specs/foo.spec"))
+
+	want := "/project/specs/foo.spec:4"
+	if got.ExecutionIdentifier != want {
+		t.Errorf("expected %s to be equal %s", got.ExecutionIdentifier, want)
+	}
+}
+
+func TestGetScenariosShouldGiveAllTheScenariosForAllScenariosLine(t *testing.T) {
 	specText := `Specification Heading
 =====================
 
@@ -88,10 +139,10 @@ Scenario Heading2
 `
 
 	uri := lsp.DocumentURI("foo.spec")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, specText)
 
-	position := lsp.Position{Line: 2, Character: 1}
+	position := lsp.Position{Line: allScenariosLine, Character: 1}
 	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: position})
 	p := json.RawMessage(b)
 
@@ -139,7 +190,7 @@ func TestGetScenariosShouldGiveTheScenariosIfDocumentIsNotOpened(t *testing.T) {
 		},
 	}
 
-	position := lsp.Position{Line: 2, Character: 1}
+	position := lsp.Position{Line: allScenariosLine, Character: 1}
 	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: "foo.spec"}, Position: position})
 	p := json.RawMessage(b)
 
@@ -174,8 +225,10 @@ func TestGetSpecsShouldReturnAllSpecsInDirectory(t *testing.T) {
 			return []*infoGatherer.SpecDetail{
 				&infoGatherer.SpecDetail{
 					Spec: &gauge.Specification{
-						Heading:  &gauge.Heading{Value: "Specification 1"},
-						FileName: "foo1.spec",
+						Heading:   &gauge.Heading{Value: "Specification 1"},
+						FileName:  "foo1.spec",
+						Tags:      &gauge.Tags{RawValues: [][]string{{"foo", "bar"}}},
+						Scenarios: []*gauge.Scenario{{Heading: &gauge.Heading{Value: "Scenario 1"}}, {Heading: &gauge.Heading{Value: "Scenario 2"}}},
 					},
 				},
 				&infoGatherer.SpecDetail{
@@ -192,13 +245,17 @@ func TestGetSpecsShouldReturnAllSpecsInDirectory(t *testing.T) {
 		{
 			Heading:             "Specification 1",
 			ExecutionIdentifier: "foo1.spec",
+			ScenarioCount:       2,
+			Tags:                []string{"foo", "bar"},
 		},
 		{
 			Heading:             "Specification 2",
 			ExecutionIdentifier: "foo2.spec",
+			ScenarioCount:       0,
+			Tags:                []string{},
 		},
 	}
-	got, err := specs()
+	got, err := specs(&jsonrpc2.Request{Method: "gauge/specs"})
 
 	if err != nil {
 		t.Errorf("expected error to be nil. Got: \n%v", err.Error())
@@ -210,3 +267,306 @@ func TestGetSpecsShouldReturnAllSpecsInDirectory(t *testing.T) {
 		t.Errorf("expected %v to be equal %v", info, want)
 	}
 }
+
+func TestGetSpecsShouldReturnOnlySpecsWithTheGivenTags(t *testing.T) {
+	provider = &dummyInfoProvider{
+		specsFunc: func(specs []string) []*infoGatherer.SpecDetail {
+			return []*infoGatherer.SpecDetail{
+				{Spec: &gauge.Specification{Heading: &gauge.Heading{Value: "Specification 1"}, FileName: "foo1.spec", Tags: &gauge.Tags{RawValues: [][]string{{"foo"}}}}},
+				{Spec: &gauge.Specification{Heading: &gauge.Heading{Value: "Specification 2"}, FileName: "foo2.spec", Tags: &gauge.Tags{RawValues: [][]string{{"bar"}}}}},
+			}
+		},
+	}
+	p := json.RawMessage(`{"tags": ["bar"]}`)
+
+	got, err := specs(&jsonrpc2.Request{Method: "gauge/specs", Params: &p})
+
+	if err != nil {
+		t.Errorf("expected error to be nil. Got: \n%v", err.Error())
+	}
+	want := []specInfo{{Heading: "Specification 2", ExecutionIdentifier: "foo2.spec", Tags: []string{"bar"}}}
+	if !reflect.DeepEqual(got.([]specInfo), want) {
+		t.Errorf("expected %v to be equal %v", got, want)
+	}
+}
+
+func TestGetScenariosShouldGiveErrorIfDocumentIsNotOpenedAndNotCached(t *testing.T) {
+	provider = &dummyInfoProvider{
+		specsFunc: func(specs []string) []*infoGatherer.SpecDetail {
+			return []*infoGatherer.SpecDetail{}
+		},
+	}
+
+	position := lsp.Position{Line: 2, Character: 1}
+	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: "foo.spec"}, Position: position})
+	p := json.RawMessage(b)
+
+	got, err := scenarios(&jsonrpc2.Request{Params: &p})
+
+	if err == nil {
+		t.Errorf("expected error for a spec which is not cached. Got: %v", got)
+	}
+}
+
+func TestPutStubImplWithMalformedParams(t *testing.T) {
+	p := json.RawMessage(`{"implementationFilePath": "foo.java", "codes": "not a list"}`)
+
+	_, err := putStubImpl(context.Background(), nil, &jsonrpc2.Request{Method: "gauge/putStubImpl", Params: &p})
+
+	assertInvalidParams(t, err)
+}
+
+func stubRunner(implemented ...string) func(*gm.Message) (*gm.Message, error) {
+	return func(m *gm.Message) (*gm.Message, error) {
+		if m.MessageType == gm.Message_StubImplementationCodeRequest {
+			r := m.GetStubImplementationCodeRequest()
+			return &gm.Message{
+				MessageType: gm.Message_FileChanges,
+				FileChanges: &gm.FileChanges{FileName: r.GetImplementationFilePath(), FileContent: strings.Join(r.GetCodes(), "\n")},
+			}, nil
+		}
+		stepText := m.GetStepValidateRequest().GetStepText()
+		for _, s := range implemented {
+			if s == stepText {
+				return &gm.Message{MessageType: gm.Message_StepValidateResponse, StepValidateResponse: &gm.StepValidateResponse{IsValid: true}}, nil
+			}
+		}
+		return &gm.Message{MessageType: gm.Message_StepValidateResponse, StepValidateResponse: &gm.StepValidateResponse{
+			IsValid:    false,
+			ErrorType:  gm.StepValidateResponse_STEP_IMPLEMENTATION_NOT_FOUND,
+			Suggestion: "stub for " + stepText,
+		}}, nil
+	}
+}
+
+func TestPutStubImplsForStepTexts(t *testing.T) {
+	GetResponseFromRunner = stubRunner("implemented step")
+	p := json.RawMessage(`{"stubs": [
+		{"implementationFilePath": "StepImpl.java", "steps": ["first step", "second step with \"arg\"", "implemented step"]},
+		{"implementationFilePath": "StepImpl.java", "steps": ["third step", "first step"]}
+	]}`)
+
+	got, err := putStubImpls(&jsonrpc2.Request{Method: "gauge/putStubImpls", Params: &p})
+
+	if err != nil {
+		t.Fatalf("expected error to be nil. Got: %v", err)
+	}
+	edit := got.(lsp.WorkspaceEdit)
+	edits := edit.Changes[string(util.ConvertPathToURI("StepImpl.java"))]
+	if len(edit.Changes) != 1 || len(edits) != 1 {
+		t.Fatalf("expected a single edit for StepImpl.java. Got: %v", edit.Changes)
+	}
+	want := "stub for first step\nstub for second step with {}\nstub for third step"
+	if edits[0].NewText != want {
+		t.Errorf("expected stubs %q. Got: %q", want, edits[0].NewText)
+	}
+}
+
+func TestPutStubImplsForUndefinedStepsInSpec(t *testing.T) {
+	provider = &dummyInfoProvider{}
+	GetResponseFromRunner = stubRunner("implemented step")
+	specText := `Specification Heading
+=====================
+
+* first step
+
+Scenario Heading
+----------------
+
+* second step
+* implemented step
+* concept1
+* third step
+`
+	uri := lsp.DocumentURI("foo.spec")
+	openFilesCache = newDocumentStore()
+	openFilesCache.add(uri, specText)
+	defer openFilesCache.remove(uri)
+	p := json.RawMessage(`{"stubs": [{"implementationFilePath": "step_impl.py", "uri": "foo.spec"}]}`)
+
+	got, err := putStubImpls(&jsonrpc2.Request{Method: "gauge/putStubImpls", Params: &p})
+
+	if err != nil {
+		t.Fatalf("expected error to be nil. Got: %v", err)
+	}
+	edits := got.(lsp.WorkspaceEdit).Changes[string(util.ConvertPathToURI("step_impl.py"))]
+	want := "stub for first step\nstub for second step\nstub for third step"
+	if len(edits) != 1 || edits[0].NewText != want {
+		t.Errorf("expected stubs %q. Got: %v", want, edits)
+	}
+}
+
+func TestPutStubImplsWithMalformedParams(t *testing.T) {
+	p := json.RawMessage(`{"stubs": {"steps": "first step"}}`)
+
+	_, err := putStubImpls(&jsonrpc2.Request{Method: "gauge/putStubImpls", Params: &p})
+
+	assertInvalidParams(t, err)
+}
+
+func TestScenariosWithMalformedParams(t *testing.T) {
+	p := json.RawMessage(`["foo.spec", 5]`)
+
+	_, err := scenarios(&jsonrpc2.Request{Method: "gauge/scenarios", Params: &p})
+
+	assertInvalidParams(t, err)
+}
+
+func TestScenariosWithoutParams(t *testing.T) {
+	_, err := scenarios(&jsonrpc2.Request{Method: "gauge/scenarios"})
+
+	assertInvalidParams(t, err)
+}
+
+func assertInvalidParams(t *testing.T, err error) {
+	rpcErr, ok := err.(*jsonrpc2.Error)
+	if !ok {
+		t.Fatalf("expected a jsonrpc2 error. Got: %v", err)
+	}
+	if rpcErr.Code != jsonrpc2.CodeInvalidParams {
+		t.Errorf("expected error code %d. Got: %d", jsonrpc2.CodeInvalidParams, rpcErr.Code)
+	}
+}
+
+func TestShowNewImplFileParamsSelectsFirstStub(t *testing.T) {
+	code := "@Step(\"foo\")\npublic void foo(){\n}"
+	fileChanges := &gm.FileChanges{
+		FileName:    filepath.Join("_testdata", "doesNotExist", "StepImplementation.java"),
+		FileContent: "public class StepImplementation {\n" + code + "\n}",
+	}
+
+	got := showNewImplFileParams(fileChanges, []string{code})
+
+	if got == nil {
+		t.Fatalf("expected params to show a new implementation file")
+	}
+	want := lsp.Range{Start: lsp.Position{Line: 1, Character: 0}, End: lsp.Position{Line: 3, Character: 1}}
+	if !reflect.DeepEqual(*got.Selection, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, *got.Selection)
+	}
+	if got.URI != util.ConvertPathToURI(lsp.DocumentURI(fileChanges.FileName)) {
+		t.Errorf("want: `%s`,\n got: `%s`", util.ConvertPathToURI(lsp.DocumentURI(fileChanges.FileName)), got.URI)
+	}
+}
+
+func TestShowNewImplFileParamsIsNilForExistingFile(t *testing.T) {
+	f, err := ioutil.TempFile("", "StepImplementation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	got := showNewImplFileParams(&gm.FileChanges{FileName: f.Name(), FileContent: "foo"}, []string{"foo"})
+
+	if got != nil {
+		t.Errorf("expected no document to be shown for an existing file, got: `%v`", got)
+	}
+}
+
+// recordingConn records the methods sent to the client and tells it applied every edit.
+type recordingConn struct {
+	methods []string
+}
+
+func (r *recordingConn) Call(ctx context.Context, method string, params, result interface{}, opt ...jsonrpc2.CallOption) error {
+	r.methods = append(r.methods, method)
+	if res, ok := result.(*applyWorkspaceEditResult); ok {
+		res.Applied = true
+	}
+	return nil
+}
+
+func (r *recordingConn) Notify(ctx context.Context, method string, params interface{}, opt ...jsonrpc2.CallOption) error {
+	r.methods = append(r.methods, method)
+	return nil
+}
+
+func (r *recordingConn) Close() error {
+	return nil
+}
+
+func putStubImplForNewFile(t *testing.T, conn jsonrpc2.JSONRPC2) lsp.WorkspaceEdit {
+	lRunner.runner = &runner.LanguageRunner{}
+	defer func() { lRunner.runner = nil }()
+	GetResponseFromRunner = stubRunner()
+	p := json.RawMessage(`{"implementationFilePath": "` + filepath.ToSlash(filepath.Join("_testdata", "doesNotExist", "StepImpl.java")) + `", "codes": ["stub"]}`)
+
+	got, err := putStubImpl(context.Background(), conn, &jsonrpc2.Request{Method: "gauge/putStubImpl", Params: &p})
+
+	if err != nil {
+		t.Fatalf("expected error to be nil. Got: %v", err)
+	}
+	return got.(lsp.WorkspaceEdit)
+}
+
+func TestPutStubImplAppliesEditBeforeShowingNewFile(t *testing.T) {
+	clientCapabilities.Workspace.ApplyEdit = true
+	clientCapabilities.Window.ShowDocument.Support = true
+	defer func() { clientCapabilities = ClientCapabilities{} }()
+	conn := &recordingConn{}
+
+	edit := putStubImplForNewFile(t, conn)
+
+	want := []string{"workspace/applyEdit", "window/showDocument"}
+	if !reflect.DeepEqual(conn.methods, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, conn.methods)
+	}
+	if len(edit.Changes) != 0 {
+		t.Errorf("expected the applied edit not to be returned again. Got: %v", edit.Changes)
+	}
+}
+
+func TestPutStubImplReturnsEditWhenClientCannotShowDocuments(t *testing.T) {
+	clientCapabilities.Workspace.ApplyEdit = true
+	defer func() { clientCapabilities = ClientCapabilities{} }()
+	conn := &recordingConn{}
+
+	edit := putStubImplForNewFile(t, conn)
+
+	if len(conn.methods) != 0 {
+		t.Errorf("expected nothing to be sent to the client. Got: %v", conn.methods)
+	}
+	if len(edit.Changes) != 1 {
+		t.Errorf("expected the edit to be returned. Got: %v", edit.Changes)
+	}
+}
+
+func TestGetWorkspaceEditForStubImplReplacesUptoLastLineOfFileWithUnicodeContent(t *testing.T) {
+	f, err := ioutil.TempFile("", "step_impl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString("// Schritte für die Überprüfung\n// 日本語のステップ\nconst x = 1;")
+	f.Close()
+	fileChanges := &gm.FileChanges{FileName: f.Name(), FileContent: "new content"}
+
+	got := getWorkspaceEditForStubImpl(fileChanges, f.Name())
+
+	want := lsp.Range{Start: lsp.Position{Line: 0, Character: 0}, End: lsp.Position{Line: 3, Character: 0}}
+	edits := got.Changes[string(util.ConvertPathToURI(lsp.DocumentURI(f.Name())))]
+	if len(edits) != 1 {
+		t.Fatalf("expected one edit, got: `%v`", got.Changes)
+	}
+	if !reflect.DeepEqual(edits[0].Range, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, edits[0].Range)
+	}
+}
+
+func TestLineCount(t *testing.T) {
+	tests := []struct {
+		content string
+		want    int
+	}{
+		{"", 0},
+		{"ä\n", 1},
+		{"ä\nö", 2},
+		{"ä\nö\n", 2},
+	}
+	for _, test := range tests {
+		if got := lineCount(test.content); got != test.want {
+			t.Errorf("lineCount(%q) want: %d, got: %d", test.content, test.want, got)
+		}
+	}
+}
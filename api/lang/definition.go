@@ -41,28 +41,32 @@ func definition(req *jsonrpc2.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	fileContent := getContent(params.TextDocument.URI)
-	if util.IsConcept(string(util.ConvertURItoFilePath(params.TextDocument.URI))) {
+	if step := stepAt(params.TextDocument.URI, params.Position.Line); step != nil {
+		return search(step)
+	}
+	return nil, nil
+}
+
+func stepAt(uri lsp.DocumentURI, line int) *gauge.Step {
+	fileContent := getContent(uri)
+	if util.IsConcept(string(util.ConvertURItoFilePath(uri))) {
 		concepts, _ := new(parser.ConceptParser).Parse(fileContent, "")
 		for _, concept := range concepts {
 			for _, step := range concept.ConceptSteps {
-				if (step.LineNo - 1) == params.Position.Line {
-					return search(step)
+				if (step.LineNo - 1) == line {
+					return step
 				}
 			}
 		}
-	} else {
-		spec, _ := new(parser.SpecParser).ParseSpecText(fileContent, "")
-		for _, item := range spec.AllItems() {
-			if item.Kind() == gauge.StepKind {
-				step := item.(*gauge.Step)
-				if (step.LineNo - 1) == params.Position.Line {
-					return search(step)
-				}
-			}
+		return nil
+	}
+	spec, _ := new(parser.SpecParser).ParseSpecText(fileContent, "")
+	for _, item := range spec.AllItems() {
+		if item.Kind() == gauge.StepKind && (item.(*gauge.Step).LineNo-1) == line {
+			return item.(*gauge.Step)
 		}
 	}
-	return nil, nil
+	return nil
 }
 
 func search(step *gauge.Step) (interface{}, error) {
@@ -110,7 +114,7 @@ func getLspLocationForStep(fileName string, span *gauge_messages.Span) lsp.Locat
 func getLspLocationForConcept(fileName lsp.DocumentURI, lineNumber int) (interface{}, error) {
 	uri := util.ConvertPathToURI(fileName)
 	var endPos int
-	diskFileCache := &files{cache: make(map[lsp.DocumentURI][]string)}
+	diskFileCache := newDocumentStore()
 	lineNo := lineNumber - 1
 	if isOpen(uri) {
 		endPos = len(getLine(uri, lineNo))
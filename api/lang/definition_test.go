@@ -27,7 +27,7 @@ import (
 )
 
 func TestConceptDefinitionInSpecFile(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
 	openFilesCache.add(uri, "# Specification \n## Scenario \n * concept1")
 
@@ -51,7 +51,7 @@ func TestConceptDefinitionInSpecFile(t *testing.T) {
 }
 
 func TestConceptDefinitionInConceptFile(t *testing.T) {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	uri := lsp.DocumentURI(util.ConvertPathToURI("concept_uri.cpt"))
 	openFilesCache.add(uri, "# Concept \n* a step \n \n # Another Concept \n*concept1")
 	provider = &dummyInfoProvider{}
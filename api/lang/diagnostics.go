@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/api/infoGatherer"
 	"github.com/getgauge/gauge/gauge"
 	gm "github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
@@ -59,6 +60,14 @@ func publishDiagnostics(ctx context.Context, conn jsonrpc2.JSONRPC2) {
 		}
 	}
 }
+
+// publishDiagnosticsOnEvents publishes diagnostics whenever a spec or concept changes on the disk.
+func publishDiagnosticsOnEvents(ctx context.Context, conn jsonrpc2.JSONRPC2, events <-chan infoGatherer.SpecEvent) {
+	for range events {
+		go publishDiagnostics(ctx, conn)
+	}
+}
+
 func publishDiagnostic(uri lsp.DocumentURI, diagnostics []lsp.Diagnostic, conn jsonrpc2.JSONRPC2, ctx context.Context) {
 	params := lsp.PublishDiagnosticsParams{URI: uri, Diagnostics: diagnostics}
 	conn.Notify(ctx, "textDocument/publishDiagnostics", params)
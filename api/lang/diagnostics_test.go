@@ -34,7 +34,7 @@ var conceptFile = "foo.cpt"
 var specFile = "foo.spec"
 
 func setup() {
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(util.ConvertPathToURI(lsp.DocumentURI(conceptFile)), "")
 	openFilesCache.add(util.ConvertPathToURI(lsp.DocumentURI(specFile)), "")
 
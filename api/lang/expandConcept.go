@@ -0,0 +1,94 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/logger"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func expandConcept(req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.TextDocumentPositionParams
+	if err := json.Unmarshal(*req.Params, &params); err != nil {
+		logger.APILog.Debugf("failed to parse request %s", err.Error())
+		return nil, err
+	}
+	step := stepAt(params.TextDocument.URI, params.Position.Line)
+	if step == nil || provider.SearchConceptDictionary(step.Value) == nil {
+		return []string{}, nil
+	}
+	return expandStep(step, nil, map[string]bool{}), nil
+}
+
+// expandStep returns the steps the given step expands to, replacing the concept parameters with the values
+// passed by the invoking step. Concepts which are already being expanded are not expanded again, to avoid cycles.
+func expandStep(step *gauge.Step, lookup *gauge.ArgLookup, expanding map[string]bool) []string {
+	args := resolveArgs(step.Args, lookup)
+	concept := provider.SearchConceptDictionary(step.Value)
+	if concept == nil || expanding[step.Value] {
+		return []string{stepText(step.Value, args)}
+	}
+	conceptLookup := new(gauge.ArgLookup)
+	for _, arg := range concept.ConceptStep.Args {
+		conceptLookup.AddArgName(arg.Value)
+	}
+	if err := new(gauge.Specification).PopulateConceptLookup(conceptLookup, concept.ConceptStep.Args, args); err != nil {
+		logger.APILog.Debugf("failed to resolve parameters of concept %s. %s", concept.ConceptStep.LineText, err.Error())
+		return []string{stepText(step.Value, args)}
+	}
+	expanding[step.Value] = true
+	defer delete(expanding, step.Value)
+	var steps []string
+	for _, conceptStep := range concept.ConceptStep.ConceptSteps {
+		steps = append(steps, expandStep(conceptStep, conceptLookup, expanding)...)
+	}
+	return steps
+}
+
+func resolveArgs(args []*gauge.StepArg, lookup *gauge.ArgLookup) []*gauge.StepArg {
+	resolved := make([]*gauge.StepArg, 0, len(args))
+	for _, arg := range args {
+		if arg.ArgType == gauge.Dynamic && lookup != nil && lookup.ContainsArg(arg.Value) {
+			if value, err := lookup.GetArg(arg.Value); err == nil && value != nil {
+				arg = value
+			}
+		}
+		resolved = append(resolved, arg)
+	}
+	return resolved
+}
+
+func stepText(stepValue string, args []*gauge.StepArg) string {
+	for _, arg := range args {
+		var text string
+		switch arg.ArgType {
+		case gauge.Static:
+			text = fmt.Sprintf("\"%s\"", arg.Value)
+		default:
+			text = fmt.Sprintf("<%s>", arg.ArgValue())
+		}
+		stepValue = strings.Replace(stepValue, gauge.ParameterPlaceholder, text, 1)
+	}
+	return stepValue
+}
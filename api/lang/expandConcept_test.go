@@ -0,0 +1,109 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type conceptInfoProvider struct {
+	dummyInfoProvider
+	concepts map[string]*gauge.Concept
+}
+
+func (p conceptInfoProvider) SearchConceptDictionary(stepValue string) *gauge.Concept {
+	return p.concepts[stepValue]
+}
+
+func newConceptInfoProvider(t *testing.T, conceptText string) conceptInfoProvider {
+	concepts, res := new(parser.ConceptParser).Parse(conceptText, "concept.cpt")
+	if len(res.ParseErrors) > 0 {
+		t.Fatalf("Failed to parse concepts: %v", res.ParseErrors)
+	}
+	p := conceptInfoProvider{concepts: make(map[string]*gauge.Concept)}
+	for _, c := range concepts {
+		p.concepts[c.Value] = &gauge.Concept{ConceptStep: c, FileName: "concept.cpt"}
+	}
+	return p
+}
+
+func expandConceptAt(t *testing.T, specText string, line int) interface{} {
+	openFilesCache = newDocumentStore()
+	uri := lsp.DocumentURI(util.ConvertPathToURI("uri.spec"))
+	openFilesCache.add(uri, specText)
+	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: lsp.Position{Line: line}})
+	p := json.RawMessage(b)
+	got, err := expandConcept(&jsonrpc2.Request{Params: &p})
+	if err != nil {
+		t.Fatalf("Failed to expand concept, err: `%v`", err)
+	}
+	return got
+}
+
+func TestExpandNestedConcept(t *testing.T) {
+	provider = newConceptInfoProvider(t, `# greet <name> in <city>
+* say hello to <name>
+* visit <city> with <name>
+
+# visit <place> with <person>
+* go to <place>
+* meet <person>
+`)
+
+	got := expandConceptAt(t, "# Specification\n## Scenario\n* greet \"john\" in \"pune\"\n", 2)
+
+	want := []string{`say hello to "john"`, `go to "pune"`, `meet "john"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong expansion of concept, got: `%v`, want: `%v`", got, want)
+	}
+}
+
+func TestExpandCyclicConcept(t *testing.T) {
+	provider = newConceptInfoProvider(t, `# first
+* second
+
+# second
+* first
+* a step
+`)
+
+	got := expandConceptAt(t, "# Specification\n## Scenario\n* first\n", 2)
+
+	want := []string{"first", "a step"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wrong expansion of concept, got: `%v`, want: `%v`", got, want)
+	}
+}
+
+func TestExpandConceptForStep(t *testing.T) {
+	provider = newConceptInfoProvider(t, "# first\n* a step\n")
+
+	got := expandConceptAt(t, "# Specification\n## Scenario\n* a step\n", 2)
+
+	if !reflect.DeepEqual(got, []string{}) {
+		t.Errorf("Expected no expansion for a step, got: `%v`", got)
+	}
+}
@@ -22,50 +22,88 @@ import (
 
 	"sync"
 
+	"github.com/getgauge/gauge/logger"
 	"github.com/sourcegraph/go-langserver/pkg/lsp"
 )
 
-type files struct {
-	cache map[lsp.DocumentURI][]string
-	sync.Mutex
+// documentState is the content of a document along with the version the editor last sent it with.
+// A document read from disk has version 0.
+type documentState struct {
+	Content string
+	Version int
+	lines   []string
 }
 
-func (file *files) add(uri lsp.DocumentURI, text string) {
-	file.Lock()
-	defer file.Unlock()
+// documentStore holds the documents known to the language server, keyed by their uri.
+type documentStore struct {
+	documents map[lsp.DocumentURI]documentState
+	sync.RWMutex
+}
+
+func newDocumentStore() *documentStore {
+	return &documentStore{documents: make(map[lsp.DocumentURI]documentState)}
+}
+
+func (store *documentStore) add(uri lsp.DocumentURI, text string) {
+	store.set(uri, text, 0)
+}
+
+func (store *documentStore) set(uri lsp.DocumentURI, text string, version int) {
+	store.Lock()
+	defer store.Unlock()
 	text = strings.Replace(text, "\r\n", "\n", -1)
-	file.cache[uri] = strings.Split(text, "\n")
+	store.documents[uri] = documentState{Content: text, Version: version, lines: strings.Split(text, "\n")}
+}
+
+// update replaces the content of the document if the version is newer than the one in the store.
+// Returns false for a stale change, which is ignored. A change without a version is always applied.
+func (store *documentStore) update(uri lsp.DocumentURI, text string, version int) bool {
+	store.Lock()
+	defer store.Unlock()
+	if doc, ok := store.documents[uri]; ok && version != 0 && version <= doc.Version {
+		return false
+	}
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	store.documents[uri] = documentState{Content: text, Version: version, lines: strings.Split(text, "\n")}
+	return true
+}
+
+func (store *documentStore) remove(uri lsp.DocumentURI) {
+	store.Lock()
+	defer store.Unlock()
+	delete(store.documents, uri)
 }
 
-func (file *files) remove(uri lsp.DocumentURI) {
-	file.Lock()
-	defer file.Unlock()
-	delete(file.cache, uri)
+func (store *documentStore) line(uri lsp.DocumentURI, lineNo int) string {
+	store.RLock()
+	defer store.RUnlock()
+	return store.documents[uri].lines[lineNo]
 }
 
-func (file *files) line(uri lsp.DocumentURI, lineNo int) string {
-	file.Lock()
-	defer file.Unlock()
-	return file.cache[uri][lineNo]
+func (store *documentStore) content(uri lsp.DocumentURI) []string {
+	store.RLock()
+	defer store.RUnlock()
+	return store.documents[uri].lines
 }
 
-func (file *files) content(uri lsp.DocumentURI) []string {
-	file.Lock()
-	defer file.Unlock()
-	return file.cache[uri]
+func (store *documentStore) version(uri lsp.DocumentURI) (int, bool) {
+	store.RLock()
+	defer store.RUnlock()
+	doc, ok := store.documents[uri]
+	return doc.Version, ok
 }
 
-func (file *files) exists(uri lsp.DocumentURI) bool {
-	file.Lock()
-	defer file.Unlock()
-	_, ok := file.cache[uri]
+func (store *documentStore) exists(uri lsp.DocumentURI) bool {
+	store.RLock()
+	defer store.RUnlock()
+	_, ok := store.documents[uri]
 	return ok
 }
 
-var openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+var openFilesCache = newDocumentStore()
 
 func openFile(params lsp.DidOpenTextDocumentParams) {
-	openFilesCache.add(params.TextDocument.URI, params.TextDocument.Text)
+	openFilesCache.set(params.TextDocument.URI, params.TextDocument.Text, params.TextDocument.Version)
 }
 
 func closeFile(params lsp.DidCloseTextDocumentParams) {
@@ -73,7 +111,9 @@ func closeFile(params lsp.DidCloseTextDocumentParams) {
 }
 
 func changeFile(params lsp.DidChangeTextDocumentParams) {
-	openFilesCache.add(params.TextDocument.URI, params.ContentChanges[0].Text)
+	if !openFilesCache.update(params.TextDocument.URI, params.ContentChanges[0].Text, params.TextDocument.Version) {
+		logger.APILog.Debugf("ignoring stale change to %s with version %d", params.TextDocument.URI, params.TextDocument.Version)
+	}
 }
 
 func getLine(uri lsp.DocumentURI, line int) string {
@@ -81,7 +121,9 @@ func getLine(uri lsp.DocumentURI, line int) string {
 }
 
 func getContent(uri lsp.DocumentURI) string {
-	return strings.Join(openFilesCache.content(uri), "\n")
+	openFilesCache.RLock()
+	defer openFilesCache.RUnlock()
+	return openFilesCache.documents[uri].Content
 }
 
 func getLineCount(uri lsp.DocumentURI) int {
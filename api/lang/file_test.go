@@ -0,0 +1,77 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+)
+
+func changeParams(uri lsp.DocumentURI, text string, version int) lsp.DidChangeTextDocumentParams {
+	return lsp.DidChangeTextDocumentParams{
+		TextDocument:   lsp.VersionedTextDocumentIdentifier{TextDocumentIdentifier: lsp.TextDocumentIdentifier{URI: uri}, Version: version},
+		ContentChanges: []lsp.TextDocumentContentChangeEvent{{Text: text}},
+	}
+}
+
+func TestChangeFileUpdatesContentAndVersion(t *testing.T) {
+	openFilesCache = newDocumentStore()
+	uri := lsp.DocumentURI("foo.spec")
+	openFile(lsp.DidOpenTextDocumentParams{TextDocument: lsp.TextDocumentItem{URI: uri, Text: "# Spec", Version: 1}})
+
+	changeFile(changeParams(uri, "# Spec\r\n## Scenario", 2))
+
+	if got := getContent(uri); got != "# Spec\n## Scenario" {
+		t.Errorf("want: `%s`,\n got: `%s`", "# Spec\n## Scenario", got)
+	}
+	if version, _ := openFilesCache.version(uri); version != 2 {
+		t.Errorf("want version 2, got: %d", version)
+	}
+	if got := getLine(uri, 1); got != "## Scenario" {
+		t.Errorf("want: `## Scenario`, got: `%s`", got)
+	}
+}
+
+func TestChangeFileIgnoresStaleChange(t *testing.T) {
+	openFilesCache = newDocumentStore()
+	uri := lsp.DocumentURI("foo.spec")
+	openFile(lsp.DidOpenTextDocumentParams{TextDocument: lsp.TextDocumentItem{URI: uri, Text: "# Spec", Version: 1}})
+	changeFile(changeParams(uri, "# Spec 3", 3))
+
+	changeFile(changeParams(uri, "# Spec 2", 2))
+
+	if got := getContent(uri); got != "# Spec 3" {
+		t.Errorf("want: `# Spec 3`, got: `%s`", got)
+	}
+	if version, _ := openFilesCache.version(uri); version != 3 {
+		t.Errorf("want version 3, got: %d", version)
+	}
+}
+
+func TestCloseFileRemovesDocument(t *testing.T) {
+	openFilesCache = newDocumentStore()
+	uri := lsp.DocumentURI("foo.spec")
+	openFile(lsp.DidOpenTextDocumentParams{TextDocument: lsp.TextDocumentItem{URI: uri, Text: "# Spec", Version: 1}})
+
+	closeFile(lsp.DidCloseTextDocumentParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
+
+	if isOpen(uri) {
+		t.Errorf("expected %s to be closed", uri)
+	}
+}
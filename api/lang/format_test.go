@@ -37,7 +37,7 @@ Scenario Heading
 
 * Step text`
 
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add("foo.spec", specText)
 
 	want := []lsp.TextEdit{
@@ -72,7 +72,7 @@ func TestFormatParseError(t *testing.T) {
 
 * Step text`
 
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add("foo.spec", specText)
 
 	specFile := lsp.DocumentURI("foo.spec")
@@ -0,0 +1,170 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/getgauge/gauge/util"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+const tableColumnSeparator = "|"
+
+func onTypeFormatting(req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.DocumentOnTypeFormattingParams
+	if err := unmarshalParams(req, &params, `{"textDocument": {"uri": string}, "position": {"line": number, "character": number}, "ch": string}`); err != nil {
+		return nil, err
+	}
+	edits := make([]lsp.TextEdit, 0)
+	if params.Ch != tableColumnSeparator || !util.IsSpec(string(params.TextDocument.URI)) || !isOpen(params.TextDocument.URI) {
+		return edits, nil
+	}
+	return alignTable(openFilesCache.content(params.TextDocument.URI), params.Position), nil
+}
+
+// alignTable pads the cells of the table at the given position so that its columns have equal widths.
+// The line with the cursor is edited in two parts, split at the cursor, so that the cursor stays right after the typed separator.
+func alignTable(lines []string, cursor lsp.Position) []lsp.TextEdit {
+	edits := make([]lsp.TextEdit, 0)
+	if cursor.Line < 0 || cursor.Character < 0 || cursor.Line >= len(lines) || !isTableLine(lines[cursor.Line]) {
+		return edits
+	}
+	start, end := cursor.Line, cursor.Line
+	for start > 0 && isTableLine(lines[start-1]) {
+		start--
+	}
+	for end < len(lines)-1 && isTableLine(lines[end+1]) {
+		end++
+	}
+	rows := make([]tableLine, 0)
+	var widths []int
+	for _, line := range lines[start : end+1] {
+		row := parseTableLine(line)
+		for i, cell := range row.cells {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			width := utf8.RuneCountInString(cell)
+			if isUnderline(cell) {
+				width = 1
+			}
+			if width > widths[i] {
+				widths[i] = width
+			}
+		}
+		rows = append(rows, row)
+	}
+	for i, row := range rows {
+		lineNo := start + i
+		old := lines[lineNo]
+		formatted := row.format(widths)
+		if formatted == old {
+			continue
+		}
+		oldLength := utf8.RuneCountInString(old)
+		if lineNo != cursor.Line || cursor.Character > oldLength {
+			edits = append(edits, lineEdit(lineNo, 0, oldLength, formatted))
+			continue
+		}
+		beforeCursor, afterCursor := splitAtCharacter(old, cursor.Character)
+		split := indexOfNthSeparator(formatted, strings.Count(beforeCursor, tableColumnSeparator))
+		if formatted[:split] != beforeCursor {
+			edits = append(edits, lineEdit(lineNo, 0, cursor.Character, formatted[:split]))
+		}
+		if formatted[split:] != afterCursor {
+			edits = append(edits, lineEdit(lineNo, cursor.Character, oldLength, formatted[split:]))
+		}
+	}
+	return edits
+}
+
+type tableLine struct {
+	indent string
+	cells  []string
+	// rest is the text after the last separator, which the user may still be typing.
+	rest string
+}
+
+func isTableLine(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), tableColumnSeparator)
+}
+
+func parseTableLine(line string) tableLine {
+	trimmed := strings.TrimLeft(line, " \t")
+	row := tableLine{indent: line[:len(line)-len(trimmed)]}
+	last := strings.LastIndex(trimmed, tableColumnSeparator)
+	row.rest = trimmed[last+1:]
+	if last == 0 {
+		return row
+	}
+	for _, cell := range strings.Split(trimmed[1:last], tableColumnSeparator) {
+		row.cells = append(row.cells, strings.TrimSpace(cell))
+	}
+	return row
+}
+
+func (row tableLine) format(widths []int) string {
+	formatted := row.indent + tableColumnSeparator
+	for i, cell := range row.cells {
+		if isUnderline(cell) {
+			cell = strings.Repeat("-", widths[i])
+		}
+		formatted += cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + tableColumnSeparator
+	}
+	return formatted + row.rest
+}
+
+func isUnderline(cell string) bool {
+	return cell != "" && strings.Trim(cell, "-") == ""
+}
+
+// splitAtCharacter splits the line before the given character, counting characters rather than bytes as the positions sent by the client do.
+func splitAtCharacter(line string, character int) (string, string) {
+	index := 0
+	for i := 0; i < character && index < len(line); i++ {
+		_, size := utf8.DecodeRuneInString(line[index:])
+		index += size
+	}
+	return line[:index], line[index:]
+}
+
+// indexOfNthSeparator gives the index right after the nth column separator in the line.
+func indexOfNthSeparator(line string, n int) int {
+	index := 0
+	for i := 0; i < n; i++ {
+		next := strings.Index(line[index:], tableColumnSeparator)
+		if next < 0 {
+			return len(line)
+		}
+		index += next + 1
+	}
+	return index
+}
+
+func lineEdit(line, startChar, endChar int, text string) lsp.TextEdit {
+	return lsp.TextEdit{
+		Range: lsp.Range{
+			Start: lsp.Position{Line: line, Character: startChar},
+			End:   lsp.Position{Line: line, Character: endChar},
+		},
+		NewText: text,
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func TestOnTypeFormattingAlignsTableColumns(t *testing.T) {
+	specText := `Specification Heading
+=====================
+* step with table
+   |id|name|
+   |--|----|
+   |1|alexander|`
+	uri := lsp.DocumentURI("foo.spec")
+	openFilesCache = newDocumentStore()
+	openFilesCache.add(uri, specText)
+	defer openFilesCache.remove(uri)
+
+	b, _ := json.Marshal(lsp.DocumentOnTypeFormattingParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: lsp.Position{Line: 5, Character: 16}, Ch: "|"})
+	p := json.RawMessage(b)
+
+	got, err := onTypeFormatting(&jsonrpc2.Request{Params: &p})
+
+	if err != nil {
+		t.Fatalf("Expected error == nil in onTypeFormatting, got %s", err.Error())
+	}
+	want := []lsp.TextEdit{
+		lineEdit(3, 0, 12, "   |id|name     |"),
+		lineEdit(4, 0, 12, "   |--|---------|"),
+		lineEdit(5, 0, 16, "   |1 |alexander|"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func TestAlignTableDoesNotEditAcrossTheCursor(t *testing.T) {
+	lines := []string{
+		"|id|name|",
+		"|1|foo|bar",
+	}
+
+	got := alignTable(lines, lsp.Position{Line: 1, Character: 3})
+
+	want := []lsp.TextEdit{
+		lineEdit(1, 0, 3, "|1 |"),
+		lineEdit(1, 3, 10, "foo |bar"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func TestAlignTableWhenCursorIsNotInATable(t *testing.T) {
+	lines := []string{"* a step", "|id|"}
+
+	got := alignTable(lines, lsp.Position{Line: 0, Character: 3})
+
+	if len(got) != 0 {
+		t.Errorf("Expected no edits. Got %v", got)
+	}
+}
+
+func TestAlignTableWithNegativeCursorPosition(t *testing.T) {
+	lines := []string{"|id|name|", "|1|foo|"}
+
+	for _, cursor := range []lsp.Position{{Line: -1, Character: 3}, {Line: 1, Character: -1}} {
+		got := alignTable(lines, cursor)
+
+		if len(got) != 0 {
+			t.Errorf("Expected no edits for %v. Got %v", cursor, got)
+		}
+	}
+}
+
+func TestOnTypeFormattingForOtherCharacters(t *testing.T) {
+	uri := lsp.DocumentURI("foo.spec")
+	openFilesCache = newDocumentStore()
+	openFilesCache.add(uri, "|id|name|\n|1|foo|")
+	defer openFilesCache.remove(uri)
+
+	b, _ := json.Marshal(lsp.DocumentOnTypeFormattingParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: lsp.Position{Line: 1, Character: 3}, Ch: "a"})
+	p := json.RawMessage(b)
+
+	got, err := onTypeFormatting(&jsonrpc2.Request{Params: &p})
+
+	if err != nil {
+		t.Fatalf("Expected error == nil in onTypeFormatting, got %s", err.Error())
+	}
+	if len(got.([]lsp.TextEdit)) != 0 {
+		t.Errorf("Expected no edits. Got %v", got)
+	}
+}
+
+func TestAlignTableWithMultiByteCharacters(t *testing.T) {
+	lines := []string{
+		"|id|name|",
+		"|1|José|Zoë|",
+	}
+
+	got := alignTable(lines, lsp.Position{Line: 1, Character: 12})
+
+	want := []lsp.TextEdit{
+		lineEdit(1, 0, 12, "|1 |José|Zoë|"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func TestOnTypeFormattingWithInvalidParams(t *testing.T) {
+	p := json.RawMessage(`{"position": "foo"}`)
+
+	_, err := onTypeFormatting(&jsonrpc2.Request{Method: "textDocument/onTypeFormatting", Params: &p})
+
+	if err == nil {
+		t.Fatal("Expected an error for invalid params")
+	}
+	if e, ok := err.(*jsonrpc2.Error); !ok || e.Code != jsonrpc2.CodeInvalidParams {
+		t.Errorf("Expected an invalid params error. Got %v", err)
+	}
+}
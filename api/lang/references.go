@@ -57,7 +57,7 @@ func stepValueAt(req *jsonrpc2.Request) (interface{}, error) {
 func getLocationFor(stepValue string) (interface{}, error) {
 	allSteps := provider.AllSteps()
 	var locations []lsp.Location
-	diskFileCache := &files{cache: make(map[lsp.DocumentURI][]string)}
+	diskFileCache := newDocumentStore()
 	for _, step := range allSteps {
 		if stepValue == step.Value {
 			uri := util.ConvertPathToURI(lsp.DocumentURI(step.FileName))
@@ -21,10 +21,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/parser"
 	"github.com/getgauge/gauge/refactor"
@@ -44,21 +46,14 @@ func rename(ctx context.Context, conn jsonrpc2.JSONRPC2, req *jsonrpc2.Request)
 		return nil, err
 	}
 
-	spec, pResult := new(parser.SpecParser).ParseSpecText(getContent(params.TextDocument.URI), string(util.ConvertURItoFilePath(params.TextDocument.URI)))
-	if !pResult.Ok {
-		return nil, fmt.Errorf("refactoring failed due to parse errors")
-	}
-	var step *gauge.Step
-	for _, item := range spec.AllItems() {
-		if item.Kind() == gauge.StepKind && item.(*gauge.Step).LineNo-1 == params.Position.Line {
-			step = item.(*gauge.Step)
-			break
-		}
+	step, err := stepToRename(params.TextDocument.URI, params.Position.Line)
+	if err != nil {
+		return nil, err
 	}
-	if step == nil {
-		return nil, fmt.Errorf("refactoring is supported for steps only")
+	newName := getNewStepName(params.NewName, step)
+	if err := checkNameConflict(step, newName); err != nil {
+		return nil, renameError(err)
 	}
-	newName := getNewStepName(params, step)
 
 	refactortingResult := refactor.GetRefactoringChanges(step.GetLineText(), newName, lRunner.runner, []string{common.SpecsDirectoryName})
 	for _, warning := range refactortingResult.Warnings {
@@ -81,8 +76,104 @@ func rename(ctx context.Context, conn jsonrpc2.JSONRPC2, req *jsonrpc2.Request)
 	return result, nil
 }
 
-func getNewStepName(params lsp.RenameParams, step *gauge.Step) string {
-	newName := strings.TrimSpace(strings.TrimPrefix(params.NewName, "*"))
+type prepareRenameResult struct {
+	Range       lsp.Range `json:"range"`
+	Placeholder string    `json:"placeholder"`
+}
+
+// prepareRename checks that the step at the position can be renamed, so that clients do not allow renames
+// which would fail when the edits are applied.
+func prepareRename(req *jsonrpc2.Request) (interface{}, error) {
+	var params lsp.TextDocumentPositionParams
+	if err := unmarshalParams(req, &params, `{"textDocument": {"uri": string}, "position": {"line": number, "character": number}}`); err != nil {
+		return nil, err
+	}
+	step, err := stepToRename(params.TextDocument.URI, params.Position.Line)
+	if err != nil {
+		return nil, renameError(err)
+	}
+	if err := checkUsagesWritable(step); err != nil {
+		return nil, renameError(err)
+	}
+	lineText := getLine(params.TextDocument.URI, params.Position.Line)
+	return prepareRenameResult{
+		Range: lsp.Range{
+			Start: lsp.Position{Line: params.Position.Line, Character: 0},
+			End:   lsp.Position{Line: params.Position.Line, Character: len(lineText)},
+		},
+		Placeholder: step.GetLineText(),
+	}, nil
+}
+
+func renameError(err error) *jsonrpc2.Error {
+	return &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidRequest, Message: err.Error()}
+}
+
+func stepToRename(uri lsp.DocumentURI, line int) (*gauge.Step, error) {
+	spec, pResult := new(parser.SpecParser).ParseSpecText(getContent(uri), string(util.ConvertURItoFilePath(uri)))
+	if !pResult.Ok {
+		return nil, fmt.Errorf("refactoring failed due to parse errors")
+	}
+	for _, item := range spec.AllItems() {
+		if item.Kind() == gauge.StepKind && item.(*gauge.Step).LineNo-1 == line {
+			return item.(*gauge.Step), nil
+		}
+	}
+	return nil, fmt.Errorf("refactoring is supported for steps only")
+}
+
+func checkNameConflict(step *gauge.Step, newName string) error {
+	newValue, err := parser.ExtractStepValueAndParams(newName, step.HasInlineTable)
+	if err != nil {
+		return err
+	}
+	if newValue.StepValue == step.Value {
+		return nil
+	}
+	if provider.SearchConceptDictionary(newValue.StepValue) != nil {
+		return fmt.Errorf("a concept with the name '%s' already exists", newName)
+	}
+	if fileName, ok := implementationFileOf(newValue.StepValue); ok {
+		return fmt.Errorf("a step with the name '%s' is already implemented in %s", newName, fileName)
+	}
+	return nil
+}
+
+func checkUsagesWritable(step *gauge.Step) error {
+	files := make(map[string]bool)
+	for _, s := range provider.FindStepUsages(step.Value, len(step.Args)) {
+		if s.FileName != "" {
+			files[s.FileName] = true
+		}
+	}
+	if fileName, ok := implementationFileOf(step.Value); ok {
+		files[fileName] = true
+	}
+	for fileName := range files {
+		f, err := os.OpenFile(fileName, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("%s, which uses the step, can not be written to. %s", fileName, err.Error())
+		}
+		f.Close()
+	}
+	return nil
+}
+
+func implementationFileOf(stepValue string) (string, bool) {
+	stepNameRequest := &gm.Message{MessageType: gm.Message_StepNameRequest, StepNameRequest: &gm.StepNameRequest{StepValue: stepValue}}
+	response, err := GetResponseFromRunner(stepNameRequest)
+	if err != nil {
+		logger.APILog.Infof("Error while connecting to runner : %s", err.Error())
+		return "", false
+	}
+	if !response.GetStepNameResponse().GetIsStepPresent() {
+		return "", false
+	}
+	return response.GetStepNameResponse().GetFileName(), true
+}
+
+func getNewStepName(name string, step *gauge.Step) string {
+	newName := strings.TrimSpace(strings.TrimPrefix(name, "*"))
 	if step.HasInlineTable {
 		newName = fmt.Sprintf("%s <%s>", newName, gauge.TableArg)
 	}
@@ -90,7 +181,7 @@ func getNewStepName(params lsp.RenameParams, step *gauge.Step) string {
 }
 
 func addWorkspaceEdits(result *lsp.WorkspaceEdit, filesChanged map[string]string) error {
-	diskFileCache := &files{cache: make(map[lsp.DocumentURI][]string)}
+	diskFileCache := newDocumentStore()
 	for fileName, text := range filesChanged {
 		uri := util.ConvertPathToURI(lsp.DocumentURI(fileName))
 		var lastLineNo int
@@ -0,0 +1,153 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package lang
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+type renameInfoProvider struct {
+	dummyInfoProvider
+	steps []*gauge.Step
+}
+
+func (p renameInfoProvider) AllSteps() []*gauge.Step {
+	return p.steps
+}
+
+func (p renameInfoProvider) FindStepUsages(stepValue string, argCount int) []*gauge.Step {
+	var usages []*gauge.Step
+	for _, s := range p.steps {
+		if s.Value == stepValue && len(s.Args) == argCount {
+			usages = append(usages, s)
+		}
+	}
+	return usages
+}
+
+func (p renameInfoProvider) SearchConceptDictionary(stepValue string) *gauge.Concept {
+	return nil
+}
+
+func implementedStepsRunner(implFile string, stepValues ...string) func(*gm.Message) (*gm.Message, error) {
+	return func(m *gm.Message) (*gm.Message, error) {
+		for _, v := range stepValues {
+			if v == m.GetStepNameRequest().GetStepValue() {
+				return &gm.Message{MessageType: gm.Message_StepNameResponse, StepNameResponse: &gm.StepNameResponse{IsStepPresent: true, FileName: implFile}}, nil
+			}
+		}
+		return &gm.Message{MessageType: gm.Message_StepNameResponse, StepNameResponse: &gm.StepNameResponse{IsStepPresent: false}}, nil
+	}
+}
+
+func prepareRenameRequest(uri lsp.DocumentURI, line int) *jsonrpc2.Request {
+	b, _ := json.Marshal(lsp.TextDocumentPositionParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: lsp.Position{Line: line, Character: 3}})
+	p := json.RawMessage(b)
+	return &jsonrpc2.Request{Method: "textDocument/prepareRename", Params: &p}
+}
+
+func renameRequest(uri lsp.DocumentURI, line int, newName string) *jsonrpc2.Request {
+	b, _ := json.Marshal(lsp.RenameParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}, Position: lsp.Position{Line: line, Character: 3}, NewName: newName})
+	p := json.RawMessage(b)
+	return &jsonrpc2.Request{Method: "textDocument/rename", Params: &p}
+}
+
+func setUpRenameProject(t *testing.T) (lsp.DocumentURI, func()) {
+	dir, err := ioutil.TempDir("", "gaugeRename")
+	if err != nil {
+		t.Fatal(err)
+	}
+	specFile := filepath.Join(dir, "foo.spec")
+	specText := "# Specification Heading\n\n## Scenario Heading\n\nA comment\n* say hello\n"
+	ioutil.WriteFile(specFile, []byte(specText), 0644)
+	uri := lsp.DocumentURI(specFile)
+	openFilesCache = newDocumentStore()
+	openFilesCache.add(uri, specText)
+	provider = renameInfoProvider{steps: []*gauge.Step{{FileName: specFile, LineNo: 6, Value: "say hello"}}}
+	return uri, func() {
+		openFilesCache.remove(uri)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestPrepareRenameForStep(t *testing.T) {
+	uri, tearDown := setUpRenameProject(t)
+	defer tearDown()
+	GetResponseFromRunner = implementedStepsRunner("")
+
+	got, err := prepareRename(prepareRenameRequest(uri, 5))
+
+	if err != nil {
+		t.Fatalf("expected error to be nil. Got: %v", err)
+	}
+	want := prepareRenameResult{
+		Range:       lsp.Range{Start: lsp.Position{Line: 5, Character: 0}, End: lsp.Position{Line: 5, Character: len("* say hello")}},
+		Placeholder: "say hello",
+	}
+	if got != want {
+		t.Errorf("want: `%v`,\n got: `%v`", want, got)
+	}
+}
+
+func TestPrepareRenameWhenCursorIsNotOnAStep(t *testing.T) {
+	uri, tearDown := setUpRenameProject(t)
+	defer tearDown()
+	GetResponseFromRunner = implementedStepsRunner("")
+
+	_, err := prepareRename(prepareRenameRequest(uri, 4))
+
+	if err == nil || err.Error() != "jsonrpc2: code -32600 message: refactoring is supported for steps only" {
+		t.Errorf("expected error for a comment. Got: %v", err)
+	}
+}
+
+func TestRenameWhenNewNameIsAlreadyImplemented(t *testing.T) {
+	uri, tearDown := setUpRenameProject(t)
+	defer tearDown()
+	GetResponseFromRunner = implementedStepsRunner("StepImpl.java", "say goodbye")
+
+	_, err := rename(context.Background(), nil, renameRequest(uri, 5, "say goodbye"))
+
+	if err == nil || !strings.Contains(err.Error(), "a step with the name 'say goodbye' is already implemented in StepImpl.java") {
+		t.Errorf("expected error for a conflicting name. Got: %v", err)
+	}
+}
+
+func TestPrepareRenameWhenAUsageCanNotBeWritten(t *testing.T) {
+	uri, tearDown := setUpRenameProject(t)
+	defer tearDown()
+	missingImplFile := filepath.Join(filepath.Dir(string(uri)), "missing", "StepImpl.java")
+	GetResponseFromRunner = implementedStepsRunner(missingImplFile, "say hello")
+
+	_, err := prepareRename(prepareRenameRequest(uri, 5))
+
+	if err == nil || !strings.Contains(err.Error(), missingImplFile+", which uses the step, can not be written to.") {
+		t.Errorf("expected error for a file which can not be written. Got: %v", err)
+	}
+}
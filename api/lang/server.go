@@ -40,19 +40,26 @@ import (
 )
 
 type infoProvider interface {
-	Init()
+	Init() error
 	Steps() []*gauge.Step
 	AllSteps() []*gauge.Step
+	FindStepUsages(stepValue string, argCount int) []*gauge.Step
 	Concepts() []*gm.ConceptInfo
 	Params(file string, argType gauge.ArgType) []gauge.StepArg
 	Tags() []string
 	SearchConceptDictionary(string) *gauge.Concept
 	GetAvailableSpecDetails(specs []string) []*infoGatherer.SpecDetail
+	GetSpecsByTag(tags []string) []*infoGatherer.SpecDetail
+	GetSpecForFile(file string) (*gauge.Specification, bool)
+	Events() <-chan infoGatherer.SpecEvent
 }
 
 var provider infoProvider
 var clientCapabilities ClientCapabilities
 
+// initErr is the problem with the project found while the provider was initialized, shown to the client once it is ready.
+var initErr error
+
 type lspHandler struct {
 	jsonrpc2.Handler
 }
@@ -96,7 +103,19 @@ type InitializeParams struct {
 }
 
 type ClientCapabilities struct {
-	SaveFiles bool `json:"saveFiles,omitempty"`
+	SaveFiles bool                        `json:"saveFiles,omitempty"`
+	Workspace WorkspaceClientCapabilities `json:"workspace,omitempty"`
+	Window    WindowClientCapabilities    `json:"window,omitempty"`
+}
+
+type WorkspaceClientCapabilities struct {
+	ApplyEdit bool `json:"applyEdit,omitempty"`
+}
+
+type WindowClientCapabilities struct {
+	ShowDocument struct {
+		Support bool `json:"support,omitempty"`
+	} `json:"showDocument,omitempty"`
 }
 
 func newHandler() jsonrpc2.Handler {
@@ -119,6 +138,9 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 		}
 		return gaugeLSPCapabilities(), nil
 	case "initialized":
+		if initErr != nil {
+			showErrorMessageOnClient(ctx, conn, initErr)
+		}
 		err := registerRunnerCapabilities(conn, ctx)
 		go publishDiagnostics(ctx, conn)
 		return nil, err
@@ -151,10 +173,14 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 			conn.Notify(ctx, "window/showMessage", lsp.ShowMessageParams{Type: 1, Message: err.Error()})
 		}
 		return data, err
+	case "textDocument/onTypeFormatting":
+		return onTypeFormatting(req)
 	case "textDocument/codeLens":
 		return codeLenses(req)
 	case "textDocument/codeAction":
 		return codeActions(req)
+	case "textDocument/prepareRename":
+		return prepareRename(req)
 	case "textDocument/rename":
 		result, err := rename(ctx, conn, req)
 		if err != nil {
@@ -170,14 +196,18 @@ func (h *LangHandler) Handle(ctx context.Context, conn jsonrpc2.JSONRPC2, req *j
 		return stepReferences(req)
 	case "gauge/stepValueAt":
 		return stepValueAt(req)
+	case "gauge/expandConcept":
+		return expandConcept(req)
 	case "gauge/scenarios":
 		return scenarios(req)
 	case "gauge/getImplFiles":
 		return getImplFiles()
 	case "gauge/putStubImpl":
-		return putStubImpl(req)
+		return putStubImpl(ctx, conn, req)
+	case "gauge/putStubImpls":
+		return putStubImpls(req)
 	case "gauge/specs":
-		return specs()
+		return specs(req)
 	case "gauge/executionStatus":
 		return execution.ReadExecutionStatus()
 	default:
@@ -195,19 +225,36 @@ func cacheInitializeParams(req *jsonrpc2.Request) error {
 	return nil
 }
 
-func gaugeLSPCapabilities() lsp.InitializeResult {
+type initializeResult struct {
+	Capabilities serverCapabilities `json:"capabilities,omitempty"`
+}
+
+// serverCapabilities adds the rename options, which lsp.ServerCapabilities does not have, to the capabilities.
+type serverCapabilities struct {
+	lsp.ServerCapabilities
+	RenameProvider renameOptions `json:"renameProvider"`
+}
+
+type renameOptions struct {
+	PrepareProvider bool `json:"prepareProvider"`
+}
+
+func gaugeLSPCapabilities() initializeResult {
 	kind := lsp.TDSKFull
-	return lsp.InitializeResult{
-		Capabilities: lsp.ServerCapabilities{
-			TextDocumentSync:           lsp.TextDocumentSyncOptionsOrKind{Kind: &kind, Options: &lsp.TextDocumentSyncOptions{Save: &lsp.SaveOptions{IncludeText: true}}},
-			CompletionProvider:         &lsp.CompletionOptions{ResolveProvider: true, TriggerCharacters: []string{"*", "* ", "\"", "<", ":", ","}},
-			DocumentFormattingProvider: true,
-			CodeLensProvider:           &lsp.CodeLensOptions{ResolveProvider: false},
-			DefinitionProvider:         true,
-			CodeActionProvider:         true,
-			DocumentSymbolProvider:     true,
-			WorkspaceSymbolProvider:    true,
-			RenameProvider:             true,
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			ServerCapabilities: lsp.ServerCapabilities{
+				TextDocumentSync:                 lsp.TextDocumentSyncOptionsOrKind{Kind: &kind, Options: &lsp.TextDocumentSyncOptions{Save: &lsp.SaveOptions{IncludeText: true}}},
+				CompletionProvider:               &lsp.CompletionOptions{ResolveProvider: true, TriggerCharacters: []string{"*", "* ", "\"", "<", ":", ","}},
+				DocumentFormattingProvider:       true,
+				DocumentOnTypeFormattingProvider: &lsp.DocumentOnTypeFormattingOptions{FirstTriggerCharacter: tableColumnSeparator},
+				CodeLensProvider:                 &lsp.CodeLensOptions{ResolveProvider: false},
+				DefinitionProvider:               true,
+				CodeActionProvider:               true,
+				DocumentSymbolProvider:           true,
+				WorkspaceSymbolProvider:          true,
+			},
+			RenameProvider: renameOptions{PrepareProvider: true},
 		},
 	}
 }
@@ -312,9 +359,13 @@ func initializeRunner() {
 
 func Start(p infoProvider, logLevel string) {
 	provider = p
-	provider.Init()
+	events := provider.Events()
+	if initErr = provider.Init(); initErr != nil {
+		logger.APILog.Errorf("%s", initErr.Error())
+	}
 	initializeRunner()
 	ctx, conn := startLsp(logLevel)
+	go publishDiagnosticsOnEvents(ctx, conn, events)
 	logger.SetCustomLogger(lspLogger{conn, ctx})
 	<-conn.DisconnectNotify()
 	logger.APILog.Info("Connection closed")
@@ -337,6 +388,26 @@ func (stdRWC) Close() error {
 	return os.Stdout.Close()
 }
 
+// unmarshalParams decodes the params of the request into v. Missing or malformed params are reported to the client
+// as an InvalidParams error which names the expected shape of the params.
+func unmarshalParams(req *jsonrpc2.Request, v interface{}, expected string) error {
+	if req.Params == nil {
+		return invalidParamsError(req.Method, expected, "Params are missing.")
+	}
+	if err := json.Unmarshal(*req.Params, v); err != nil {
+		logger.APILog.Debugf("failed to parse request %s", err.Error())
+		return invalidParamsError(req.Method, expected, err.Error())
+	}
+	return nil
+}
+
+func invalidParamsError(method, expected, reason string) *jsonrpc2.Error {
+	return &jsonrpc2.Error{
+		Code:    jsonrpc2.CodeInvalidParams,
+		Message: fmt.Sprintf("Invalid params for %s, expected %s. %s", method, expected, reason),
+	}
+}
+
 type lspLogger struct {
 	conn *jsonrpc2.Conn
 	ctx  context.Context
@@ -272,7 +272,7 @@ Scenario Heading2
 * Step text`
 
 	uri := util.ConvertPathToURI("foo.spec")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, specText)
 	b, _ := json.Marshal(lsp.DocumentSymbolParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
 	p := json.RawMessage(b)
@@ -312,7 +312,7 @@ func TestDocumentSymbolsForConcept(t *testing.T) {
 	`
 
 	uri := util.ConvertPathToURI("foo.cpt")
-	openFilesCache = &files{cache: make(map[lsp.DocumentURI][]string)}
+	openFilesCache = newDocumentStore()
 	openFilesCache.add(uri, cptText)
 	b, _ := json.Marshal(lsp.DocumentSymbolParams{TextDocument: lsp.TextDocumentIdentifier{URI: uri}})
 	p := json.RawMessage(b)
@@ -58,6 +58,7 @@ var (
 		},
 	}
 	logLevel        string
+	logFormat       string
 	dir             string
 	machineReadable bool
 	gaugeVersion    bool
@@ -89,6 +90,7 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.
 Complete manual is available at https://manpage.getgauge.io/.{{end}}
 `)
 	GaugeCmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "info", "Set level of logging to debug, info, warning, error or critical")
+	GaugeCmd.PersistentFlags().StringVarP(&logFormat, "log-format", "", logger.TextFormat, "Set format of the log files to text or json")
 	GaugeCmd.PersistentFlags().StringVarP(&dir, "dir", "d", ".", "Set the working directory for the current command, accepts a path relative to current directory")
 	GaugeCmd.PersistentFlags().BoolVarP(&machineReadable, "machine-readable", "m", false, "Prints output in JSON format")
 	GaugeCmd.Flags().BoolVarP(&gaugeVersion, "version", "v", false, "Print Gauge and plugin versions")
@@ -116,6 +118,9 @@ func getSpecsDir(args []string) []string {
 }
 
 func setGlobalFlags() {
+	if err := logger.SetLogFormat(logFormat); err != nil {
+		logger.Fatalf("%s", err.Error())
+	}
 	logger.Initialize(logLevel)
 	msg := fmt.Sprintf("Gauge Install ID: %s", config.UniqueID())
 	if !lsp {
@@ -140,6 +145,13 @@ func initPackageFlags() {
 	execution.NumberOfExecutionStreams = streams
 	execution.InParallel = parallel
 	execution.Strategy = strategy
+	execution.FailOnNew = failOnNew
+	execution.EventsFile = eventsFile
+	execution.SuiteName = suiteName
+	execution.BeforeAll = beforeAll
+	execution.AfterAll = afterAll
+	execution.ReportDir = reportDir
+	execution.KeepOldReports = keepOldReports
 	filter.ExecuteTags = tags
 	order.Sorted = sort
 	filter.Distribute = group
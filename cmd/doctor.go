@@ -0,0 +1,57 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/doctor"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the health of the project",
+	Long: `Check the health of the project and suggest fixes for the issues found.
+Exits with a non zero status if any check fails.`,
+	Example: `  gauge doctor
+  gauge doctor --env ci`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if e := env.LoadEnv(environment); e != nil {
+			logger.Fatalf("%s", e.Error())
+		}
+		if err := config.SetProjectRoot(args); err != nil {
+			logger.Fatalf("%s", err.Error())
+		}
+		results := doctor.RunChecks()
+		fmt.Print(doctor.Format(results))
+		if !doctor.AllPassed(results) {
+			os.Exit(1)
+		}
+	},
+	DisableAutoGenTag: true,
+}
+
+func init() {
+	GaugeCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().StringVarP(&environment, "env", "e", "default", "Specifies the environment to use")
+}
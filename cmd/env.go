@@ -0,0 +1,84 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/logger"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envCmd = &cobra.Command{
+		Use:   "env [command]",
+		Short: "List the environments of the project and their properties",
+		Long:  `List the environments of the project and their properties.`,
+		Example: `  gauge env list
+  gauge env show default --json`,
+		DisableAutoGenTag: true,
+	}
+	envListCmd = &cobra.Command{
+		Use:     "list [flags]",
+		Short:   "List all the environments and their properties",
+		Long:    `List all the environments and their properties. Values of secret properties are masked.`,
+		Example: "  gauge env list",
+		Run: func(cmd *cobra.Command, args []string) {
+			envs, err := env.Environments()
+			if err != nil {
+				logger.Fatalf("%s", err.Error())
+			}
+			printEnvironments(envs)
+		},
+		DisableAutoGenTag: true,
+	}
+	envShowCmd = &cobra.Command{
+		Use:     "show [flags] <name>",
+		Short:   "Show the properties of an environment",
+		Long:    `Show the properties of an environment. Values of secret properties are masked.`,
+		Example: "  gauge env show default",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				logger.Fatalf("Error: Missing argument <name>.\n%s", cmd.UsageString())
+			}
+			e, err := env.GetEnvironment(args[0])
+			if err != nil {
+				logger.Fatalf("%s", err.Error())
+			}
+			printEnvironments([]*env.Environment{e})
+		},
+		DisableAutoGenTag: true,
+	}
+	envJSON bool
+)
+
+func init() {
+	GaugeCmd.AddCommand(envCmd)
+	envCmd.AddCommand(envListCmd)
+	envCmd.AddCommand(envShowCmd)
+	envCmd.PersistentFlags().BoolVarP(&envJSON, "json", "", false, "Print environments in JSON format")
+}
+
+func printEnvironments(envs []*env.Environment) {
+	text, err := env.FormatEnvironments(envs, envJSON || machineReadable)
+	if err != nil {
+		logger.Fatalf("%s", err.Error())
+	}
+	fmt.Println(text)
+}
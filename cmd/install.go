@@ -31,7 +31,8 @@ var (
 		Long:  `Download and install specified plugin or all plugins in the project's 'manifest.json' file.`,
 		Example: `  gauge install
   gauge install java
-  gauge install java -f gauge-java-0.6.3-darwin.x86_64.zip`,
+  gauge install java -f gauge-java-0.6.3-darwin.x86_64.zip
+  gauge install html-report --constraint ^4`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) < 1 {
 				track.InstallAll()
@@ -45,18 +46,20 @@ var (
 				track.Install(args[0], false)
 				install.HandleInstallResult(install.Plugin(args[0], pVersion), args[0], true)
 			}
-			if err := install.AddPluginToProject(args[0]); err != nil {
+			if err := install.AddPluginToProject(args[0], constraint); err != nil {
 				logger.Fatalf("Failed to add plugin %s to project : %s\n", args[0], err.Error())
 			}
 		},
 		DisableAutoGenTag: true,
 	}
-	zip      string
-	pVersion string
+	zip        string
+	pVersion   string
+	constraint string
 )
 
 func init() {
 	GaugeCmd.AddCommand(installCmd)
 	installCmd.Flags().StringVarP(&zip, "file", "f", "", "Installs the plugin from zip file")
 	installCmd.Flags().StringVarP(&pVersion, "version", "v", "", "Version of plugin to be installed")
+	installCmd.Flags().StringVarP(&constraint, "constraint", "", "", "Version constraint of the plugin recorded in the project's manifest, e.g. ^4 or ~1.2")
 }
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 
@@ -80,18 +81,26 @@ var (
 		},
 		DisableAutoGenTag: true,
 	}
-	verbose       bool
-	simpleConsole bool
-	failed        bool
-	repeat        bool
-	parallel      bool
-	sort          bool
-	environment   string
-	tags          string
-	rows          string
-	strategy      string
-	streams       int
-	group         int
+	verbose        bool
+	simpleConsole  bool
+	failed         bool
+	repeat         bool
+	parallel       bool
+	sort           bool
+	environment    string
+	tags           string
+	rows           string
+	strategy       string
+	streams        int
+	group          int
+	failOnNew      bool
+	filterFile     string
+	eventsFile     string
+	suiteName      string
+	beforeAll      string
+	afterAll       string
+	reportDir      string
+	keepOldReports bool
 )
 
 func init() {
@@ -109,6 +118,14 @@ func init() {
 	runCmd.Flags().BoolVarP(&failed, "failed", "f", false, "Run only the scenarios failed in previous run")
 	runCmd.Flags().BoolVarP(&repeat, "repeat", "", false, "Repeat last run")
 	runCmd.Flags().BoolVarP(&hideSuggestion, "hide-suggestion", "", false, "Prints a step implementation stub for every unimplemented step")
+	runCmd.Flags().StringVarP(&filterFile, "filter-file", "", "", "Executes only the specs listed in the given file, one path per line")
+	runCmd.Flags().BoolVarP(&failOnNew, "fail-on-new", "", false, "Fail if a spec which was not in the previous run has unimplemented steps. The specs of each run are recorded in .gauge/last-run.json")
+	runCmd.Flags().StringVarP(&eventsFile, "events-file", "", "", "Writes the execution events to the given file as newline delimited JSON")
+	runCmd.Flags().StringVarP(&suiteName, "suite-name", "", "", "Labels the run in reports and the events file, default being the project directory name")
+	runCmd.Flags().StringVarP(&beforeAll, "before-all", "", "", "Runs the given shell command before the first spec. Execution is aborted if the command fails")
+	runCmd.Flags().StringVarP(&afterAll, "after-all", "", "", "Runs the given shell command after the last spec")
+	runCmd.Flags().StringVarP(&reportDir, "report-dir", "", "", "Writes the reports to the given directory, clearing reports of earlier runs")
+	runCmd.Flags().BoolVarP(&keepOldReports, "keep-old-reports", "", false, "Does not clear the directory given to --report-dir")
 }
 
 //This flag stores whether the command is gauge run --failed and if it is triggering another command.
@@ -130,12 +147,20 @@ func loadLastState(cmd *cobra.Command) {
 }
 
 func resetFlags() {
-	verbose, simpleConsole, failed, repeat, parallel, sort, hideSuggestion = false, false, false, false, false, false, false
-	environment, tags, rows, strategy, logLevel, dir = "default", "", "", "lazy", "info", "."
+	verbose, simpleConsole, failed, repeat, parallel, sort, hideSuggestion, failOnNew = false, false, false, false, false, false, false, false
+	environment, tags, rows, strategy, logLevel, dir, filterFile, eventsFile, suiteName = "default", "", "", "lazy", "info", ".", "", "", ""
+	beforeAll, afterAll, reportDir, keepOldReports = "", "", "", false
 	streams, group = util.NumberOfCores(), -1
 }
 
 func execute(args []string) {
+	if filterFile != "" {
+		paths, err := readFilterFile(filterFile)
+		if err != nil {
+			logger.Fatalf("%s", err.Error())
+		}
+		args = append(args, paths...)
+	}
 	specs := getSpecsDir(args)
 	rerun.SaveState(os.Args[1:], specs)
 	track.Execution(parallel, tags != "", sort, simpleConsole, verbose, hideSuggestion, strategy)
@@ -143,6 +168,26 @@ func execute(args []string) {
 	os.Exit(exitCode)
 }
 
+// readFilterFile reads the spec paths listed in the file, one per line. Empty lines and lines starting with # are ignored.
+func readFilterFile(file string) ([]string, error) {
+	contents, err := common.ReadFileContents(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read filter file %s. %s", file, err.Error())
+	}
+	var paths []string
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("No spec paths found in filter file %s", file)
+	}
+	return paths, nil
+}
+
 func handleRepeatCommand(cmd *cobra.Command, cmdArgs []string) {
 	if repeat {
 		prevCmd := readPrevCmd()
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -76,3 +77,29 @@ func TestHandleRepeatCommandForFailedCommandFlow(t *testing.T) {
 		t.Errorf("Expected %v  Got %v", args, commandWritten.Command)
 	}
 }
+
+func TestReadFilterFile(t *testing.T) {
+	file := filepath.Join(path, "filter.txt")
+	ioutil.WriteFile(file, []byte("# specs changed in this commit\nspecs/login.spec\n\n  specs/cart.spec  \r\n"), 0644)
+	defer os.Remove(file)
+
+	got, err := readFilterFile(file)
+
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	want := []string{"specs/login.spec", "specs/cart.spec"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v  Got %v", want, got)
+	}
+}
+
+func TestReadFilterFileWithoutSpecs(t *testing.T) {
+	file := filepath.Join(path, "filter.txt")
+	ioutil.WriteFile(file, []byte("# nothing to run\n"), 0644)
+	defer os.Remove(file)
+
+	if _, err := readFilterFile(file); err == nil {
+		t.Error("Expected an error for a filter file without spec paths")
+	}
+}
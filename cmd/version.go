@@ -20,9 +20,11 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/plugin"
 	"github.com/getgauge/gauge/version"
 	"github.com/spf13/cobra"
@@ -34,8 +36,13 @@ var (
 		Short: "Print Gauge and plugin versions",
 		Long:  `Print Gauge and plugin versions.`,
 		Example: `  gauge version
-  gauge version -m`,
+  gauge version -m
+  gauge version --json`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if versionJSON {
+				printVersionJSON(cmd.OutOrStdout())
+				return
+			}
 			if machineReadable {
 				printJSONVersion()
 				return
@@ -44,10 +51,12 @@ var (
 		},
 		DisableAutoGenTag: true,
 	}
+	versionJSON bool
 )
 
 func init() {
 	GaugeCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVarP(&versionJSON, "json", "", false, "Print Gauge and plugin versions in JSON format")
 }
 
 func printVersion() {
@@ -80,6 +89,35 @@ func printJSONVersion() {
 	fmt.Println(fmt.Sprintf("%s\n", string(b)))
 }
 
+type pluginVersion struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+type gaugeVersionInfo struct {
+	Gauge   string           `json:"gauge"`
+	Plugins []*pluginVersion `json:"plugins"`
+}
+
+// printVersionJSON prints the versions as {"gauge": ..., "plugins": [{"id": ..., "version": ...}]}.
+// Unlike the output of -m, the schema of this output is kept stable for tools which parse it.
+func printVersionJSON(w io.Writer) {
+	plugins, _ := plugin.GetAllInstalledPluginsWithVersion()
+	b, err := versionInfoJSON(version.FullVersion(), plugins)
+	if err != nil {
+		logger.Fatalf("%s", err.Error())
+	}
+	fmt.Fprintln(w, string(b))
+}
+
+func versionInfoJSON(gaugeVersion string, plugins []plugin.PluginInfo) ([]byte, error) {
+	info := gaugeVersionInfo{Gauge: gaugeVersion, Plugins: make([]*pluginVersion, 0)}
+	for _, p := range plugins {
+		info.Plugins = append(info.Plugins, &pluginVersion{ID: p.Name, Version: filepath.Base(p.Path)})
+	}
+	return json.Marshal(info)
+}
+
 func printTextVersion() {
 	fmt.Printf("Gauge version: %s\n", version.FullVersion())
 	v := version.GetCommitHash()
@@ -0,0 +1,84 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/version"
+)
+
+func TestVersionInfoJSON(t *testing.T) {
+	plugins := []plugin.PluginInfo{{Name: "html-report", Path: filepath.Join("plugins", "html-report", "4.2.0")}}
+
+	b, err := versionInfoJSON("1.2.3", plugins)
+
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	want := `{"gauge":"1.2.3","plugins":[{"id":"html-report","version":"4.2.0"}]}`
+	if string(b) != want {
+		t.Errorf("Expected %s\nGot %s", want, string(b))
+	}
+}
+
+func TestVersionInfoJSONWithoutPlugins(t *testing.T) {
+	b, err := versionInfoJSON("1.2.3", nil)
+
+	if err != nil {
+		t.Fatalf("Expected no error. Got %s", err.Error())
+	}
+	want := `{"gauge":"1.2.3","plugins":[]}`
+	if string(b) != want {
+		t.Errorf("Expected %s\nGot %s", want, string(b))
+	}
+}
+
+func TestVersionWithJSONFlag(t *testing.T) {
+	gaugeHome, err := ioutil.TempDir("", "gaugeHome")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(gaugeHome)
+	if err := os.MkdirAll(filepath.Join(gaugeHome, "plugins", "html-report", "4.2.0"), common.NewDirectoryPermissions); err != nil {
+		t.Fatal(err)
+	}
+	old := os.Getenv(common.GaugeHome)
+	os.Setenv(common.GaugeHome, gaugeHome)
+	defer os.Setenv(common.GaugeHome, old)
+	if err := versionCmd.Flags().Set("json", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { versionJSON = false }()
+	out := new(bytes.Buffer)
+	versionCmd.SetOutput(out)
+	defer versionCmd.SetOutput(nil)
+
+	versionCmd.Run(versionCmd, []string{})
+
+	want := `{"gauge":"` + version.FullVersion() + `","plugins":[{"id":"html-report","version":"4.2.0"}]}` + "\n"
+	if out.String() != want {
+		t.Errorf("Expected %s\nGot %s", want, out.String())
+	}
+}
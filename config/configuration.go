@@ -18,6 +18,7 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -36,22 +37,33 @@ const (
 	runnerConnectionTimeout = "runner_connection_timeout"
 	pluginConnectionTimeout = "plugin_connection_timeout"
 	pluginKillTimeOut       = "plugin_kill_timeout"
+	pluginWriteTimeout      = "plugin_write_timeout"
 	runnerRequestTimeout    = "runner_request_timeout"
 	checkUpdates            = "check_updates"
 	telemetryEnabled        = "gauge_telemetry_enabled"
 	telemetryLoggingEnabled = "gauge_telemetry_log_enabled"
+	apiLogRateLimit         = "api_log_rate_limit"
+	pluginMaxMemory         = "plugin_max_memory"
+	parseFailureThreshold   = "parse_failure_threshold"
 
 	defaultRunnerConnectionTimeout = time.Second * 25
 	defaultPluginConnectionTimeout = time.Second * 10
 	defaultPluginKillTimeout       = time.Second * 4
+	defaultPluginWriteTimeout      = time.Second * 10
 	defaultRefactorTimeout         = time.Second * 10
 	defaultRunnerRequestTimeout    = time.Second * 3
+	defaultAPILogRateLimit         = 20
+	defaultPluginMaxMemory         = 0
+	defaultParseFailureThreshold   = 0.9
 	LayoutForTimeStamp             = "Jan 2, 2006 at 3:04pm"
 )
 
 var APILog = logging.MustGetLogger("gauge-api")
 var ProjectRoot string
 
+// environmentProperties holds the properties of the project's current environment. Gauge's configuration takes precedence over them.
+var environmentProperties = make(map[string]string)
+
 // RunnerConnectionTimeout gets timeout in milliseconds for making a connection to the language runner
 func RunnerConnectionTimeout() time.Duration {
 	intervalString := getFromConfig(runnerConnectionTimeout)
@@ -70,6 +82,12 @@ func PluginKillTimeout() time.Duration {
 	return convertToTime(intervalString, defaultPluginKillTimeout, pluginKillTimeOut)
 }
 
+// PluginWriteTimeout gets timeout in milliseconds for a message to be written to a plugin
+func PluginWriteTimeout() time.Duration {
+	intervalString := getFromConfig(pluginWriteTimeout)
+	return convertToTime(intervalString, defaultPluginWriteTimeout, pluginWriteTimeout)
+}
+
 // CheckUpdates determines if update check is enabled
 func CheckUpdates() bool {
 	allow := getFromConfig(checkUpdates)
@@ -90,6 +108,33 @@ func RunnerRequestTimeout() time.Duration {
 	return convertToTime(intervalString, defaultRunnerRequestTimeout, runnerRequestTimeout)
 }
 
+// APILogRateLimit gets the maximum number of file change messages the API logs in a second. A value of 0 disables the limit.
+func APILogRateLimit() int {
+	limit := os.Getenv(apiLogRateLimit)
+	if limit == "" {
+		limit = getFromConfig(apiLogRateLimit)
+	}
+	return convertToInt(limit, defaultAPILogRateLimit, apiLogRateLimit)
+}
+
+// PluginMaxMemory gets the memory in MB a plugin may use before Gauge kills it. A value of 0 disables the limit.
+func PluginMaxMemory() int {
+	limit := os.Getenv(pluginMaxMemory)
+	if limit == "" {
+		limit = getFromConfig(pluginMaxMemory)
+	}
+	return convertToInt(limit, defaultPluginMaxMemory, pluginMaxMemory)
+}
+
+// ParseFailureThreshold gets the fraction of spec files which may fail to parse before Gauge reports a problem with the project.
+func ParseFailureThreshold() float64 {
+	threshold := os.Getenv(parseFailureThreshold)
+	if threshold == "" {
+		threshold = getFromConfig(parseFailureThreshold)
+	}
+	return convertToFraction(threshold, defaultParseFailureThreshold, parseFailureThreshold)
+}
+
 // GaugeRepositoryUrl fetches the repository URL to locate plugins
 func GaugeRepositoryUrl() string {
 	return getFromConfig(gaugeRepositoryURL)
@@ -121,15 +166,12 @@ func TelemetryLogEnabled() bool {
 }
 
 // SetProjectRoot sets project root location in ENV.
+// If a spec path is given, the project root is looked up from it, otherwise from the working directory.
 func SetProjectRoot(args []string) error {
 	if ProjectRoot != "" {
 		return setCurrentProjectEnvVariable()
 	}
-	value := ""
-	if len(args) != 0 {
-		value = args[0]
-	}
-	root, err := common.GetProjectRootFromSpecPath(value)
+	root, err := projectRootFor(args)
 	if err != nil {
 		return err
 	}
@@ -137,6 +179,50 @@ func SetProjectRoot(args []string) error {
 	return setCurrentProjectEnvVariable()
 }
 
+func projectRootFor(args []string) (string, error) {
+	if len(args) != 0 {
+		if root, err := FindProjectRoot(args[0]); err == nil {
+			return root, nil
+		}
+	}
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("Failed to find project root directory. %s", err.Error())
+	}
+	return FindProjectRoot(pwd)
+}
+
+// FindProjectRoot returns the project root for the given path, i.e. the nearest directory
+// containing a manifest.json file, starting from the path itself and walking upwards.
+func FindProjectRoot(startPath string) (string, error) {
+	dir, err := filepath.Abs(startPath)
+	if err != nil {
+		return "", fmt.Errorf("Failed to find project directory: %s", err.Error())
+	}
+	if !common.DirExists(dir) {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		if common.FileExists(filepath.Join(dir, common.ManifestFile)) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("Failed to find project directory containing %s from %s", common.ManifestFile, startPath)
+		}
+		dir = parent
+	}
+}
+
+// SetEnvironmentProperties sets the properties of the project's current environment, as loaded by the env package.
+// They are used for the properties which Gauge's configuration does not have.
+func SetEnvironmentProperties(properties map[string]string) {
+	environmentProperties = make(map[string]string, len(properties))
+	for k, v := range properties {
+		environmentProperties[k] = v
+	}
+}
+
 // UniqueID gets the unique installation ID.
 func UniqueID() string {
 	configDir, err := common.GetConfigurationDir()
@@ -166,6 +252,24 @@ func convertToTime(value string, defaultValue time.Duration, name string) time.D
 	return time.Millisecond * time.Duration(intValue)
 }
 
+func convertToInt(value string, defaultValue int, property string) int {
+	intValue, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || intValue < 0 {
+		APILog.Warningf("Incorrect value for %s in property file. Cannot convert %s to a non negative number.", property, value)
+		return defaultValue
+	}
+	return intValue
+}
+
+func convertToFraction(value string, defaultValue float64, property string) float64 {
+	fraction, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || fraction <= 0 || fraction > 1 {
+		APILog.Warningf("Incorrect value for %s in property file. Cannot convert %s to a fraction between 0 and 1.", property, value)
+		return defaultValue
+	}
+	return fraction
+}
+
 func convertToBool(value string, property string, defaultValue bool) bool {
 	boolValue, err := strconv.ParseBool(strings.TrimSpace(value))
 	if err != nil {
@@ -179,7 +283,15 @@ var getFromConfig = func(propertyName string) string {
 	config, err := common.GetGaugeConfiguration()
 	if err != nil {
 		APILog.Warningf("Failed to get configuration from Gauge properties file. Error: %s", err.Error())
-		return ""
 	}
-	return config[propertyName]
+	return configValue(propertyName, config)
+}
+
+// configValue gives the value of the property in Gauge's configuration, falling back to the project's environment
+// for the properties which are not in Gauge's configuration.
+func configValue(propertyName string, gaugeConfig map[string]string) string {
+	if value, ok := gaugeConfig[propertyName]; ok {
+		return value
+	}
+	return environmentProperties[propertyName]
 }
@@ -65,6 +65,55 @@ func TestRunnerRequestTimeout(t *testing.T) {
 	}
 }
 
+func TestAPILogRateLimit(t *testing.T) {
+	getFromConfig = stubGetFromConfig
+	if got := APILogRateLimit(); got != defaultAPILogRateLimit {
+		t.Errorf("Expected APILogRateLimit == defaultAPILogRateLimit(%d), got %d", defaultAPILogRateLimit, got)
+	}
+
+	getFromConfig = stub2GetFromConfig
+	if got := APILogRateLimit(); got != 10000 {
+		t.Errorf("Expected APILogRateLimit == 10000, got %d", got)
+	}
+
+	os.Setenv(apiLogRateLimit, "5")
+	defer os.Unsetenv(apiLogRateLimit)
+	if got := APILogRateLimit(); got != 5 {
+		t.Errorf("Expected APILogRateLimit == 5, got %d", got)
+	}
+}
+
+func TestPluginMaxMemory(t *testing.T) {
+	getFromConfig = stubGetFromConfig
+	if got := PluginMaxMemory(); got != defaultPluginMaxMemory {
+		t.Errorf("Expected PluginMaxMemory == defaultPluginMaxMemory(%d), got %d", defaultPluginMaxMemory, got)
+	}
+
+	os.Setenv(pluginMaxMemory, "512")
+	defer os.Unsetenv(pluginMaxMemory)
+	if got := PluginMaxMemory(); got != 512 {
+		t.Errorf("Expected PluginMaxMemory == 512, got %d", got)
+	}
+}
+
+func TestParseFailureThreshold(t *testing.T) {
+	getFromConfig = stubGetFromConfig
+	if got := ParseFailureThreshold(); got != defaultParseFailureThreshold {
+		t.Errorf("Expected ParseFailureThreshold == defaultParseFailureThreshold(%v), got %v", defaultParseFailureThreshold, got)
+	}
+
+	os.Setenv(parseFailureThreshold, "0.5")
+	defer os.Unsetenv(parseFailureThreshold)
+	if got := ParseFailureThreshold(); got != 0.5 {
+		t.Errorf("Expected ParseFailureThreshold == 0.5, got %v", got)
+	}
+
+	os.Setenv(parseFailureThreshold, "2")
+	if got := ParseFailureThreshold(); got != defaultParseFailureThreshold {
+		t.Errorf("Expected ParseFailureThreshold == defaultParseFailureThreshold(%v) for a value above 1, got %v", defaultParseFailureThreshold, got)
+	}
+}
+
 func TestAllowUpdates(t *testing.T) {
 	getFromConfig = stubGetFromConfig
 	if !CheckUpdates() {
@@ -109,3 +158,52 @@ func TestReadUniqueID(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestFindProjectRoot(t *testing.T) {
+	root, err := ioutil.TempDir("", "gaugeTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	root, _ = filepath.EvalSymlinks(root)
+	specsDir := filepath.Join(root, "specs", "nested")
+	os.MkdirAll(specsDir, common.NewDirectoryPermissions)
+	ioutil.WriteFile(filepath.Join(root, common.ManifestFile), []byte("{}"), common.NewFilePermissions)
+	specFile := filepath.Join(specsDir, "example.spec")
+	ioutil.WriteFile(specFile, []byte("# Spec"), common.NewFilePermissions)
+
+	for _, path := range []string{root, specsDir, specFile} {
+		got, err := FindProjectRoot(path)
+		if err != nil {
+			t.Errorf("Expected project root for %s, got error %s", path, err.Error())
+		}
+		if got != root {
+			t.Errorf("Expected project root for %s == %s, got %s", path, root, got)
+		}
+	}
+}
+
+func TestFindProjectRootWithoutManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gaugeTest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := FindProjectRoot(dir); err == nil {
+		t.Error("Expected an error when there is no manifest.json")
+	}
+}
+
+func TestConfigValueDoesNotLetEnvironmentOverrideGaugeProperties(t *testing.T) {
+	defer SetEnvironmentProperties(nil)
+	SetEnvironmentProperties(map[string]string{runnerRequestTimeout: "100", "foo": "staging"})
+	gaugeConfig := map[string]string{runnerRequestTimeout: "30000"}
+
+	if got := configValue(runnerRequestTimeout, gaugeConfig); got != "30000" {
+		t.Errorf("Expected %s == 30000, got %s", runnerRequestTimeout, got)
+	}
+	if got := configValue("foo", gaugeConfig); got != "staging" {
+		t.Errorf("Expected foo == staging, got %s", got)
+	}
+}
@@ -28,14 +28,18 @@ func TestJSONFormatter(t *testing.T) {
 	want := []string{
 		"----------------------------------------------------------------------",
 		"Key                           	Value                              ",
+		"api_log_rate_limit            	20                                 ",
 		"check_updates                 	true                               ",
 		"gauge_repository_url          	https://downloads.getgauge.io/plugin",
 		"gauge_telemetry_enabled       	true                               ",
 		"gauge_telemetry_log_enabled   	false                              ",
 		"gauge_templates_url           	https://downloads.getgauge.io/templates",
 		"gauge_update_url              	https://downloads.getgauge.io/gauge",
+		"parse_failure_threshold       	0.9                                ",
 		"plugin_connection_timeout     	10000                              ",
 		"plugin_kill_timeout           	4000                               ",
+		"plugin_max_memory             	0                                  ",
+		"plugin_write_timeout          	10000                              ",
 		"runner_connection_timeout     	30000                              ",
 		"runner_request_timeout        	30000                              ",
 	}
@@ -95,10 +95,14 @@ func Properties() *properties {
 		runnerConnectionTimeout: newProperty(runnerConnectionTimeout, "30000", "Timeout in milliseconds for making a connection to the language runner."),
 		pluginConnectionTimeout: newProperty(pluginConnectionTimeout, "10000", "Timeout in milliseconds for making a connection to plugins."),
 		pluginKillTimeOut:       newProperty(pluginKillTimeOut, "4000", "Timeout in milliseconds for a plugin to stop after a kill message has been sent."),
+		pluginWriteTimeout:      newProperty(pluginWriteTimeout, "10000", "Timeout in milliseconds for a message to be written to a plugin."),
 		runnerRequestTimeout:    newProperty(runnerRequestTimeout, "30000", "Timeout in milliseconds for requests from the language runner."),
 		checkUpdates:            newProperty(checkUpdates, "true", "Allow Gauge and its plugin updates to be notified."),
 		telemetryEnabled:        newProperty(telemetryEnabled, "true", "Allow Gauge to collect anonymous usage statistics"),
 		telemetryLoggingEnabled: newProperty(telemetryLoggingEnabled, "false", "Log request sent to Gauge telemetry engine"),
+		apiLogRateLimit:         newProperty(apiLogRateLimit, "20", "Maximum number of file change messages logged by the API in a second. 0 logs every message."),
+		pluginMaxMemory:         newProperty(pluginMaxMemory, "0", "Memory in MB a plugin may use before it is killed. 0 does not limit the memory."),
+		parseFailureThreshold:   newProperty(parseFailureThreshold, "0.9", "Fraction of spec files which may fail to parse before the API reports a problem with the project."),
 	}}
 }
 
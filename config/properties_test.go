@@ -110,6 +110,9 @@ plugin_connection_timeout = 10000
 # Timeout in milliseconds for a plugin to stop after a kill message has been sent.
 plugin_kill_timeout = 4000
 
+# Timeout in milliseconds for a message to be written to a plugin.
+plugin_write_timeout = 10000
+
 # Timeout in milliseconds for requests from the language runner.
 runner_request_timeout = 30000
 
@@ -130,6 +133,15 @@ check_updates = true
 
 # Allow Gauge to collect anonymous usage statistics
 gauge_telemetry_enabled = true
+
+# Maximum number of file change messages logged by the API in a second. 0 logs every message.
+api_log_rate_limit = 20
+
+# Memory in MB a plugin may use before it is killed. 0 does not limit the memory.
+plugin_max_memory = 0
+
+# Fraction of spec files which may fail to parse before the API reports a problem with the project.
+parse_failure_threshold = 0.9
 `
 	want := strings.Split(propertiesContent, "\n")
 	sort.Strings(want)
@@ -108,6 +108,18 @@ func Write(conn net.Conn, messageBytes []byte) error {
 	return err
 }
 
+// WriteWithTimeout writes the message to the connection and returns an error if the write does not complete within the timeout.
+// A timeout of zero means no deadline.
+func WriteWithTimeout(conn net.Conn, messageBytes []byte, timeout time.Duration) error {
+	if timeout > 0 {
+		if err := conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+			return err
+		}
+		defer conn.SetWriteDeadline(time.Time{})
+	}
+	return Write(conn, messageBytes)
+}
+
 func WriteGaugeMessage(message *gauge_messages.Message, conn net.Conn) error {
 	messageID := common.GetUniqueID()
 	message.MessageId = messageID
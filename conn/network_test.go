@@ -241,3 +241,41 @@ func TestGetResponseForGaugeMessageShoudNotErrorIfNoTimeoutIsSpecified(t *testin
 		t.Errorf("expected : %v\ngot : %v", responseMessage, res)
 	}
 }
+
+func TestWriteWithTimeoutShouldErrorIfTheOtherEndDoesNotRead(t *testing.T) {
+	gaugeEnd, pluginEnd := net.Pipe()
+	defer gaugeEnd.Close()
+	defer pluginEnd.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- WriteWithTimeout(gaugeEnd, []byte("message"), 50*time.Millisecond)
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected write to a plugin which does not read to time out")
+		}
+		if e, ok := err.(net.Error); !ok || !e.Timeout() {
+			t.Errorf("expected a timeout error, got %s", err.Error())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("write to a plugin which does not read did not time out")
+	}
+}
+
+func TestWriteWithTimeoutShouldWriteTheMessage(t *testing.T) {
+	gaugeEnd, pluginEnd := net.Pipe()
+	defer gaugeEnd.Close()
+	defer pluginEnd.Close()
+
+	go func() {
+		b := make([]byte, 10)
+		pluginEnd.Read(b)
+	}()
+
+	if err := WriteWithTimeout(gaugeEnd, []byte("message"), time.Second); err != nil {
+		t.Errorf("expected write to succeed, got %s", err.Error())
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/manifest"
+	"github.com/getgauge/gauge/parser"
+	"github.com/getgauge/gauge/plugin"
+	"github.com/getgauge/gauge/runner"
+	"github.com/getgauge/gauge/util"
+)
+
+const (
+	successSymbol = "✔"
+	failureSymbol = "✘"
+)
+
+// CheckResult is the outcome of one health check of the project.
+type CheckResult struct {
+	Name   string
+	Errors []error
+	Fix    string
+}
+
+// Passed tells whether the check found no issues.
+func (r *CheckResult) Passed() bool {
+	return len(r.Errors) == 0
+}
+
+type check struct {
+	name string
+	fix  string
+	run  func() []error
+}
+
+var checks = []check{
+	{
+		name: "Project manifest is valid and its plugins are installed",
+		fix:  "Run `gauge install` to install the language runner and plugins listed in manifest.json.",
+		run:  checkManifest,
+	},
+	{
+		name: "Specifications parse without errors",
+		fix:  "Correct the reported lines and run `gauge validate` to confirm.",
+		run:  checkSpecs,
+	},
+	{
+		name: "Concepts parse without errors and have no circular references",
+		fix:  "Correct the reported concepts so that no concept uses itself, directly or through other concepts.",
+		run:  checkConcepts,
+	},
+	{
+		name: "Language runner can be started",
+		fix:  "Run `gauge install <language>` to reinstall the runner, and `gauge --log-level debug doctor` for details.",
+		run:  checkRunner,
+	},
+	{
+		name: "Environment properties files are valid",
+		fix:  "Correct the syntax of the reported properties files in the env directory.",
+		run:  checkEnvironments,
+	},
+}
+
+// RunChecks runs all the health checks on the current project.
+func RunChecks() []*CheckResult {
+	var results []*CheckResult
+	for _, c := range checks {
+		results = append(results, &CheckResult{Name: c.name, Errors: c.run(), Fix: c.fix})
+	}
+	return results
+}
+
+// AllPassed tells whether none of the checks found an issue.
+func AllPassed(results []*CheckResult) bool {
+	for _, r := range results {
+		if !r.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Format renders the results for the console, listing the issues and a suggested fix for every failed check.
+func Format(results []*CheckResult) string {
+	var b bytes.Buffer
+	for _, r := range results {
+		if r.Passed() {
+			fmt.Fprintf(&b, "%s %s\n", successSymbol, r.Name)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s\n", failureSymbol, r.Name)
+		for _, err := range r.Errors {
+			fmt.Fprintf(&b, "    %s\n", strings.TrimSpace(err.Error()))
+		}
+		fmt.Fprintf(&b, "    Suggested fix: %s\n", r.Fix)
+	}
+	return b.String()
+}
+
+func checkManifest() []error {
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	if m.Language == "" {
+		errs = append(errs, fmt.Errorf("No language is specified in %s", common.ManifestFile))
+	} else if !plugin.IsPluginInstalled(m.Language, "") {
+		errs = append(errs, fmt.Errorf("Language runner %s is not installed", m.Language))
+	}
+	for _, id := range m.Plugins {
+		if !plugin.IsPluginInstalled(id, "") {
+			errs = append(errs, fmt.Errorf("Plugin %s is not installed", id))
+		}
+	}
+	return errs
+}
+
+func checkSpecs() []error {
+	specDir := filepath.Join(config.ProjectRoot, common.SpecsDirectoryName)
+	if !common.DirExists(specDir) {
+		return nil
+	}
+	_, results := parser.ParseSpecFiles(util.FindSpecFilesIn(specDir), gauge.NewConceptDictionary(), gauge.NewBuildErrors())
+	var errs []error
+	for _, res := range results {
+		for _, e := range res.ParseErrors {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+func checkConcepts() []error {
+	dict := gauge.NewConceptDictionary()
+	_, parseErrs, err := parser.AddConcepts(util.GetConceptFiles(), dict)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range parseErrs {
+		errs = append(errs, e)
+	}
+	for _, e := range parser.ValidateConcepts(dict).ParseErrors {
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+func checkRunner() []error {
+	m, err := manifest.ProjectManifest()
+	if err != nil {
+		return []error{fmt.Errorf("Cannot find the language runner without a valid manifest. %s", err.Error())}
+	}
+	r, err := runner.Start(m, ioutil.Discard, make(chan bool), false)
+	if err != nil {
+		return []error{fmt.Errorf("Failed to start %s runner. %s", m.Language, err.Error())}
+	}
+	if err := r.Kill(); err != nil {
+		return []error{fmt.Errorf("Failed to stop %s runner. %s", m.Language, err.Error())}
+	}
+	return nil
+}
+
+func checkEnvironments() []error {
+	if !common.DirExists(filepath.Join(config.ProjectRoot, common.EnvDirectoryName)) {
+		return nil
+	}
+	if _, err := env.Environments(); err != nil {
+		return []error{err}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package doctor
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct {
+	projectRoot string
+}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	s.projectRoot = config.ProjectRoot
+	config.ProjectRoot = c.MkDir()
+}
+
+func (s *MySuite) TearDownTest(c *C) {
+	config.ProjectRoot = s.projectRoot
+}
+
+func (s *MySuite) TestFormatShowsIssuesAndFixOfFailedChecks(c *C) {
+	results := []*CheckResult{
+		{Name: "first check", Fix: "never shown"},
+		{Name: "second check", Errors: []error{errors.New("something is wrong")}, Fix: "do this"},
+	}
+
+	want := "✔ first check\n" +
+		"✘ second check\n" +
+		"    something is wrong\n" +
+		"    Suggested fix: do this\n"
+
+	c.Assert(Format(results), Equals, want)
+	c.Assert(AllPassed(results), Equals, false)
+	c.Assert(AllPassed(results[:1]), Equals, true)
+}
+
+func (s *MySuite) TestCheckManifestWithoutManifest(c *C) {
+	c.Assert(len(checkManifest()), Equals, 1)
+}
+
+func (s *MySuite) TestCheckManifestReportsPluginsNotInstalled(c *C) {
+	manifestJSON := `{"Language": "", "Plugins": ["some-plugin-which-is-not-installed"]}`
+	ioutil.WriteFile(filepath.Join(config.ProjectRoot, common.ManifestFile), []byte(manifestJSON), common.NewFilePermissions)
+
+	errs := checkManifest()
+
+	c.Assert(len(errs), Equals, 2)
+	c.Assert(errs[0].Error(), Equals, "No language is specified in manifest.json")
+	c.Assert(errs[1].Error(), Equals, "Plugin some-plugin-which-is-not-installed is not installed")
+}
+
+func (s *MySuite) TestCheckSpecsReportsParseErrors(c *C) {
+	specDir := filepath.Join(config.ProjectRoot, common.SpecsDirectoryName)
+	os.Mkdir(specDir, common.NewDirectoryPermissions)
+	ioutil.WriteFile(filepath.Join(specDir, "valid.spec"), []byte("# Spec\n## Scenario\n* step\n"), common.NewFilePermissions)
+	ioutil.WriteFile(filepath.Join(specDir, "invalid.spec"), []byte("* step without a spec heading\n"), common.NewFilePermissions)
+
+	errs := checkSpecs()
+
+	c.Assert(len(errs) > 0, Equals, true)
+	for _, err := range errs {
+		c.Assert(err.Error(), Matches, ".*invalid.spec.*")
+	}
+}
+
+func (s *MySuite) TestCheckEnvironmentsWithoutEnvDirectory(c *C) {
+	c.Assert(checkEnvironments(), IsNil)
+}
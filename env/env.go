@@ -36,6 +36,11 @@ const (
 	OverwriteReports    = "overwrite_reports"
 	ScreenshotOnFailure = "screenshot_on_failure"
 	SaveExecutionResult = "save_execution_result" // determines if last run result should be saved
+	// AllowScenarioDatatable determines if a table right after a scenario heading is the scenario's data table.
+	// Otherwise such a table is treated as a comment.
+	AllowScenarioDatatable = "allow_scenario_datatable"
+	// PluginReportDir is the directory given to gauge run --report-dir, for reporting plugins to write to.
+	PluginReportDir = "plugin_report_dir"
 )
 
 var envVars map[string]string
@@ -70,6 +75,7 @@ func LoadEnv(envName string) error {
 	if err != nil {
 		return fmt.Errorf("%s", err.Error())
 	}
+	config.SetEnvironmentProperties(envVars)
 
 	err = setEnvVars()
 	if err != nil {
@@ -84,6 +90,7 @@ func loadDefaultEnvVars() {
 	addEnvVar(OverwriteReports, "true")
 	addEnvVar(ScreenshotOnFailure, "true")
 	addEnvVar(SaveExecutionResult, "false")
+	addEnvVar(AllowScenarioDatatable, "false")
 }
 
 func loadEnvDir(envName string) error {
@@ -176,6 +183,44 @@ func containsEnvVar(value string) (contains bool, matches [][]string) {
 	return
 }
 
+// PluginProperties returns the properties of the environment which are meant for the plugin with the given id.
+// These are named with the plugin id as a prefix, like html-report.output_dir, and are returned without the prefix.
+// Properties of the default environment are used when the environment does not set them.
+func PluginProperties(envName, pluginID string) (map[string]string, error) {
+	prefix := pluginID + "."
+	pluginProperties := make(map[string]string)
+	addProperties := func(path string, info os.FileInfo, err error) error {
+		if err != nil || !isPropertiesFile(path) {
+			return err
+		}
+		p, err := properties.Load(path)
+		if err != nil {
+			return fmt.Errorf("Failed to parse: %s. %s", path, err.Error())
+		}
+		for property, value := range p {
+			name := strings.TrimPrefix(property, prefix)
+			if _, ok := pluginProperties[name]; !ok && name != property && name != "" {
+				pluginProperties[name] = value
+			}
+		}
+		return nil
+	}
+	envNames := []string{envName}
+	if envName != "default" {
+		envNames = append(envNames, "default")
+	}
+	for _, name := range envNames {
+		envDirPath := filepath.Join(config.ProjectRoot, common.EnvDirectoryName, name)
+		if !common.DirExists(envDirPath) {
+			continue
+		}
+		if err := filepath.Walk(envDirPath, addProperties); err != nil {
+			return nil, err
+		}
+	}
+	return pluginProperties, nil
+}
+
 // CurrentEnv returns the value of currentEnv
 func CurrentEnv() string {
 	return currentEnv
@@ -186,3 +186,21 @@ func (s *MySuite) TestLoadDefaultEnvWithInvalidSubstitutedVariable(c *C) {
 	e := LoadEnv("default")
 	c.Assert(e, ErrorMatches, ".*env variable was not set.")
 }
+
+func (s *MySuite) TestPluginPropertiesOfEnvOverrideDefaultOnes(c *C) {
+	config.ProjectRoot = "_testdata/proj4"
+
+	properties, err := PluginProperties("staging", "html-report")
+
+	c.Assert(err, IsNil)
+	c.Assert(properties, DeepEquals, map[string]string{"output_dir": "reports/staging/html", "theme": "dark"})
+}
+
+func (s *MySuite) TestPluginPropertiesOfDefaultEnv(c *C) {
+	config.ProjectRoot = "_testdata/proj4"
+
+	properties, err := PluginProperties("default", "xml-report")
+
+	c.Assert(err, IsNil)
+	c.Assert(properties, DeepEquals, map[string]string{"output_dir": "reports/xml"})
+}
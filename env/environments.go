@@ -0,0 +1,128 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dmotylev/goproperties"
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+)
+
+const maskedValue = "******"
+
+// secretKeyParts are the parts of a property name which mark its value as secret.
+var secretKeyParts = []string{"password", "secret", "token", "key"}
+
+// Environment is an environment defined in the env directory of the project, along with its properties.
+type Environment struct {
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties"`
+}
+
+// Environments returns all the environments defined in the project, sorted by name.
+// Values of properties which look like secrets are masked.
+func Environments() ([]*Environment, error) {
+	envDir := filepath.Join(config.ProjectRoot, common.EnvDirectoryName)
+	files, err := ioutil.ReadDir(envDir)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read env directory %s. %s", envDir, err.Error())
+	}
+	var envs []*Environment
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+		e, err := GetEnvironment(f.Name())
+		if err != nil {
+			return nil, err
+		}
+		envs = append(envs, e)
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i].Name < envs[j].Name })
+	return envs, nil
+}
+
+// GetEnvironment returns the environment with the given name, with the properties from all its properties files.
+// Values of properties which look like secrets are masked.
+func GetEnvironment(name string) (*Environment, error) {
+	envDirPath := filepath.Join(config.ProjectRoot, common.EnvDirectoryName, name)
+	if !common.DirExists(envDirPath) {
+		return nil, fmt.Errorf("%s environment does not exist", name)
+	}
+	e := &Environment{Name: name, Properties: make(map[string]string)}
+	err := filepath.Walk(envDirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !isPropertiesFile(path) {
+			return err
+		}
+		p, err := properties.Load(path)
+		if err != nil {
+			return fmt.Errorf("Failed to parse: %s. %s", path, err.Error())
+		}
+		for k, v := range p {
+			e.Properties[k] = maskSecret(k, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func maskSecret(name, value string) string {
+	lowerName := strings.ToLower(name)
+	for _, part := range secretKeyParts {
+		if strings.Contains(lowerName, part) {
+			return maskedValue
+		}
+	}
+	return value
+}
+
+// FormatEnvironments returns the given environments either as JSON or as text, with one property per line.
+func FormatEnvironments(envs []*Environment, asJSON bool) (string, error) {
+	if asJSON {
+		b, err := json.MarshalIndent(envs, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	var buf bytes.Buffer
+	for _, e := range envs {
+		buf.WriteString(e.Name + "\n")
+		var keys []string
+		for k := range e.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf.WriteString(fmt.Sprintf("  %s = %s\n", k, e.Properties[k]))
+		}
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package env
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestEnvironments(c *C) {
+	config.ProjectRoot = "_testdata/proj1"
+
+	envs, err := Environments()
+
+	c.Assert(err, IsNil)
+	c.Assert(len(envs), Equals, 2)
+	c.Assert(envs[0].Name, Equals, "default")
+	c.Assert(envs[1].Name, Equals, "foo")
+	c.Assert(envs[1].Properties, DeepEquals, map[string]string{"screenshot_on_failure": "false", "logs_directory": "foo/logs"})
+}
+
+func (s *MySuite) TestGetEnvironmentMasksSecrets(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeTest")
+	defer os.RemoveAll(dir)
+	config.ProjectRoot = dir
+	os.MkdirAll(filepath.Join(dir, "env", "staging"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "env", "staging", "staging.properties"), []byte("db_password = foo\nAPI_TOKEN = bar\nurl = http://staging\n"), 0644)
+
+	e, err := GetEnvironment("staging")
+
+	c.Assert(err, IsNil)
+	c.Assert(e.Properties, DeepEquals, map[string]string{"db_password": maskedValue, "API_TOKEN": maskedValue, "url": "http://staging"})
+}
+
+func (s *MySuite) TestGetEnvironmentWhichDoesNotExist(c *C) {
+	config.ProjectRoot = "_testdata/proj1"
+
+	_, err := GetEnvironment("bar")
+
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestFormatEnvironments(c *C) {
+	envs := []*Environment{{Name: "default", Properties: map[string]string{"b": "2", "a": "1"}}}
+
+	text, err := FormatEnvironments(envs, false)
+	c.Assert(err, IsNil)
+	c.Assert(text, Equals, "default\n  a = 1\n  b = 2")
+
+	text, err = FormatEnvironments(envs, true)
+	c.Assert(err, IsNil)
+	c.Assert(text, Equals, "[\n  {\n    \"name\": \"default\",\n    \"properties\": {\n      \"a\": \"1\",\n      \"b\": \"2\"\n    }\n  }\n]")
+}
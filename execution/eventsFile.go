@@ -0,0 +1,135 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+)
+
+// EventsFile is the path of the file to which execution events are written, one JSON object per line.
+// No events file is written when it is empty.
+var EventsFile string
+
+// ExecutionEventType is the type of an event written to the events file.
+type ExecutionEventType string
+
+const (
+	SuiteStartEvent    ExecutionEventType = "SuiteStart"
+	SpecStartEvent     ExecutionEventType = "SpecStart"
+	ScenarioStartEvent ExecutionEventType = "ScenarioStart"
+	ScenarioEndEvent   ExecutionEventType = "ScenarioEnd"
+	SpecEndEvent       ExecutionEventType = "SpecEnd"
+	SuiteEndEvent      ExecutionEventType = "SuiteEnd"
+)
+
+// ExecutionEvent is a line of the events file. Passed and Duration, in milliseconds, are only set on end events.
+type ExecutionEvent struct {
+	Type     ExecutionEventType `json:"type"`
+	Suite    string             `json:"suite"`
+	Spec     string             `json:"spec,omitempty"`
+	Scenario string             `json:"scenario,omitempty"`
+	Time     time.Time          `json:"time"`
+	Passed   *bool              `json:"passed,omitempty"`
+	Duration *int64             `json:"duration,omitempty"`
+}
+
+var eventTypes = map[event.Topic]ExecutionEventType{
+	event.SuiteStart:    SuiteStartEvent,
+	event.SpecStart:     SpecStartEvent,
+	event.ScenarioStart: ScenarioStartEvent,
+	event.ScenarioEnd:   ScenarioEndEvent,
+	event.SpecEnd:       SpecEndEvent,
+	event.SuiteEnd:      SuiteEndEvent,
+}
+
+// ListenExecutionEventsAndWriteToFile listens to execution events and writes them to the given file as newline delimited JSON.
+func ListenExecutionEventsAndWriteToFile(wg *sync.WaitGroup, file string) {
+	if err := os.MkdirAll(filepath.Dir(file), common.NewDirectoryPermissions); err != nil {
+		logger.Errorf("Failed to create directory for events file %s. Reason: %s", file, err.Error())
+		return
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		logger.Errorf("Failed to create events file %s. Reason: %s", file, err.Error())
+		return
+	}
+	ch := make(chan event.ExecutionEvent, 0)
+	event.Register(ch, event.SuiteStart, event.SpecStart, event.ScenarioStart, event.ScenarioEnd, event.SpecEnd, event.SuiteEnd)
+	wg.Add(1)
+
+	go func() {
+		encoder := json.NewEncoder(f)
+		for {
+			e := <-ch
+			writeExecutionEvent(encoder, e, time.Now())
+			if e.Topic == event.SuiteEnd {
+				f.Close()
+				wg.Done()
+			}
+		}
+	}()
+}
+
+func writeExecutionEvent(encoder *json.Encoder, e event.ExecutionEvent, t time.Time) {
+	if err := encoder.Encode(newExecutionEvent(e, t)); err != nil {
+		logger.Errorf("Failed to write execution event to events file. Reason: %s", err.Error())
+	}
+}
+
+func newExecutionEvent(e event.ExecutionEvent, t time.Time) ExecutionEvent {
+	ee := ExecutionEvent{Type: eventTypes[e.Topic], Suite: suiteName(), Time: t}
+	if spec, ok := e.Item.(*gauge.Specification); ok {
+		ee.Spec = spec.FileName
+	} else if e.ExecutionInfo.CurrentSpec != nil {
+		ee.Spec = e.ExecutionInfo.CurrentSpec.FileName
+	}
+	if scenario, ok := e.Item.(*gauge.Scenario); ok {
+		ee.Scenario = scenario.Heading.Value
+	}
+	var passed bool
+	var duration int64
+	switch res := e.Result.(type) {
+	case *result.ScenarioResult:
+		if e.Topic != event.ScenarioEnd {
+			return ee
+		}
+		passed, duration = res.ProtoScenario.GetExecutionStatus() == gm.ExecutionStatus_PASSED, res.ExecTime()
+	case *result.SpecResult:
+		if e.Topic != event.SpecEnd {
+			return ee
+		}
+		passed, duration = !res.GetFailed() && !res.Skipped, res.ExecTime()
+	case *result.SuiteResult:
+		passed, duration = !res.GetFailed(), res.ExecTime()
+	default:
+		return ee
+	}
+	ee.Passed, ee.Duration = &passed, &duration
+	return ee
+}
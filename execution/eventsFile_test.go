@@ -0,0 +1,83 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getgauge/gauge/execution/event"
+	"github.com/getgauge/gauge/execution/result"
+	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestListenExecutionEventsAndWriteToFile(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeEvents")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "reports", "events.ndjson")
+	spec := &gauge.Specification{FileName: "example.spec", Heading: &gauge.Heading{Value: "Example Spec"}, Tags: &gauge.Tags{}}
+	scenario := &gauge.Scenario{Heading: &gauge.Heading{Value: "Example Scenario"}}
+	info := gm.ExecutionInfo{CurrentSpec: &gm.SpecInfo{FileName: "example.spec"}}
+	scenarioResult := result.NewScenarioResult(&gm.ProtoScenario{ExecutionStatus: gm.ExecutionStatus_PASSED, ExecutionTime: 1234})
+	suiteResult := result.NewSuiteResult("", time.Now())
+	suiteResult.SetFailure()
+	SuiteName = "checkout"
+	defer func() { SuiteName = "" }()
+	event.InitRegistry()
+	wg := &sync.WaitGroup{}
+
+	ListenExecutionEventsAndWriteToFile(wg, file)
+	event.Notify(event.NewExecutionEvent(event.SuiteStart, nil, nil, 0, gm.ExecutionInfo{}))
+	event.Notify(event.NewExecutionEvent(event.SpecStart, spec, gauge.NewSpecResult(spec), 0, info))
+	event.Notify(event.NewExecutionEvent(event.ScenarioStart, scenario, scenarioResult, 0, info))
+	event.Notify(event.NewExecutionEvent(event.ScenarioEnd, scenario, scenarioResult, 0, info))
+	event.Notify(event.NewExecutionEvent(event.SuiteEnd, nil, suiteResult, 0, gm.ExecutionInfo{}))
+	wg.Wait()
+
+	contents, err := ioutil.ReadFile(file)
+	c.Assert(err, IsNil)
+	lines := strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+	c.Assert(len(lines), Equals, 5)
+	c.Assert(strings.HasPrefix(lines[0], `{"type":"SuiteStart","suite":"checkout","time":`), Equals, true)
+	c.Assert(strings.HasPrefix(lines[1], `{"type":"SpecStart","suite":"checkout","spec":"example.spec","time":`), Equals, true)
+	c.Assert(strings.HasPrefix(lines[2], `{"type":"ScenarioStart","suite":"checkout","spec":"example.spec","scenario":"Example Scenario","time":`), Equals, true)
+	c.Assert(strings.HasPrefix(lines[3], `{"type":"ScenarioEnd","suite":"checkout","spec":"example.spec","scenario":"Example Scenario","time":`), Equals, true)
+	c.Assert(strings.HasSuffix(lines[3], `"passed":true,"duration":1234}`), Equals, true)
+	c.Assert(strings.HasSuffix(lines[4], `"passed":false,"duration":0}`), Equals, true)
+}
+
+func (s *MySuite) TestNewExecutionEventForSpecEnd(c *C) {
+	spec := &gauge.Specification{FileName: "example.spec", Heading: &gauge.Heading{Value: "Example Spec"}, Tags: &gauge.Tags{}}
+	specResult := gauge.NewSpecResult(spec)
+	specResult.AddExecTime(20)
+	now := time.Now()
+
+	got := newExecutionEvent(event.NewExecutionEvent(event.SpecEnd, spec, specResult, 0, gm.ExecutionInfo{}), now)
+
+	c.Assert(got.Type, Equals, SpecEndEvent)
+	c.Assert(got.Spec, Equals, "example.spec")
+	c.Assert(got.Time, Equals, now)
+	c.Assert(*got.Passed, Equals, true)
+	c.Assert(*got.Duration, Equals, int64(20))
+}
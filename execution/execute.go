@@ -79,6 +79,17 @@ var NumberOfExecutionStreams int
 // InParallel if true executes the specs in parallel else in serial.
 var InParallel bool
 
+// SuiteName labels the run in the suite result, the events file and the last run record.
+var SuiteName string
+
+// suiteName returns SuiteName, or the name of the project directory when it is not set.
+func suiteName() string {
+	if SuiteName != "" {
+		return SuiteName
+	}
+	return filepath.Base(config.ProjectRoot)
+}
+
 type suiteExecutor interface {
 	run() *result.SuiteResult
 }
@@ -122,6 +133,11 @@ func ExecuteSpecs(specDirs []string) int {
 	if err != nil {
 		logger.Fatalf(err.Error())
 	}
+	if ReportDir != "" {
+		if _, err := prepareReportDir(ReportDir, specDirs, KeepOldReports); err != nil {
+			logger.Fatalf("Failed to create the report directory %s. %s", ReportDir, err.Error())
+		}
+	}
 	if config.CheckUpdates() {
 		i := &install.UpdateFacade{}
 		i.BufferUpdateDetails()
@@ -140,6 +156,22 @@ func ExecuteSpecs(specDirs []string) int {
 		}
 		return 1
 	}
+	if FailOnNew {
+		knownSpecs, hasLastRun := readLastRunSpecs()
+		if hasLastRun {
+			if newSpecs := newUnimplementedSpecs(res.SpecCollection.Specs(), res.ErrMap, knownSpecs); len(newSpecs) > 0 {
+				logger.Errorf("New specifications have unimplemented steps:\n%s", strings.Join(newSpecs, "\n"))
+				res.Runner.Kill()
+				return 1
+			}
+		}
+		writeLastRunSpecs(res.SpecCollection.Specs(), res.ErrMap, knownSpecs)
+	}
+	if err := runShellHook("before-all", BeforeAll); err != nil {
+		logger.Errorf("%s", err.Error())
+		res.Runner.Kill()
+		return 1
+	}
 	event.InitRegistry()
 	wg := &sync.WaitGroup{}
 	reporter.ListenExecutionEvents(wg)
@@ -147,11 +179,18 @@ func ExecuteSpecs(specDirs []string) int {
 	if util.ConvertToBool(os.Getenv(env.SaveExecutionResult), env.SaveExecutionResult, false) {
 		ListenSuiteEndAndSaveResult(wg)
 	}
+	if EventsFile != "" {
+		ListenExecutionEventsAndWriteToFile(wg, EventsFile)
+	}
 	defer wg.Wait()
 	defer recoverPanic()
 	ei := newExecutionInfo(res.SpecCollection, res.Runner, nil, res.ErrMap, InParallel, 0)
 	e := newExecution(ei)
-	return printExecutionStatus(e.run(), res.ParseOk)
+	r := e.run()
+	if err := runShellHook("after-all", AfterAll); err != nil {
+		logger.Errorf("%s", err.Error())
+	}
+	return printExecutionStatus(r, res.ParseOk)
 }
 
 func recoverPanic() {
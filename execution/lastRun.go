@@ -0,0 +1,139 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+	"github.com/getgauge/gauge/validation"
+)
+
+const lastRun = "last-run.json"
+
+// FailOnNew fails the execution when a spec which is not in the last run record has unimplemented steps.
+// The last run record is read and written only when it is set.
+var FailOnNew bool
+
+type lastRunRecord struct {
+	Suite string   `json:"suite,omitempty"`
+	Specs []string `json:"specs"`
+}
+
+// readLastRunSpecs returns the spec files recorded by the previous run. ok is false when there is no record.
+func readLastRunSpecs() (specs map[string]bool, ok bool) {
+	contents, err := common.ReadFileContents(filepath.Join(config.ProjectRoot, dotGauge, lastRun))
+	if err != nil {
+		return nil, false
+	}
+	var record lastRunRecord
+	if err := json.Unmarshal([]byte(contents), &record); err != nil {
+		logger.Warningf("Ignoring invalid %s. %s", lastRun, err.Error())
+		return nil, false
+	}
+	specs = make(map[string]bool)
+	for _, s := range record.Specs {
+		specs[s] = true
+	}
+	return specs, true
+}
+
+// writeLastRunSpecs records the spec files known to this run. New specs which still have unimplemented steps are left out,
+// so they are reported again by the next run. Without a previous record all the specs are recorded.
+func writeLastRunSpecs(specs []*gauge.Specification, errMap *gauge.BuildErrors, known map[string]bool) {
+	unimplemented := make(map[string]bool)
+	if known != nil {
+		for _, name := range newUnimplementedSpecs(specs, errMap, known) {
+			unimplemented[name] = true
+		}
+	}
+	names := make(map[string]bool)
+	for name := range known {
+		names[name] = true
+	}
+	for _, spec := range specs {
+		if name := util.RelPathToProjectRoot(spec.FileName); !unimplemented[name] {
+			names[name] = true
+		}
+	}
+	record := lastRunRecord{Suite: suiteName(), Specs: []string{}}
+	for name := range names {
+		record.Specs = append(record.Specs, name)
+	}
+	sort.Strings(record.Specs)
+	contents, err := json.MarshalIndent(record, "", "\t")
+	if err != nil {
+		logger.Errorf("Unable to marshal the last run record. %s", err.Error())
+		return
+	}
+	dotGaugeDir := filepath.Join(config.ProjectRoot, dotGauge)
+	if err := os.MkdirAll(dotGaugeDir, common.NewDirectoryPermissions); err != nil {
+		logger.Errorf("Failed to create directory in %s. Reason: %s", dotGaugeDir, err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(dotGaugeDir, lastRun), contents, common.NewFilePermissions); err != nil {
+		logger.Errorf("Failed to write the last run record. Reason: %s", err.Error())
+	}
+}
+
+// newUnimplementedSpecs returns the files of the specs which are not known from the last run and have unimplemented steps.
+func newUnimplementedSpecs(specs []*gauge.Specification, errMap *gauge.BuildErrors, known map[string]bool) []string {
+	var newSpecs []string
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		name := util.RelPathToProjectRoot(spec.FileName)
+		if known[name] || seen[name] || !hasUnimplementedSteps(spec, errMap) {
+			continue
+		}
+		seen[name] = true
+		newSpecs = append(newSpecs, name)
+	}
+	sort.Strings(newSpecs)
+	return newSpecs
+}
+
+func hasUnimplementedSteps(spec *gauge.Specification, errMap *gauge.BuildErrors) bool {
+	var steps []*gauge.Step
+	steps = append(steps, spec.Contexts...)
+	steps = append(steps, spec.TearDownSteps...)
+	for _, scenario := range spec.Scenarios {
+		steps = append(steps, scenario.Steps...)
+	}
+	return anyUnimplemented(steps, errMap)
+}
+
+func anyUnimplemented(steps []*gauge.Step, errMap *gauge.BuildErrors) bool {
+	for _, step := range steps {
+		if step.IsConcept && anyUnimplemented(step.ConceptSteps, errMap) {
+			return true
+		}
+		if err, ok := errMap.StepErrs[step].(validation.StepValidationError); ok && err.ErrorType() == gm.StepValidateResponse_STEP_IMPLEMENTATION_NOT_FOUND {
+			return true
+		}
+	}
+	return false
+}
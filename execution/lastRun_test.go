@@ -0,0 +1,102 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/gauge"
+	gm "github.com/getgauge/gauge/gauge_messages"
+	"github.com/getgauge/gauge/validation"
+	. "gopkg.in/check.v1"
+)
+
+func specWithStep(fileName string) (*gauge.Specification, *gauge.Step) {
+	step := &gauge.Step{Value: "unimplemented step"}
+	return &gauge.Specification{FileName: fileName, Scenarios: []*gauge.Scenario{{Steps: []*gauge.Step{step}}}}, step
+}
+
+func unimplemented(step *gauge.Step) error {
+	errType := gm.StepValidateResponse_STEP_IMPLEMENTATION_NOT_FOUND
+	return validation.NewStepValidationError(step, "Step implementation not found", "", &errType, "")
+}
+
+func (s *MySuite) TestNewUnimplementedSpecs(c *C) {
+	oldSpec, oldStep := specWithStep("old.spec")
+	newSpec, newStep := specWithStep("new.spec")
+	implementedSpec, _ := specWithStep("implemented.spec")
+	errMap := gauge.NewBuildErrors()
+	errMap.StepErrs[oldStep] = unimplemented(oldStep)
+	errMap.StepErrs[newStep] = unimplemented(newStep)
+
+	got := newUnimplementedSpecs([]*gauge.Specification{oldSpec, newSpec, implementedSpec}, errMap, map[string]bool{"old.spec": true})
+
+	c.Assert(got, DeepEquals, []string{"new.spec"})
+}
+
+func (s *MySuite) TestLastRunRecordLeavesOutNewUnimplementedSpecs(c *C) {
+	defer os.RemoveAll(filepath.Join(config.ProjectRoot, dotGauge))
+	newSpec, newStep := specWithStep("new.spec")
+	implementedSpec, _ := specWithStep("implemented.spec")
+	errMap := gauge.NewBuildErrors()
+	errMap.StepErrs[newStep] = unimplemented(newStep)
+
+	writeLastRunSpecs([]*gauge.Specification{newSpec, implementedSpec}, errMap, map[string]bool{"old.spec": true})
+	known, ok := readLastRunSpecs()
+
+	c.Assert(ok, Equals, true)
+	c.Assert(known, DeepEquals, map[string]bool{"old.spec": true, "implemented.spec": true})
+}
+
+func (s *MySuite) TestLastRunRecordWithoutPreviousRecordHasAllSpecs(c *C) {
+	defer os.RemoveAll(filepath.Join(config.ProjectRoot, dotGauge))
+	newSpec, newStep := specWithStep("new.spec")
+	errMap := gauge.NewBuildErrors()
+	errMap.StepErrs[newStep] = unimplemented(newStep)
+
+	writeLastRunSpecs([]*gauge.Specification{newSpec}, errMap, nil)
+	known, _ := readLastRunSpecs()
+
+	c.Assert(known, DeepEquals, map[string]bool{"new.spec": true})
+}
+
+func (s *MySuite) TestLastRunRecordHasSuiteName(c *C) {
+	defer os.RemoveAll(filepath.Join(config.ProjectRoot, dotGauge))
+	SuiteName = "checkout"
+	defer func() { SuiteName = "" }()
+	spec, _ := specWithStep("example.spec")
+
+	writeLastRunSpecs([]*gauge.Specification{spec}, gauge.NewBuildErrors(), nil)
+	contents, err := ioutil.ReadFile(filepath.Join(config.ProjectRoot, dotGauge, lastRun))
+
+	c.Assert(err, IsNil)
+	var record lastRunRecord
+	c.Assert(json.Unmarshal(contents, &record), IsNil)
+	c.Assert(record.Suite, Equals, "checkout")
+}
+
+func (s *MySuite) TestSuiteNameDefaultsToProjectDirectoryName(c *C) {
+	c.Assert(suiteName(), Equals, filepath.Base(config.ProjectRoot))
+	SuiteName = "checkout"
+	defer func() { SuiteName = "" }()
+	c.Assert(suiteName(), Equals, "checkout")
+}
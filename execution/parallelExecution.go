@@ -238,6 +238,7 @@ func (e *parallelExecution) finish() {
 
 func (e *parallelExecution) aggregateResults(suiteResults []*result.SuiteResult) {
 	r := result.NewSuiteResult(ExecuteTags, e.startTime)
+	r.ProjectName = suiteName()
 	for _, result := range suiteResults {
 		r.SpecsFailedCount += result.SpecsFailedCount
 		r.SpecResults = append(r.SpecResults, result.SpecResults...)
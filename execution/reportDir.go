@@ -0,0 +1,105 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	"github.com/getgauge/gauge/util"
+)
+
+// reportDirMarker is written to every report directory gauge prepares. Only directories holding it are cleared.
+const reportDirMarker = ".gauge-report-dir"
+
+// ReportDir is the directory reporting plugins write their reports to, instead of the one in the environment.
+var ReportDir string
+
+// KeepOldReports leaves the contents of ReportDir in place. By default they are cleared before the run.
+var KeepOldReports bool
+
+// prepareReportDir creates the report directory, clearing an existing one unless keepOld is set, and passes its
+// absolute path to the plugins through the environment. Relative paths are resolved against the project root.
+// The project root, its parents and the spec directories are never used, and an existing directory is only
+// cleared if gauge prepared it before.
+func prepareReportDir(dir string, specDirs []string, keepOld bool) (string, error) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(config.ProjectRoot, dir)
+	}
+	dir = filepath.Clean(dir)
+	if err := checkReportDir(dir, specDirs); err != nil {
+		return "", err
+	}
+	if !keepOld && common.DirExists(dir) {
+		if err := clearReportDir(dir); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(dir, common.NewDirectoryPermissions); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, reportDirMarker), []byte{}, common.NewFilePermissions); err != nil {
+		return "", err
+	}
+	if err := common.SetEnvVariable(env.PluginReportDir, dir); err != nil {
+		return "", err
+	}
+	if err := common.SetEnvVariable(env.GaugeReportsDir, dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func checkReportDir(dir string, specDirs []string) error {
+	if util.IsInsideSpecDir([]string{dir}, config.ProjectRoot) {
+		return fmt.Errorf("%s is the project root or one of its parent directories", dir)
+	}
+	for _, specDir := range specDirs {
+		if !filepath.IsAbs(specDir) {
+			specDir = filepath.Join(config.ProjectRoot, specDir)
+		}
+		if util.IsInsideSpecDir([]string{specDir}, dir) || util.IsInsideSpecDir([]string{dir}, specDir) {
+			return fmt.Errorf("%s overlaps with the spec directory %s", dir, specDir)
+		}
+	}
+	return nil
+}
+
+func clearReportDir(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	if !common.FileExists(filepath.Join(dir, reportDirMarker)) {
+		return fmt.Errorf("%s is not empty and was not created by gauge. Use --keep-old-reports or choose another directory", dir)
+	}
+	for _, f := range files {
+		if err := os.RemoveAll(filepath.Join(dir, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
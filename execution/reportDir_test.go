@@ -0,0 +1,128 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/env"
+	. "gopkg.in/check.v1"
+)
+
+func withProjectRoot(dir string) func() {
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = dir
+	return func() { config.ProjectRoot = oldProjectRoot }
+}
+
+func (s *MySuite) TestPrepareReportDirCreatesMissingDirectory(c *C) {
+	tmp, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "reports", "nightly")
+
+	got, err := prepareReportDir(dir, nil, false)
+
+	c.Assert(err, IsNil)
+	c.Assert(got, Equals, dir)
+	c.Assert(common.DirExists(dir), Equals, true)
+	c.Assert(common.FileExists(filepath.Join(dir, reportDirMarker)), Equals, true)
+	c.Assert(os.Getenv(env.PluginReportDir), Equals, dir)
+	c.Assert(os.Getenv(env.GaugeReportsDir), Equals, dir)
+}
+
+func (s *MySuite) TestPrepareReportDirResolvesRelativePathAgainstProjectRoot(c *C) {
+	tmp, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(tmp)
+	defer withProjectRoot(tmp)()
+
+	got, err := prepareReportDir(filepath.Join("reports", "nightly"), nil, false)
+
+	c.Assert(err, IsNil)
+	c.Assert(got, Equals, filepath.Join(tmp, "reports", "nightly"))
+}
+
+func (s *MySuite) TestPrepareReportDirClearsDirectoryPreparedBefore(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, reportDirMarker), []byte{}, common.NewFilePermissions)
+	ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("old"), common.NewFilePermissions)
+	os.MkdirAll(filepath.Join(dir, "html-report"), common.NewDirectoryPermissions)
+
+	_, err := prepareReportDir(dir, nil, false)
+
+	c.Assert(err, IsNil)
+	files, _ := ioutil.ReadDir(dir)
+	c.Assert(len(files), Equals, 1)
+	c.Assert(files[0].Name(), Equals, reportDirMarker)
+}
+
+func (s *MySuite) TestPrepareReportDirDoesNotClearDirectoryNotCreatedByGauge(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("mine"), common.NewFilePermissions)
+
+	_, err := prepareReportDir(dir, nil, false)
+
+	c.Assert(err, NotNil)
+	c.Assert(common.FileExists(filepath.Join(dir, "notes.txt")), Equals, true)
+}
+
+func (s *MySuite) TestPrepareReportDirRefusesProjectRootAndItsParents(c *C) {
+	tmp, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(tmp)
+	project := filepath.Join(tmp, "project")
+	os.MkdirAll(project, common.NewDirectoryPermissions)
+	defer withProjectRoot(project)()
+
+	_, err := prepareReportDir(project, nil, false)
+	c.Assert(err, NotNil)
+
+	_, err = prepareReportDir("..", nil, false)
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestPrepareReportDirRefusesSpecDirs(c *C) {
+	tmp, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(tmp)
+	defer withProjectRoot(tmp)()
+	specs := []string{"specs"}
+
+	_, err := prepareReportDir("specs", specs, false)
+	c.Assert(err, NotNil)
+
+	_, err = prepareReportDir(filepath.Join("specs", "reports"), specs, false)
+	c.Assert(err, NotNil)
+
+	_, err = prepareReportDir("specs-reports", specs, false)
+	c.Assert(err, IsNil)
+}
+
+func (s *MySuite) TestPrepareReportDirKeepsOldReports(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeReportDir")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("old"), common.NewFilePermissions)
+
+	_, err := prepareReportDir(dir, nil, true)
+
+	c.Assert(err, IsNil)
+	c.Assert(common.FileExists(filepath.Join(dir, "index.html")), Equals, true)
+}
@@ -99,6 +99,45 @@ func (s *ScenarioResult) AddPostHook(f ...*gauge_messages.ProtoHookFailure) {
 	s.ProtoScenario.PostHookFailure = f[0]
 }
 
+// FailureReason returns the error message of the first hook or step failure of the scenario.
+func (s *ScenarioResult) FailureReason() string {
+	if f := s.ProtoScenario.GetPreHookFailure(); f != nil {
+		return f.GetErrorMessage()
+	}
+	for _, items := range [][]*gauge_messages.ProtoItem{s.ProtoScenario.GetContexts(), s.ProtoScenario.GetScenarioItems(), s.ProtoScenario.GetTearDownSteps()} {
+		if reason := failureReasonOf(items); reason != "" {
+			return reason
+		}
+	}
+	if f := s.ProtoScenario.GetPostHookFailure(); f != nil {
+		return f.GetErrorMessage()
+	}
+	return ""
+}
+
+func failureReasonOf(protoItems []*gauge_messages.ProtoItem) string {
+	for _, item := range protoItems {
+		switch item.GetItemType() {
+		case gauge_messages.ProtoItem_Step:
+			stepResult := item.GetStep().GetStepExecutionResult()
+			if f := stepResult.GetPreHookFailure(); f != nil {
+				return f.GetErrorMessage()
+			}
+			if res := stepResult.GetExecutionResult(); res.GetFailed() {
+				return res.GetErrorMessage()
+			}
+			if f := stepResult.GetPostHookFailure(); f != nil {
+				return f.GetErrorMessage()
+			}
+		case gauge_messages.ProtoItem_Concept:
+			if reason := failureReasonOf(item.GetConcept().GetSteps()); reason != "" {
+				return reason
+			}
+		}
+	}
+	return ""
+}
+
 func (s *ScenarioResult) Item() interface{} {
 	return s.ProtoScenario
 }
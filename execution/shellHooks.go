@@ -0,0 +1,60 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// BeforeAll is a shell command run before the first spec is executed. Execution is aborted if it fails.
+var BeforeAll string
+
+// AfterAll is a shell command run after the last spec is executed.
+var AfterAll string
+
+// runShellHook runs the command with the shell of the platform from the project root and logs its output.
+// It does nothing if the command is empty.
+func runShellHook(name, command string) error {
+	if strings.TrimSpace(command) == "" {
+		return nil
+	}
+	logger.Infof("Running %s command: %s", name, command)
+	cmd := shellCommand(command)
+	cmd.Dir = config.ProjectRoot
+	output, err := cmd.CombinedOutput()
+	if len(output) > 0 {
+		logger.Infof("%s", strings.TrimRight(string(output), "\r\n"))
+	}
+	if err != nil {
+		return fmt.Errorf("%s command `%s` failed: %s", name, command, err.Error())
+	}
+	return nil
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if util.IsWindows() {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
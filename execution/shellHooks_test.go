@@ -0,0 +1,60 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestRunShellHookRunsCommandFromProjectRoot(c *C) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		c.Skip("needs a posix shell")
+	}
+	dir, _ := ioutil.TempDir("", "gaugeShellHook")
+	defer os.RemoveAll(dir)
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = dir
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+
+	err := runShellHook("before-all", "echo started > service.log")
+
+	c.Assert(err, IsNil)
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "service.log"))
+	c.Assert(err, IsNil)
+	c.Assert(string(contents), Equals, "started\n")
+}
+
+func (s *MySuite) TestRunShellHookFailsWhenCommandExitsWithNonZero(c *C) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		c.Skip("needs a posix shell")
+	}
+
+	err := runShellHook("before-all", "echo could not start; exit 3")
+
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "before-all command `echo could not start; exit 3` failed: exit status 3")
+}
+
+func (s *MySuite) TestRunShellHookWithoutCommand(c *C) {
+	c.Assert(runShellHook("after-all", " "), IsNil)
+}
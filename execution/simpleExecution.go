@@ -74,6 +74,7 @@ func (e *simpleExecution) run() *result.SuiteResult {
 
 func (e *simpleExecution) execute() {
 	e.suiteResult = result.NewSuiteResult(ExecuteTags, e.startTime)
+	e.suiteResult.ProjectName = suiteName()
 	setResultMeta := func() {
 		e.suiteResult.UpdateExecTime(e.startTime)
 		e.suiteResult.SetSpecsSkippedCount()
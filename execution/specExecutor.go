@@ -22,6 +22,7 @@ import (
 
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
@@ -83,7 +84,17 @@ func (e *specExecutor) execute(executeBefore, execute, executeAfter bool) *resul
 			return e.specResult
 		}
 	}
-	resolvedSpecItems, err := e.resolveItems(e.specification.GetSpecItems())
+	if executeBefore {
+		if err := runSpecHooks(e.specification); err != nil {
+			e.failSpecForError(err)
+			return e.specResult
+		}
+	}
+	lookup, err := e.dataTableLookup()
+	if err != nil {
+		logger.Fatalf("Failed to resolve Specifications : %s", err.Error())
+	}
+	resolvedSpecItems, err := e.resolveItems(e.specification.GetSpecItems(), lookup)
 	if err != nil {
 		logger.Fatalf("Failed to resolve Specifications : %s", err.Error())
 	}
@@ -148,11 +159,11 @@ func (e *specExecutor) executeSpec() error {
 	return nil
 }
 
-func (e *specExecutor) resolveItems(items []gauge.Item) ([]*gauge_messages.ProtoItem, error) {
+func (e *specExecutor) resolveItems(items []gauge.Item, lookup *gauge.ArgLookup) ([]*gauge_messages.ProtoItem, error) {
 	var protoItems []*gauge_messages.ProtoItem
 	for _, item := range items {
 		if item.Kind() != gauge.TearDownKind {
-			protoItem, err := e.resolveToProtoItem(item)
+			protoItem, err := e.resolveToProtoItem(item, lookup)
 			if err != nil {
 				return nil, err
 			}
@@ -162,20 +173,16 @@ func (e *specExecutor) resolveItems(items []gauge.Item) ([]*gauge_messages.Proto
 	return protoItems, nil
 }
 
-func (e *specExecutor) resolveToProtoItem(item gauge.Item) (*gauge_messages.ProtoItem, error) {
+func (e *specExecutor) resolveToProtoItem(item gauge.Item, lookup *gauge.ArgLookup) (*gauge_messages.ProtoItem, error) {
 	var protoItem *gauge_messages.ProtoItem
 	var err error
 	switch item.Kind() {
 	case gauge.StepKind:
 		if (item.(*gauge.Step)).IsConcept {
 			concept := item.(*gauge.Step)
-			lookup, err := e.dataTableLookup()
-			if err != nil {
-				return nil, err
-			}
 			protoItem, err = e.resolveToProtoConceptItem(*concept, lookup)
 		} else {
-			protoItem, err = e.resolveToProtoStepItem(item.(*gauge.Step))
+			protoItem, err = e.resolveToProtoStepItem(item.(*gauge.Step), lookup)
 		}
 		break
 
@@ -215,13 +222,9 @@ func (e *specExecutor) resolveToProtoConceptItem(concept gauge.Step, lookup *gau
 	return protoConceptItem, nil
 }
 
-func (e *specExecutor) resolveToProtoStepItem(step *gauge.Step) (*gauge_messages.ProtoItem, error) {
+func (e *specExecutor) resolveToProtoStepItem(step *gauge.Step, lookup *gauge.ArgLookup) (*gauge_messages.ProtoItem, error) {
 	protoStepItem := gauge.ConvertToProtoItem(step)
 	paramResolver := new(parser.ParamResolver)
-	lookup, err := e.dataTableLookup()
-	if err != nil {
-		return nil, err
-	}
 	parameters, err := paramResolver.GetResolvedParams(step, nil, lookup)
 	if err != nil {
 		return nil, err
@@ -285,6 +288,16 @@ func (e *specExecutor) failSpec() {
 	e.specResult.SetFailure()
 }
 
+func (e *specExecutor) failSpecForError(err error) {
+	logger.Errorf("%s", err.Error())
+	e.specResult.Errors = append(e.specResult.Errors, &gauge_messages.Error{
+		Message:  err.Error(),
+		Filename: e.specification.FileName,
+		Type:     gauge_messages.Error_VALIDATION_ERROR,
+	})
+	e.specResult.SetFailure()
+}
+
 func (e *specExecutor) convertErrors(specErrors []error) []*gauge_messages.Error {
 	var errors []*gauge_messages.Error
 	for _, e := range specErrors {
@@ -321,13 +334,39 @@ func (e *specExecutor) getItemsForScenarioExecution(steps []*gauge.Step) ([]*gau
 	for i, context := range steps {
 		items[i] = context
 	}
-	return e.resolveItems(items)
+	lookup, err := e.dataTableLookup()
+	if err != nil {
+		return nil, err
+	}
+	return e.resolveItems(items, lookup)
 }
 
 func (e *specExecutor) dataTableLookup() (*gauge.ArgLookup, error) {
 	return new(gauge.ArgLookup).FromDataTableRow(&e.specification.DataTable.Table, 0)
 }
 
+// Scenario data table values take precedence over spec data table values with the same header.
+func (e *specExecutor) dataTableLookupFor(scenario *gauge.Scenario) (*gauge.ArgLookup, error) {
+	lookup, err := e.dataTableLookup()
+	if err != nil || !scenario.HasDataTable() {
+		return lookup, err
+	}
+	scenarioLookup, err := new(gauge.ArgLookup).FromDataTableRow(&scenario.DataTable.Table, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, header := range scenario.DataTable.Table.Headers {
+		arg, _ := scenarioLookup.GetArg(header)
+		if !lookup.ContainsArg(header) {
+			lookup.AddArgName(header)
+		}
+		if err := lookup.AddArgValue(header, arg); err != nil {
+			return nil, err
+		}
+	}
+	return lookup, nil
+}
+
 func (e *specExecutor) executeScenarios(scenarios []*gauge.Scenario) ([]result.Result, error) {
 	var scenarioResults []result.Result
 	for _, scenario := range scenarios {
@@ -353,6 +392,11 @@ func (e *specExecutor) executeScenario(scenario *gauge.Scenario) (*result.Scenar
 	}
 
 	e.scenarioExecutor.execute(scenario, scenarioResult)
+	scenario.Result = &gauge.ScenarioResult{
+		Passed:        scenarioResult.ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_PASSED,
+		FailureReason: scenarioResult.FailureReason(),
+		Duration:      time.Duration(scenarioResult.ExecTime()) * time.Millisecond,
+	}
 	if scenarioResult.ProtoScenario.GetExecutionStatus() == gauge_messages.ExecutionStatus_SKIPPED {
 		e.specResult.ScenarioSkippedCount++
 	}
@@ -370,7 +414,11 @@ func (e *specExecutor) addAllItemsForScenarioExecution(scenario *gauge.Scenario,
 		return err
 	}
 	scenarioResult.AddTearDownSteps(tearDownSteps)
-	items, err := e.resolveItems(scenario.Items)
+	lookup, err := e.dataTableLookupFor(scenario)
+	if err != nil {
+		return err
+	}
+	items, err := e.resolveItems(scenario.Items, lookup)
 	if err != nil {
 		return err
 	}
@@ -20,11 +20,16 @@ package execution
 import (
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"sync"
 
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/execution/event"
 	"github.com/getgauge/gauge/execution/result"
 	"github.com/getgauge/gauge/gauge"
@@ -270,6 +275,31 @@ func (s *MySuite) TestResolveToProtoConceptItemWithDataTable(c *C) {
 	c.Assert(params[0].GetValue(), Equals, "8800")
 }
 
+func (s *MySuite) TestScenarioDataTableLookupOverridesSpecDataTable(c *C) {
+	os.Setenv(env.AllowScenarioDatatable, "true")
+	defer os.Unsetenv(env.AllowScenarioDatatable)
+	specText := SpecBuilder().specHeading("A spec heading").
+		tableHeader("id", "name").
+		tableRow("123", "foo").
+		scenarioHeading("First scenario").
+		tableHeader("name").
+		tableRow("bar").
+		step("say <id> <name>").
+		String()
+	spec, _, _ := new(parser.SpecParser).Parse(specText, gauge.NewConceptDictionary(), "")
+
+	specExecutor := newSpecExecutor(spec, nil, nil, nil, 0)
+	lookup, err := specExecutor.dataTableLookupFor(spec.Scenarios[0])
+	c.Assert(err, IsNil)
+
+	id, err := lookup.GetArg("id")
+	c.Assert(err, IsNil)
+	c.Assert(id.Value, Equals, "123")
+	name, err := lookup.GetArg("name")
+	c.Assert(err, IsNil)
+	c.Assert(name.Value, Equals, "bar")
+}
+
 func checkConceptParameterValuesInOrder(c *C, concept *gauge_messages.ProtoConcept, paramValues ...string) {
 	params := getParameters(concept.GetConceptStep().Fragments)
 	c.Assert(len(params), Equals, len(paramValues))
@@ -498,6 +528,54 @@ func TestExecuteFailsWhenSpecHasParseErrors(t *testing.T) {
 	}
 }
 
+func TestExecuteRunsSpecHooksBeforeTheSpec(t *testing.T) {
+	defer func() { specHooks = nil }()
+	var hookedSpecs []string
+	RegisterSpecHook(func(spec *gauge.Specification) error {
+		hookedSpecs = append(hookedSpecs, spec.Heading.Value)
+		return nil
+	})
+	r := &mockRunner{ExecuteAndGetStatusFunc: func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		return &gauge_messages.ProtoExecutionResult{}
+	}}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	se := newSpecExecutor(exampleSpecWithScenarios, r, h, gauge.NewBuildErrors(), 0)
+
+	res := se.execute(true, false, false)
+
+	if res.GetFailed() {
+		t.Errorf("Expected result.Failed=false, got %t", res.GetFailed())
+	}
+	if len(hookedSpecs) != 1 || hookedSpecs[0] != exampleSpecWithScenarios.Heading.Value {
+		t.Errorf("Expected the spec hook to be called with the spec, got %v", hookedSpecs)
+	}
+}
+
+func TestExecuteFailsSpecWhenSpecHookFails(t *testing.T) {
+	defer func() { specHooks = nil }()
+	RegisterSpecHook(func(spec *gauge.Specification) error { return nil })
+	RegisterSpecHook(func(spec *gauge.Specification) error { return fmt.Errorf("invalid timeout") })
+	executed := false
+	r := &mockRunner{ExecuteAndGetStatusFunc: func(m *gauge_messages.Message) *gauge_messages.ProtoExecutionResult {
+		executed = true
+		return &gauge_messages.ProtoExecutionResult{}
+	}}
+	h := &mockPluginHandler{NotifyPluginsfunc: func(m *gauge_messages.Message) {}, GracefullyKillPluginsfunc: func() {}}
+	se := newSpecExecutor(exampleSpecWithScenarios, r, h, gauge.NewBuildErrors(), 0)
+
+	res := se.execute(true, true, true)
+
+	if !res.GetFailed() {
+		t.Errorf("Expected result.Failed=true, got %t", res.GetFailed())
+	}
+	if executed {
+		t.Error("Expected the spec not to be executed")
+	}
+	if len(res.Errors) != 1 || !strings.Contains(res.Errors[0].Message, "invalid timeout") {
+		t.Errorf("Expected the hook error in the result, got %v", res.Errors)
+	}
+}
+
 func TestExecuteSkipsWhenSpecHasErrors(t *testing.T) {
 	errs := gauge.NewBuildErrors()
 	errs.SpecErrs[exampleSpec] = append(errs.SpecErrs[exampleSpec], fmt.Errorf("some error"))
@@ -752,6 +830,38 @@ func TestExecuteScenario(t *testing.T) {
 	}
 }
 
+func TestExecuteScenarioSetsScenarioResult(t *testing.T) {
+	passing := &gauge.Scenario{Heading: &gauge.Heading{Value: "Passing"}, Items: make([]gauge.Item, 0), Tags: &gauge.Tags{}, Span: &gauge.Span{}}
+	failing := &gauge.Scenario{Heading: &gauge.Heading{Value: "Failing"}, Items: make([]gauge.Item, 0), Tags: &gauge.Tags{}, Span: &gauge.Span{}}
+	spec := &gauge.Specification{Heading: &gauge.Heading{Value: "Spec"}, FileName: "spec.spec", Tags: &gauge.Tags{}, Scenarios: []*gauge.Scenario{passing, failing}}
+	se := newSpecExecutor(spec, nil, nil, gauge.NewBuildErrors(), 0)
+	se.scenarioExecutor = &mockExecutor{
+		executeFunc: func(i gauge.Item, r result.Result) {
+			res := r.(*result.ScenarioResult)
+			res.AddExecTime(1500)
+			if i.(*gauge.Scenario) == failing {
+				res.AddItems([]*gauge_messages.ProtoItem{{ItemType: gauge_messages.ProtoItem_Step, Step: &gauge_messages.ProtoStep{
+					StepExecutionResult: &gauge_messages.ProtoStepExecutionResult{ExecutionResult: &gauge_messages.ProtoExecutionResult{Failed: true, ErrorMessage: "assertion failed"}},
+				}}})
+				res.SetFailure()
+				return
+			}
+			res.ProtoScenario.ExecutionStatus = gauge_messages.ExecutionStatus_PASSED
+		},
+	}
+
+	se.execute(false, true, false)
+
+	want := &gauge.ScenarioResult{Passed: true, Duration: 1500 * time.Millisecond}
+	if !reflect.DeepEqual(passing.Result, want) {
+		t.Errorf("Expected result of passing scenario to be %v, got %v", want, passing.Result)
+	}
+	want = &gauge.ScenarioResult{Passed: false, FailureReason: "assertion failed", Duration: 1500 * time.Millisecond}
+	if !reflect.DeepEqual(failing.Result, want) {
+		t.Errorf("Expected result of failing scenario to be %v, got %v", want, failing.Result)
+	}
+}
+
 func TestExecuteShouldMarkSpecAsSkippedWhenAllScenariosSkipped(t *testing.T) {
 	errs := gauge.NewBuildErrors()
 	se := newSpecExecutor(exampleSpecWithScenarios, nil, nil, errs, 0)
@@ -0,0 +1,45 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package execution
+
+import (
+	"fmt"
+
+	"github.com/getgauge/gauge/gauge"
+)
+
+// SpecHook is run by Gauge before a spec is executed. It may change the spec, for example to add scenarios, or fail it
+// by returning an error.
+type SpecHook func(spec *gauge.Specification) error
+
+var specHooks []SpecHook
+
+// RegisterSpecHook adds a hook to be run before each spec is executed. Hooks run in the order they are registered,
+// and should be registered before the execution starts.
+func RegisterSpecHook(h SpecHook) {
+	specHooks = append(specHooks, h)
+}
+
+func runSpecHooks(spec *gauge.Specification) error {
+	for _, h := range specHooks {
+		if err := h(spec); err != nil {
+			return fmt.Errorf("Spec hook failed for %s: %s", spec.FileName, err.Error())
+		}
+	}
+	return nil
+}
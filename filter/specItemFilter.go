@@ -34,22 +34,46 @@ import (
 
 type scenarioFilterBasedOnSpan struct {
 	lineNumbers []int
+	lineRanges  []LineRange
 }
+
+// LineRange is an inclusive range of line numbers in a spec file, as given in specs/foo.spec:10-20.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+func (r LineRange) contains(lineNumber int) bool {
+	return r.Start <= lineNumber && lineNumber <= r.End
+}
+
 type ScenarioFilterBasedOnTags struct {
 	specTags      []string
 	tagExpression string
 }
 
 func NewScenarioFilterBasedOnSpan(lineNumbers []int) *scenarioFilterBasedOnSpan {
-	return &scenarioFilterBasedOnSpan{lineNumbers}
+	return &scenarioFilterBasedOnSpan{lineNumbers: lineNumbers}
+}
+
+// NewScenarioFilterBasedOnLines keeps the scenarios spanning any of the line numbers along with the scenarios whose
+// heading is on a line within any of the line ranges.
+func NewScenarioFilterBasedOnLines(lineNumbers []int, lineRanges []LineRange) *scenarioFilterBasedOnSpan {
+	return &scenarioFilterBasedOnSpan{lineNumbers: lineNumbers, lineRanges: lineRanges}
 }
 
 func (filter *scenarioFilterBasedOnSpan) Filter(item gauge.Item) bool {
 	if item.Kind() != gauge.ScenarioKind {
 		return false
 	}
+	scenario := item.(*gauge.Scenario)
 	for _, lineNumber := range filter.lineNumbers {
-		if item.(*gauge.Scenario).InSpan(lineNumber) {
+		if scenario.InSpan(lineNumber) {
+			return false
+		}
+	}
+	for _, lineRange := range filter.lineRanges {
+		if scenario.Span != nil && lineRange.contains(scenario.Span.Start) {
 			return false
 		}
 	}
@@ -60,6 +84,16 @@ func newScenarioFilterBasedOnTags(specTags []string, tagExp string) *ScenarioFil
 	return &ScenarioFilterBasedOnTags{specTags, tagExp}
 }
 
+// NewTagFilter returns a filter which removes the scenarios of the spec not matching the tag expression.
+// The tags of the spec apply to each of its scenarios.
+func NewTagFilter(spec *gauge.Specification, tagExpression string) *ScenarioFilterBasedOnTags {
+	tagValues := make([]string, 0)
+	if spec.Tags != nil {
+		tagValues = spec.Tags.Values()
+	}
+	return newScenarioFilterBasedOnTags(tagValues, tagExpression)
+}
+
 func (filter *ScenarioFilterBasedOnTags) Filter(item gauge.Item) bool {
 	if item.Kind() == gauge.ScenarioKind {
 		tags := item.(*gauge.Scenario).Tags
@@ -195,11 +229,7 @@ func (filter *ScenarioFilterBasedOnTags) parseTagExpression() (tagExpressionPart
 func filterSpecsByTags(specs []*gauge.Specification, tagExpression string) []*gauge.Specification {
 	filteredSpecs := make([]*gauge.Specification, 0)
 	for _, spec := range specs {
-		tagValues := make([]string, 0)
-		if spec.Tags != nil {
-			tagValues = spec.Tags.Values()
-		}
-		spec.Filter(newScenarioFilterBasedOnTags(tagValues, tagExpression))
+		spec.Filter(NewTagFilter(spec, tagExpression))
 		if len(spec.Scenarios) != 0 {
 			filteredSpecs = append(filteredSpecs, spec)
 		}
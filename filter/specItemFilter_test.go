@@ -701,3 +701,33 @@ func (s *MySuite) TestToFilterScenariosByUnavailableTags(c *C) {
 
 	c.Assert(len(specs), Equals, 0)
 }
+
+func (s *MySuite) TestScenarioFilterBasedOnLineRangeKeepsScenariosWithHeadingInRange(c *C) {
+	scenario1 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "First Scenario"},
+		Span:    &gauge.Span{Start: 1, End: 9},
+	}
+	scenario2 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Second Scenario"},
+		Span:    &gauge.Span{Start: 10, End: 14},
+	}
+	scenario3 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Third Scenario"},
+		Span:    &gauge.Span{Start: 15, End: 22},
+	}
+	scenario4 := &gauge.Scenario{
+		Heading: &gauge.Heading{Value: "Fourth Scenario"},
+		Span:    &gauge.Span{Start: 23, End: 30},
+	}
+	spec := &gauge.Specification{
+		Items:     []gauge.Item{scenario1, scenario2, scenario3, scenario4},
+		Scenarios: []*gauge.Scenario{scenario1, scenario2, scenario3, scenario4},
+	}
+
+	spec.Filter(NewScenarioFilterBasedOnLines([]int{25}, []LineRange{{Start: 5, End: 20}}))
+
+	c.Assert(len(spec.Scenarios), Equals, 3)
+	c.Assert(spec.Scenarios[0], Equals, scenario2)
+	c.Assert(spec.Scenarios[1], Equals, scenario3)
+	c.Assert(spec.Scenarios[2], Equals, scenario4)
+}
@@ -71,6 +71,10 @@ func (formatter *formatter) Scenario(scenario *gauge.Scenario) {
 }
 
 func (formatter *formatter) Step(step *gauge.Step) {
+	// Steps pulled in from another spec by an include directive stay in that spec.
+	if step.Included {
+		return
+	}
 	formatter.buffer.WriteString(FormatStep(step))
 }
 
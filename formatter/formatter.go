@@ -199,6 +199,11 @@ func formatAndSave(spec *gauge.Specification) error {
 	return nil
 }
 
+// SpecToText regenerates the source of the spec from its items, in the order they were parsed.
+func SpecToText(spec *gauge.Specification) string {
+	return FormatSpecification(spec)
+}
+
 func FormatSpecification(specification *gauge.Specification) string {
 	var formattedSpec bytes.Buffer
 	queue := &gauge.ItemQueue{Items: specification.AllItems()}
@@ -61,6 +61,28 @@ Scenario Heading
 `)
 }
 
+func (s *MySuite) TestFormatSpecificationLeavesOutIncludedContexts(c *C) {
+	tokens := []*parser.Token{
+		&parser.Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&parser.Token{Kind: gauge.StepKind, Value: "Context step", LineNo: 2, LineText: "Context step"},
+		&parser.Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 3},
+		&parser.Token{Kind: gauge.StepKind, Value: "Example step", LineNo: 4, LineText: "Example step"},
+	}
+	spec, _, _ := new(parser.SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "foo.spec")
+	spec.Contexts = append(spec.Contexts, &gauge.Step{Value: "Included step", LineText: "Included step", FileName: "common.spec", Included: true})
+
+	formatted := FormatSpecification(spec)
+
+	c.Assert(formatted, Equals,
+		`Spec Heading
+============
+* Context step
+Scenario Heading
+----------------
+* Example step
+`)
+}
+
 func (s *MySuite) TestFormatTable(c *C) {
 	cell1 := gauge.TableCell{"john", gauge.Static}
 	cell2 := gauge.TableCell{"doe", gauge.Static}
@@ -454,3 +476,80 @@ func (s *MySuite) TestFormatShouldNotAddExtraNewLinesBeforeDataTable(c *C) {
    |Rhythm|0          |
 `)
 }
+
+func (s *MySuite) TestSpecToTextRoundTrip(c *C) {
+	specText := `# Spec Heading
+
+tags: foo, bar
+
+A comment about the spec
+
+* context step
+
+## Scenario Heading
+
+tags: baz
+
+* step with "static" param
+* step with inline table
+
+   |id|name|
+   |--|----|
+   |1 |foo |
+
+A comment about the scenario
+
+* another step
+___
+* teardown step
+`
+	spec, res := new(parser.SpecParser).ParseSpecText(specText, "foo.spec")
+	c.Assert(res.Ok, Equals, true)
+
+	text := SpecToText(spec)
+	reparsed, res := new(parser.SpecParser).ParseSpecText(text, "foo.spec")
+
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(reparsed.Heading.Value, Equals, spec.Heading.Value)
+	c.Assert(reparsed.Tags.Values(), DeepEquals, spec.Tags.Values())
+	c.Assert(len(reparsed.Comments), Equals, len(spec.Comments))
+	c.Assert(stepValues(reparsed.Contexts), DeepEquals, []string{"context step"})
+	c.Assert(len(reparsed.Scenarios), Equals, 1)
+	c.Assert(reparsed.Scenarios[0].Heading.Value, Equals, "Scenario Heading")
+	c.Assert(reparsed.Scenarios[0].Tags.Values(), DeepEquals, []string{"baz"})
+	c.Assert(stepValues(reparsed.Scenarios[0].Steps), DeepEquals, stepValues(spec.Scenarios[0].Steps))
+	c.Assert(reparsed.Scenarios[0].Steps[1].Args[0].Table.Rows(), DeepEquals, [][]string{{"1", "foo"}})
+	c.Assert(stepValues(reparsed.TearDownSteps), DeepEquals, []string{"teardown step"})
+	c.Assert(len(reparsed.Items), Equals, len(spec.Items))
+	c.Assert(SpecToText(reparsed), Equals, text)
+}
+
+func stepValues(steps []*gauge.Step) []string {
+	var values []string
+	for _, step := range steps {
+		values = append(values, step.Value)
+	}
+	return values
+}
+
+func (s *MySuite) TestFormatSpecificationKeepsCommentBeforeSpecTags(c *C) {
+	text := `Spec Heading
+============
+
+This is a comment before tags
+
+tags: foo, bar
+
+Scenario Heading
+----------------
+* Example step
+`
+	spec, res, err := new(parser.SpecParser).Parse(text, gauge.NewConceptDictionary(), "")
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+
+	c.Assert(spec.Items[1].Kind(), Equals, gauge.CommentKind)
+	c.Assert(spec.Items[1].(*gauge.Comment).Value, Equals, "This is a comment before tags")
+	c.Assert(spec.Items[3].Kind(), Equals, gauge.TagKind)
+	c.Assert(FormatSpecification(spec), Equals, text)
+}
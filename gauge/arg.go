@@ -74,17 +74,20 @@ func (lookup *ArgLookup) GetArg(param string) (*StepArg, error) {
 }
 
 func (lookup *ArgLookup) GetCopy() (*ArgLookup, error) {
+	return lookup.Clone(), nil
+}
+
+// Clone returns a deep copy of the lookup. Params keep their order and the args bound to them, including their tables,
+// are copied, so a concept expansion that rebinds or edits an arg of the clone never leaks into the original.
+func (lookup *ArgLookup) Clone() *ArgLookup {
 	lookupCopy := new(ArgLookup)
-	var err error
-	for key, _ := range lookup.ParamIndexMap {
-		lookupCopy.AddArgName(key)
-		var arg *StepArg
-		arg, err = lookup.GetArg(key)
-		if arg != nil {
-			err = lookupCopy.AddArgValue(key, &StepArg{Value: arg.Value, ArgType: arg.ArgType, Table: arg.Table, Name: arg.Name})
+	for _, param := range lookup.paramValue {
+		lookupCopy.AddArgName(param.name)
+		if param.stepArg != nil {
+			lookupCopy.paramValue[lookupCopy.ParamIndexMap[param.name]].stepArg = param.stepArg.clone()
 		}
 	}
-	return lookupCopy, err
+	return lookupCopy
 }
 
 func (lookup *ArgLookup) FromDataTableRow(datatable *Table, index int) (*ArgLookup, error) {
@@ -133,6 +136,10 @@ func (stepArg *StepArg) String() string {
 	return fmt.Sprintf("{Name: %s,value %s,argType %s,table %v}", stepArg.Name, stepArg.Value, string(stepArg.ArgType), stepArg.Table)
 }
 
+func (stepArg *StepArg) clone() *StepArg {
+	return &StepArg{Name: stepArg.Name, Value: stepArg.Value, ArgType: stepArg.ArgType, Table: stepArg.Table.clone()}
+}
+
 func (stepArg *StepArg) ArgValue() string {
 	switch stepArg.ArgType {
 	case Static, Dynamic:
@@ -79,6 +79,53 @@ func (s *MySuite) TestGetLookupCopy(c *C) {
 	c.Assert(stepArg.Value, Equals, "oldValue")
 }
 
+func (s *MySuite) TestCloneLookupPreservesParamOrder(c *C) {
+	originalLookup := new(ArgLookup)
+	for _, param := range []string{"a", "b", "c", "d", "e"} {
+		originalLookup.AddArgName(param)
+		originalLookup.AddArgValue(param, &StepArg{Value: param + "-value", ArgType: Static})
+	}
+
+	clonedLookup := originalLookup.Clone()
+
+	c.Assert(clonedLookup.ParamIndexMap, DeepEquals, originalLookup.ParamIndexMap)
+	for param := range originalLookup.ParamIndexMap {
+		stepArg, err := clonedLookup.GetArg(param)
+		c.Assert(err, IsNil)
+		c.Assert(stepArg.Value, Equals, param+"-value")
+	}
+}
+
+func (s *MySuite) TestCloneLookupCopiesTables(c *C) {
+	table := new(Table)
+	table.AddHeaders([]string{"id", "name"})
+	table.AddRowValues([]string{"1", "admin"})
+	originalLookup := new(ArgLookup)
+	originalLookup.AddArgName("users")
+	originalLookup.AddArgValue("users", &StepArg{Name: "users", ArgType: TableArg, Table: *table})
+
+	clonedLookup := originalLookup.Clone()
+	clonedArg, _ := clonedLookup.GetArg("users")
+	clonedArg.Table.Columns[1][0].Value = "root"
+	clonedArg.Table.AddHeaders([]string{"role"})
+
+	originalArg, _ := originalLookup.GetArg("users")
+	c.Assert(originalArg.Table.Columns[1][0].Value, Equals, "admin")
+	c.Assert(originalArg.Table.Headers, DeepEquals, []string{"id", "name"})
+	c.Assert(originalArg.Table.headerExists("name"), Equals, true)
+}
+
+func (s *MySuite) TestCloneLookupWithUnboundParam(c *C) {
+	originalLookup := new(ArgLookup)
+	originalLookup.AddArgName("param1")
+
+	clonedLookup := originalLookup.Clone()
+
+	stepArg, err := clonedLookup.GetArg("param1")
+	c.Assert(err, IsNil)
+	c.Assert(stepArg, IsNil)
+}
+
 func (s *MySuite) TestGetLookupFromTableRow(c *C) {
 	dataTable := new(Table)
 	dataTable.AddHeaders([]string{"id", "name"})
@@ -47,11 +47,7 @@ func (dict *ConceptDictionary) ReplaceNestedConceptSteps(conceptStep *Step) erro
 			//replace step with actual concept
 			conceptStep.ConceptSteps[i].ConceptSteps = nestedConcept.ConceptStep.ConceptSteps
 			conceptStep.ConceptSteps[i].IsConcept = nestedConcept.ConceptStep.IsConcept
-			lookupCopy, err := nestedConcept.ConceptStep.Lookup.GetCopy()
-			if err != nil {
-				return err
-			}
-			conceptStep.ConceptSteps[i].Lookup = *lookupCopy
+			conceptStep.ConceptSteps[i].Lookup = *nestedConcept.ConceptStep.Lookup.Clone()
 		} else {
 			if err := dict.updateStep(stepInsideConcept); err != nil {
 				return err
@@ -70,11 +66,7 @@ func (dict *ConceptDictionary) updateStep(step *Step) error {
 		for _, allSteps := range dict.constructionMap[step.Value] {
 			allSteps.IsConcept = step.IsConcept
 			allSteps.ConceptSteps = step.ConceptSteps
-			lookupCopy, err := step.Lookup.GetCopy()
-			if err != nil {
-				return err
-			}
-			allSteps.Lookup = *lookupCopy
+			allSteps.Lookup = *step.Lookup.Clone()
 		}
 	}
 	return nil
@@ -0,0 +1,60 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+import "github.com/sourcegraph/go-langserver/pkg/lsp"
+
+// StartPosition is the position of the first character of the step in its file.
+func (step *Step) StartPosition() lsp.Position {
+	return lsp.Position{Line: step.LineNo - 1, Character: step.StartChar}
+}
+
+// EndPosition is the position after the last character of the step in its file.
+func (step *Step) EndPosition() lsp.Position {
+	return lsp.Position{Line: step.LineNo - 1, Character: step.EndChar}
+}
+
+// StartPosition is the position of the first character of the heading in its file.
+func (heading *Heading) StartPosition() lsp.Position {
+	return lsp.Position{Line: heading.LineNo - 1, Character: heading.StartChar}
+}
+
+// EndPosition is the position after the last character of the heading in its file.
+func (heading *Heading) EndPosition() lsp.Position {
+	return lsp.Position{Line: heading.LineNo - 1, Character: heading.EndChar}
+}
+
+// StartPosition is the position of the first character of the comment in its file.
+func (comment *Comment) StartPosition() lsp.Position {
+	return lsp.Position{Line: comment.LineNo - 1, Character: comment.StartChar}
+}
+
+// EndPosition is the position after the last character of the comment in its file.
+func (comment *Comment) EndPosition() lsp.Position {
+	return lsp.Position{Line: comment.LineNo - 1, Character: comment.EndChar}
+}
+
+// StartPosition is the position of the first character of the table header in its file.
+func (table *Table) StartPosition() lsp.Position {
+	return lsp.Position{Line: table.LineNo - 1, Character: table.StartChar}
+}
+
+// EndPosition is the position after the last character of the last row of the table in its file.
+func (table *Table) EndPosition() lsp.Position {
+	return lsp.Position{Line: table.EndLineNo - 1, Character: table.EndChar}
+}
@@ -60,7 +60,7 @@ func (s *MySuite) TestCopyingProtoTable(c *C) {
 }
 
 func (s *MySuite) TestCopyingStepValue(c *C) {
-	stepValue := &StepValue{[]string{"param1"}, "foo with {}", "foo with <param>"}
+	stepValue := &StepValue{Args: []string{"param1"}, StepValue: "foo with {}", ParameterizedStepValue: "foo with <param>"}
 	protoStepValue := ConvertToProtoStepValue(stepValue)
 
 	c.Assert(protoStepValue.GetStepValue(), Equals, stepValue.StepValue)
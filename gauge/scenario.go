@@ -17,15 +17,34 @@
 
 package gauge
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Scenario struct {
 	Heading           *Heading
 	Steps             []*Step
 	Comments          []*Comment
 	Tags              *Tags
 	Items             []Item
+	TearDownSteps     []*Step
 	DataTableRow      Table
 	DataTableRowIndex int
-	Span              *Span
+	// DataTable is the scenario's own data table. When present, the scenario is run once for each of its rows.
+	DataTable DataTable
+	Span      *Span
+	// Result is set by the executor once the scenario has run. It is nil for a scenario which has not been run.
+	Result *ScenarioResult
+}
+
+// ScenarioResult is the outcome of running a scenario.
+type ScenarioResult struct {
+	Passed        bool
+	FailureReason string
+	Duration      time.Duration
+	RetryCount    int
 }
 
 // Span represents scope of Scenario based on line number
@@ -60,6 +79,78 @@ func (scenario *Scenario) NTags() int {
 	return len(scenario.Tags.Values())
 }
 
+// SetTag adds the given tag to the scenario, creating the tags if there are none.
+func (scenario *Scenario) SetTag(tag string) {
+	if scenario.Tags == nil {
+		scenario.Tags = &Tags{}
+	}
+	scenario.Tags.Add([]string{tag})
+}
+
+// RemoveTag removes the first occurrence of the given tag from the scenario.
+func (scenario *Scenario) RemoveTag(tag string) {
+	if scenario.Tags != nil {
+		scenario.Tags.Remove(tag)
+	}
+}
+
+// EffectiveTags returns the tags of the given spec followed by the tags of the scenario, without duplicates.
+func (scenario *Scenario) EffectiveTags(spec *Specification) []string {
+	var all []string
+	if spec != nil && spec.Tags != nil {
+		all = append(all, spec.Tags.Values()...)
+	}
+	if scenario.Tags != nil {
+		all = append(all, scenario.Tags.Values()...)
+	}
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range all {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// IsDisabled returns true if any of the effective tags of the scenario is one of the skip tags.
+// No scenario is disabled when there are no skip tags.
+func (scenario *Scenario) IsDisabled(skipTags []string, spec *Specification) bool {
+	for _, tag := range scenario.EffectiveTags(spec) {
+		for _, skipTag := range skipTags {
+			if tag == skipTag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TagQuery returns a tag expression which selects the scenarios having all the effective tags of this scenario.
+// Tags containing spaces are quoted. Returns an empty string if the scenario has no tags.
+func (scenario *Scenario) TagQuery(spec *Specification) string {
+	var tags []string
+	for _, tag := range scenario.EffectiveTags(spec) {
+		if strings.ContainsAny(tag, " \t") {
+			tag = strconv.Quote(tag)
+		}
+		tags = append(tags, tag)
+	}
+	return strings.Join(tags, " & ")
+}
+
+// AddDataTable attaches a data table to the scenario. Its values take precedence over the spec's data table.
+func (scenario *Scenario) AddDataTable(table *Table) {
+	scenario.DataTable.Table = *table
+	scenario.AddItem(&scenario.DataTable)
+}
+
+// HasDataTable returns true if the scenario has a data table of its own.
+func (scenario *Scenario) HasDataTable() bool {
+	return scenario.DataTable.IsInitialized()
+}
+
 func (scenario *Scenario) AddComment(comment *Comment) {
 	scenario.Comments = append(scenario.Comments, comment)
 	scenario.AddItem(comment)
@@ -69,13 +160,40 @@ func (scenario *Scenario) InSpan(lineNumber int) bool {
 	return scenario.Span.isInRange(lineNumber)
 }
 
-func (scenario *Scenario) renameSteps(oldStep Step, newStep Step, orderMap map[int]int) bool {
-	isRefactored := false
-	isConcept := false
-	for _, step := range scenario.Steps {
-		isRefactored = step.Rename(oldStep, newStep, isRefactored, orderMap, &isConcept)
+// AddTearDownStep adds a step which is executed after the steps of this scenario.
+func (scenario *Scenario) AddTearDownStep(step *Step) {
+	scenario.TearDownSteps = append(scenario.TearDownSteps, step)
+	scenario.AddItem(step)
+}
+
+// clone returns a copy of the scenario with copies of its steps. The copies are recorded in steps against the originals.
+func (scenario *Scenario) clone(steps map[*Step]*Step) *Scenario {
+	clone := *scenario
+	clone.Steps = cloneSteps(scenario.Steps, nil, steps)
+	clone.TearDownSteps = cloneSteps(scenario.TearDownSteps, nil, steps)
+	clone.Items = cloneItems(scenario.Items, steps)
+	clone.Comments = append([]*Comment(nil), scenario.Comments...)
+	if scenario.Span != nil {
+		span := *scenario.Span
+		clone.Span = &span
 	}
-	return isRefactored
+	if scenario.Result != nil {
+		result := *scenario.Result
+		clone.Result = &result
+	}
+	return &clone
+}
+
+// stepsWithTearDown returns the steps of the scenario followed by its teardown steps, in a new slice.
+func (scenario *Scenario) stepsWithTearDown() []*Step {
+	steps := make([]*Step, 0, len(scenario.Steps)+len(scenario.TearDownSteps))
+	steps = append(steps, scenario.Steps...)
+	return append(steps, scenario.TearDownSteps...)
+}
+
+func (scenario *Scenario) renameSteps(oldStep Step, newStep Step, orderMap map[int]int) []*RenameResult {
+	isConcept := false
+	return renameAll(scenario.stepsWithTearDown(), oldStep, newStep, orderMap, &isConcept)
 }
 
 func (scenario *Scenario) AddItem(itemToAdd Item) {
@@ -89,6 +207,10 @@ func (scenario *Scenario) LatestStep() *Step {
 	return scenario.Steps[len(scenario.Steps)-1]
 }
 
+func (scenario *Scenario) LatestTeardown() *Step {
+	return scenario.TearDownSteps[len(scenario.TearDownSteps)-1]
+}
+
 func (scenario *Scenario) UsesArgsInSteps(args ...string) bool {
 	return UsesArgs(scenario.Steps, args...)
 }
@@ -96,3 +218,43 @@ func (scenario *Scenario) UsesArgsInSteps(args ...string) bool {
 func (scenario Scenario) Kind() TokenKind {
 	return ScenarioKind
 }
+
+// ResolvedSteps returns the scenario's steps with concept steps expanded using the given dictionary.
+// Each concept step in the result carries its resolved ConceptSteps, nested concepts being expanded in turn.
+// The scenario's own steps are not modified. Steps which are not found in the dictionary are left as leaves,
+// as is a concept which is already being expanded higher up in the same branch, so cyclic concepts terminate.
+func (scenario *Scenario) ResolvedSteps(dict *ConceptDictionary) []*Step {
+	var steps []*Step
+	for _, step := range scenario.Steps {
+		steps = append(steps, resolveStep(step, nil, dict, make(map[string]bool)))
+	}
+	return steps
+}
+
+func resolveStep(step *Step, parent *Step, dict *ConceptDictionary, expanding map[string]bool) *Step {
+	resolved := new(Step)
+	*resolved = *step
+	resolved.Parent = parent
+	resolved.ConceptSteps = nil
+	concept := dict.Search(step.Value)
+	if concept == nil || expanding[step.Value] {
+		return resolved
+	}
+	expanding[step.Value] = true
+	defer delete(expanding, step.Value)
+
+	resolved.IsConcept = true
+	resolved.Lookup = *concept.ConceptStep.Lookup.Clone()
+	for i, arg := range step.Args {
+		if i >= len(concept.ConceptStep.Args) {
+			break
+		}
+		if err := resolved.Lookup.AddArgValue(concept.ConceptStep.Args[i].Value, arg.clone()); err != nil {
+			break
+		}
+	}
+	for _, conceptStep := range concept.ConceptStep.ConceptSteps {
+		resolved.ConceptSteps = append(resolved.ConceptSteps, resolveStep(conceptStep, resolved, dict, expanding))
+	}
+	return resolved
+}
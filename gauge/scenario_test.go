@@ -68,3 +68,158 @@ func (s *MySuite) TestDoesNotUseArgsInStep(c *C) {
 
 	c.Assert(scenario.UsesArgsInSteps("foo"), Equals, false)
 }
+
+func (s *MySuite) TestSetTagOnScenarioWithoutTags(c *C) {
+	scenario := &Scenario{}
+
+	scenario.SetTag("foo")
+
+	c.Assert(scenario.Tags.Values(), DeepEquals, []string{"foo"})
+}
+
+func (s *MySuite) TestSetTagOnScenarioWithTags(c *C) {
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"foo", "bar"}}}}
+
+	scenario.SetTag("baz")
+
+	c.Assert(scenario.Tags.Values(), DeepEquals, []string{"foo", "bar", "baz"})
+}
+
+func (s *MySuite) TestRemoveTagFromScenario(c *C) {
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"foo", "bar"}, {"foo"}}}}
+
+	scenario.RemoveTag("foo")
+
+	c.Assert(scenario.Tags.Values(), DeepEquals, []string{"bar", "foo"})
+}
+
+func (s *MySuite) TestRemoveTagFromScenarioWithoutTags(c *C) {
+	scenario := &Scenario{}
+
+	scenario.RemoveTag("foo")
+
+	c.Assert(scenario.Tags, IsNil)
+}
+
+func (s *MySuite) TestAddTearDownStepToScenario(c *C) {
+	scenario := &Scenario{}
+	step := &Step{Value: "cleanup"}
+
+	scenario.AddTearDownStep(step)
+
+	c.Assert(scenario.TearDownSteps, DeepEquals, []*Step{step})
+	c.Assert(scenario.LatestTeardown(), Equals, step)
+	c.Assert(scenario.Items, DeepEquals, []Item{step})
+}
+
+func (s *MySuite) TestRenameStepsInScenarioTearDown(c *C) {
+	scenario := &Scenario{}
+	scenario.AddStep(&Step{Value: "some step"})
+	scenario.AddTearDownStep(&Step{Value: "old step"})
+
+	results := scenario.renameSteps(Step{Value: "old step"}, Step{Value: "new step"}, map[int]int{})
+
+	c.Assert(len(results), Equals, 1)
+	c.Assert(scenario.Steps[0].Value, Equals, "some step")
+	c.Assert(scenario.TearDownSteps[0].Value, Equals, "new step")
+}
+
+func (s *MySuite) TestStepsWithTearDownDoesNotWriteIntoTheSteps(c *C) {
+	step := &Step{Value: "some step"}
+	scenario := &Scenario{Steps: make([]*Step, 1, 2), TearDownSteps: []*Step{{Value: "cleanup"}}}
+	scenario.Steps[0] = step
+
+	steps := scenario.stepsWithTearDown()
+	steps[1] = &Step{Value: "other step"}
+
+	c.Assert(len(steps), Equals, 2)
+	c.Assert(scenario.Steps[:2][1], IsNil)
+	c.Assert(scenario.TearDownSteps[0].Value, Equals, "cleanup")
+}
+
+func (s *MySuite) TestTagQueryWithSingleTag(c *C) {
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"smoke"}}}}
+
+	c.Assert(scenario.TagQuery(&Specification{}), Equals, "smoke")
+}
+
+func (s *MySuite) TestTagQueryWithSpecAndScenarioTags(c *C) {
+	spec := &Specification{Tags: &Tags{RawValues: [][]string{{"smoke", "regression"}}}}
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"regression", "login"}}}}
+
+	c.Assert(scenario.TagQuery(spec), Equals, "smoke & regression & login")
+}
+
+func (s *MySuite) TestTagQueryQuotesMultiWordTags(c *C) {
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"smoke", "user login"}}}}
+
+	c.Assert(scenario.TagQuery(nil), Equals, `smoke & "user login"`)
+}
+
+func (s *MySuite) TestTagQueryWithoutTags(c *C) {
+	scenario := &Scenario{}
+
+	c.Assert(scenario.TagQuery(&Specification{}), Equals, "")
+}
+
+func (s *MySuite) TestResolvedStepsExpandsNestedConcepts(c *C) {
+	dict := NewConceptDictionary()
+	innerConcept := &Step{Value: "inner concept", IsConcept: true, ConceptSteps: []*Step{{Value: "leaf step"}}}
+	outerConcept := &Step{Value: "outer concept", IsConcept: true, ConceptSteps: []*Step{{Value: "first step"}, {Value: "inner concept"}}}
+	dict.ConceptsMap["inner concept"] = &Concept{ConceptStep: innerConcept}
+	dict.ConceptsMap["outer concept"] = &Concept{ConceptStep: outerConcept}
+	scenarioStep := &Step{Value: "outer concept", LineNo: 3}
+	scenario := &Scenario{Steps: []*Step{scenarioStep, {Value: "unknown step"}}}
+
+	steps := scenario.ResolvedSteps(dict)
+
+	c.Assert(len(steps), Equals, 2)
+	outer := steps[0]
+	c.Assert(outer.IsConcept, Equals, true)
+	c.Assert(outer.LineNo, Equals, 3)
+	c.Assert(len(outer.ConceptSteps), Equals, 2)
+	c.Assert(outer.ConceptSteps[0].Value, Equals, "first step")
+	c.Assert(outer.ConceptSteps[0].ConceptSteps, IsNil)
+	inner := outer.ConceptSteps[1]
+	c.Assert(inner.IsConcept, Equals, true)
+	c.Assert(inner.Parent, Equals, outer)
+	c.Assert(len(inner.ConceptSteps), Equals, 1)
+	c.Assert(inner.ConceptSteps[0].Value, Equals, "leaf step")
+	c.Assert(steps[1].IsConcept, Equals, false)
+	c.Assert(scenarioStep.ConceptSteps, IsNil)
+}
+
+func (s *MySuite) TestResolvedStepsWithCyclicConcepts(c *C) {
+	dict := NewConceptDictionary()
+	dict.ConceptsMap["a"] = &Concept{ConceptStep: &Step{Value: "a", IsConcept: true, ConceptSteps: []*Step{{Value: "b"}}}}
+	dict.ConceptsMap["b"] = &Concept{ConceptStep: &Step{Value: "b", IsConcept: true, ConceptSteps: []*Step{{Value: "a"}}}}
+	scenario := &Scenario{Steps: []*Step{{Value: "a"}}}
+
+	steps := scenario.ResolvedSteps(dict)
+
+	b := steps[0].ConceptSteps[0]
+	c.Assert(b.Value, Equals, "b")
+	c.Assert(len(b.ConceptSteps), Equals, 1)
+	c.Assert(b.ConceptSteps[0].Value, Equals, "a")
+	c.Assert(b.ConceptSteps[0].ConceptSteps, IsNil)
+}
+
+func (s *MySuite) TestScenarioWithSkipTagIsDisabled(c *C) {
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"login", "wip"}}}}
+
+	c.Assert(scenario.IsDisabled([]string{"wip", "skip"}, &Specification{}), Equals, true)
+}
+
+func (s *MySuite) TestScenarioIsDisabledBySkipTagOnSpec(c *C) {
+	spec := &Specification{Tags: &Tags{RawValues: [][]string{{"skip"}}}}
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"login"}}}}
+
+	c.Assert(scenario.IsDisabled([]string{"skip"}, spec), Equals, true)
+}
+
+func (s *MySuite) TestScenarioWithoutSkipTagIsNotDisabled(c *C) {
+	scenario := &Scenario{Tags: &Tags{RawValues: [][]string{{"login"}}}}
+
+	c.Assert(scenario.IsDisabled([]string{"skip"}, nil), Equals, false)
+	c.Assert(scenario.IsDisabled(nil, nil), Equals, false)
+}
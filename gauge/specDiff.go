@@ -0,0 +1,107 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+// SpecDiff is the structural difference between two versions of a spec.
+type SpecDiff struct {
+	HeadingChanged    bool
+	AddedScenarios    []*Scenario
+	RemovedScenarios  []*Scenario
+	ModifiedScenarios []*Scenario
+}
+
+// HasChanges tells if the two versions of the spec differ.
+func (diff SpecDiff) HasChanges() bool {
+	return diff.HeadingChanged || len(diff.AddedScenarios) > 0 || len(diff.RemovedScenarios) > 0 || len(diff.ModifiedScenarios) > 0
+}
+
+// Diff compares the spec with a newer version of it. Scenarios are matched by their heading; a scenario present in
+// both versions is modified when its steps differ. Modified scenarios are the ones from the newer version.
+// Either spec may be nil, which is treated as an empty spec.
+func (spec *Specification) Diff(newSpec *Specification) SpecDiff {
+	var diff SpecDiff
+	diff.HeadingChanged = headingOf(spec) != headingOf(newSpec)
+	old := make(map[string]*Scenario)
+	for _, scenario := range scenariosOf(spec) {
+		old[scenarioHeading(scenario)] = scenario
+	}
+	matched := make(map[string]bool)
+	for _, scenario := range scenariosOf(newSpec) {
+		heading := scenarioHeading(scenario)
+		oldScenario, ok := old[heading]
+		if !ok || matched[heading] {
+			diff.AddedScenarios = append(diff.AddedScenarios, scenario)
+			continue
+		}
+		matched[heading] = true
+		if !sameSteps(oldScenario, scenario) {
+			diff.ModifiedScenarios = append(diff.ModifiedScenarios, scenario)
+		}
+	}
+	for _, scenario := range scenariosOf(spec) {
+		if !matched[scenarioHeading(scenario)] {
+			diff.RemovedScenarios = append(diff.RemovedScenarios, scenario)
+		}
+	}
+	return diff
+}
+
+func headingOf(spec *Specification) string {
+	if spec == nil || spec.Heading == nil {
+		return ""
+	}
+	return spec.Heading.Value
+}
+
+func scenariosOf(spec *Specification) []*Scenario {
+	if spec == nil {
+		return nil
+	}
+	return spec.Scenarios
+}
+
+func scenarioHeading(scenario *Scenario) string {
+	if scenario.Heading == nil {
+		return ""
+	}
+	return scenario.Heading.Value
+}
+
+func sameSteps(scenario, other *Scenario) bool {
+	steps, otherSteps := stepTexts(scenario), stepTexts(other)
+	if len(steps) != len(otherSteps) {
+		return false
+	}
+	for i := range steps {
+		if steps[i] != otherSteps[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func stepTexts(scenario *Scenario) []string {
+	var texts []string
+	for _, step := range scenario.Steps {
+		texts = append(texts, step.LineText)
+	}
+	for _, step := range scenario.TearDownSteps {
+		texts = append(texts, step.LineText)
+	}
+	return texts
+}
@@ -0,0 +1,56 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+import . "gopkg.in/check.v1"
+
+func scenarioWithSteps(heading string, steps ...string) *Scenario {
+	scenario := &Scenario{Heading: &Heading{Value: heading}}
+	for _, step := range steps {
+		scenario.Steps = append(scenario.Steps, &Step{LineText: step})
+	}
+	return scenario
+}
+
+func (s *MySuite) TestDiffFindsAddedRemovedAndModifiedScenarios(c *C) {
+	unchanged := scenarioWithSteps("unchanged", "step")
+	removed := scenarioWithSteps("removed", "step")
+	oldSpec := &Specification{Heading: &Heading{Value: "spec"}, Scenarios: []*Scenario{unchanged, removed, scenarioWithSteps("modified", "step")}}
+	modified := scenarioWithSteps("modified", "step", "another step")
+	added := scenarioWithSteps("added", "step")
+	newSpec := &Specification{Heading: &Heading{Value: "spec"}, Scenarios: []*Scenario{scenarioWithSteps("unchanged", "step"), modified, added}}
+
+	diff := oldSpec.Diff(newSpec)
+
+	c.Assert(diff.HeadingChanged, Equals, false)
+	c.Assert(diff.AddedScenarios, DeepEquals, []*Scenario{added})
+	c.Assert(diff.RemovedScenarios, DeepEquals, []*Scenario{removed})
+	c.Assert(diff.ModifiedScenarios, DeepEquals, []*Scenario{modified})
+	c.Assert(diff.HasChanges(), Equals, true)
+}
+
+func (s *MySuite) TestDiffWithNilSpec(c *C) {
+	spec := &Specification{Heading: &Heading{Value: "spec"}, Scenarios: []*Scenario{scenarioWithSteps("scenario", "step")}}
+
+	var empty *Specification
+	diff := empty.Diff(spec)
+
+	c.Assert(diff.HeadingChanged, Equals, true)
+	c.Assert(diff.AddedScenarios, DeepEquals, spec.Scenarios)
+	c.Assert(spec.Diff(spec).HasChanges(), Equals, false)
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+// SpecSummary holds counts describing a spec, for reports and lints which do not need to walk its items.
+type SpecSummary struct {
+	ScenarioCount int
+	// StepCount is the number of steps in the scenarios. Steps of concepts are not counted.
+	StepCount int
+	// TagCount is the number of distinct tags of the spec and its scenarios.
+	TagCount     int
+	HasDataTable bool
+	// ConceptCount is the number of concepts used by the contexts, scenarios and teardown of the spec.
+	ConceptCount      int
+	TearDownStepCount int
+}
+
+// Summary returns the SpecSummary of the spec.
+func (spec *Specification) Summary() SpecSummary {
+	summary := SpecSummary{
+		ScenarioCount:     len(spec.Scenarios),
+		HasDataTable:      spec.DataTable.IsInitialized(),
+		TearDownStepCount: len(spec.TearDownSteps),
+	}
+	tags := make(map[string]bool)
+	addTags := func(t *Tags) {
+		if t == nil {
+			return
+		}
+		for _, tag := range t.Values() {
+			tags[tag] = true
+		}
+	}
+	countConcepts := func(steps []*Step) {
+		for _, step := range steps {
+			if step.IsConcept {
+				summary.ConceptCount++
+			}
+		}
+	}
+	addTags(spec.Tags)
+	countConcepts(spec.Contexts)
+	for _, scenario := range spec.Scenarios {
+		summary.StepCount += len(scenario.Steps)
+		addTags(scenario.Tags)
+		countConcepts(scenario.Steps)
+		countConcepts(scenario.TearDownSteps)
+	}
+	countConcepts(spec.TearDownSteps)
+	summary.TagCount = len(tags)
+	return summary
+}
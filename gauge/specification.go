@@ -42,6 +42,7 @@ const (
 	TableKind
 	DataTableKind
 	TearDownKind
+	IncludeKind
 )
 
 type Specification struct {
@@ -54,6 +55,7 @@ type Specification struct {
 	Tags          *Tags
 	Items         []Item
 	TearDownSteps []*Step
+	Includes      []string
 }
 
 type Item interface {
@@ -71,7 +73,7 @@ func (spec *Specification) ProcessConceptStepsFrom(conceptDictionary *ConceptDic
 		}
 	}
 	for _, scenario := range spec.Scenarios {
-		for _, step := range scenario.Steps {
+		for _, step := range scenario.stepsWithTearDown() {
 			if err := spec.processConceptStep(step, conceptDictionary); err != nil {
 				return err
 			}
@@ -143,6 +145,15 @@ func (spec *Specification) AddDataTable(table *Table) {
 	spec.AddItem(&spec.DataTable)
 }
 
+// DataTableFor returns the data table which the steps of the given scenario take their values from.
+// This is the scenario's own table if it has one, the spec's table otherwise. scenario is nil for contexts and teardowns.
+func (spec *Specification) DataTableFor(scenario *Scenario) *Table {
+	if scenario != nil && scenario.HasDataTable() {
+		return &scenario.DataTable.Table
+	}
+	return &spec.DataTable.Table
+}
+
 func (spec *Specification) AddExternalDataTable(externalTable *DataTable) {
 	spec.DataTable = *externalTable
 	spec.AddItem(externalTable)
@@ -160,6 +171,21 @@ func (spec *Specification) NTags() int {
 	return len(spec.Tags.Values())
 }
 
+// SetTag adds the given tag to the specification, creating the tags if there are none.
+func (spec *Specification) SetTag(tag string) {
+	if spec.Tags == nil {
+		spec.Tags = &Tags{}
+	}
+	spec.Tags.Add([]string{tag})
+}
+
+// RemoveTag removes the first occurrence of the given tag from the specification.
+func (spec *Specification) RemoveTag(tag string) {
+	if spec.Tags != nil {
+		spec.Tags.Remove(tag)
+	}
+}
+
 func (spec *Specification) LatestScenario() *Scenario {
 	return spec.Scenarios[len(spec.Scenarios)-1]
 }
@@ -197,6 +223,26 @@ func (spec *Specification) removeScenario(scenario *Scenario) {
 	}
 }
 
+// RemoveScenarioByHeading removes the first scenario with the given heading from the spec.
+// If more than one scenario has the same heading, only the first one is removed.
+// Returns true if a scenario was removed.
+func (spec *Specification) RemoveScenarioByHeading(heading string) bool {
+	for i, scenario := range spec.Scenarios {
+		if scenario.Heading == nil || scenario.Heading.Value != heading {
+			continue
+		}
+		spec.Scenarios = append(spec.Scenarios[:i], spec.Scenarios[i+1:]...)
+		for j, item := range spec.Items {
+			if item == Item(scenario) {
+				spec.Items = append(spec.Items[:j], spec.Items[j+1:]...)
+				break
+			}
+		}
+		return true
+	}
+	return false
+}
+
 func (spec *Specification) PopulateConceptLookup(lookup *ArgLookup, conceptArgs []*StepArg, stepArgs []*StepArg) error {
 	for i, arg := range stepArgs {
 		stepArg := StepArg{Value: arg.Value, ArgType: arg.ArgType, Table: arg.Table, Name: arg.Name}
@@ -207,23 +253,28 @@ func (spec *Specification) PopulateConceptLookup(lookup *ArgLookup, conceptArgs
 	return nil
 }
 
-func (spec *Specification) RenameSteps(oldStep Step, newStep Step, orderMap map[int]int) bool {
-	isRefactored := spec.rename(spec.Contexts, oldStep, newStep, false, orderMap)
+// RenameSteps renames the steps of the spec which are the same as oldStep, and returns what was changed.
+func (spec *Specification) RenameSteps(oldStep Step, newStep Step, orderMap map[int]int) []*RenameResult {
+	results := spec.rename(spec.Contexts, oldStep, newStep, orderMap)
 	for _, scenario := range spec.Scenarios {
-		refactor := scenario.renameSteps(oldStep, newStep, orderMap)
-		if refactor {
-			isRefactored = refactor
-		}
+		results = append(results, scenario.renameSteps(oldStep, newStep, orderMap)...)
 	}
-	return spec.rename(spec.TearDownSteps, oldStep, newStep, isRefactored, orderMap)
+	return append(results, spec.rename(spec.TearDownSteps, oldStep, newStep, orderMap)...)
 }
 
-func (spec *Specification) rename(steps []*Step, oldStep Step, newStep Step, isRefactored bool, orderMap map[int]int) bool {
+func (spec *Specification) rename(steps []*Step, oldStep Step, newStep Step, orderMap map[int]int) []*RenameResult {
 	isConcept := false
+	return renameAll(steps, oldStep, newStep, orderMap, &isConcept)
+}
+
+func renameAll(steps []*Step, oldStep Step, newStep Step, orderMap map[int]int, isConcept *bool) []*RenameResult {
+	var results []*RenameResult
 	for _, step := range steps {
-		isRefactored = step.Rename(oldStep, newStep, isRefactored, orderMap, &isConcept)
+		if r := step.Rename(oldStep, newStep, orderMap, isConcept); r.WasRefactored {
+			results = append(results, r)
+		}
 	}
-	return isRefactored
+	return results
 }
 
 func (spec *Specification) GetSpecItems() []Item {
@@ -236,17 +287,29 @@ func (spec *Specification) GetSpecItems() []Item {
 	return specItems
 }
 
+// Traverse passes the spec and every item in the queue to the processor. Context and teardown steps of the spec which
+// are not in the queue, like the ones pulled in by an include directive, are passed to processor.Step as well, at
+// their place among the other contexts or teardown steps.
 func (spec *Specification) Traverse(processor ItemProcessor, queue *ItemQueue) {
 	processor.Specification(spec)
 	processor.Heading(spec.Heading)
 
+	queued := make(map[Item]bool, len(queue.Items))
+	for _, item := range queue.Items {
+		queued[item] = true
+	}
+	contexts := &unqueuedSteps{steps: spec.Contexts, queued: queued}
+	tearDownSteps := &unqueuedSteps{steps: spec.TearDownSteps, queued: queued}
 	for queue.Peek() != nil {
 		item := queue.Next()
 		switch item.Kind() {
 		case ScenarioKind:
+			contexts.processRest(processor)
 			processor.Heading(item.(*Scenario).Heading)
 			processor.Scenario(item.(*Scenario))
 		case StepKind:
+			contexts.processUpTo(processor, item.(*Step))
+			tearDownSteps.processUpTo(processor, item.(*Step))
 			processor.Step(item.(*Step))
 		case CommentKind:
 			processor.Comment(item.(*Comment))
@@ -255,11 +318,79 @@ func (spec *Specification) Traverse(processor ItemProcessor, queue *ItemQueue) {
 		case TagKind:
 			processor.Tags(item.(*Tags))
 		case TearDownKind:
+			contexts.processRest(processor)
 			processor.TearDown(item.(*TearDown))
 		case DataTableKind:
 			processor.DataTable(item.(*DataTable))
 		}
 	}
+	contexts.processRest(processor)
+	tearDownSteps.processRest(processor)
+}
+
+// unqueuedSteps passes the steps which are not in the item queue to the processor in the order of the steps,
+// keeping track of how far into the steps the traversal is.
+type unqueuedSteps struct {
+	steps  []*Step
+	queued map[Item]bool
+	next   int
+}
+
+// processUpTo processes the unqueued steps before the given step, if it is one of the steps.
+func (u *unqueuedSteps) processUpTo(processor ItemProcessor, step *Step) {
+	for i := u.next; i < len(u.steps); i++ {
+		if u.steps[i] == step {
+			u.process(processor, i)
+			u.next = i + 1
+			return
+		}
+	}
+}
+
+func (u *unqueuedSteps) processRest(processor ItemProcessor) {
+	u.process(processor, len(u.steps))
+	u.next = len(u.steps)
+}
+
+func (u *unqueuedSteps) process(processor ItemProcessor, end int) {
+	for _, step := range u.steps[u.next:end] {
+		if !u.queued[step] {
+			processor.Step(step)
+		}
+	}
+}
+
+// TraverseDepthFirst is Traverse over the items of the spec in document order, each scenario followed by its own items.
+func (spec *Specification) TraverseDepthFirst(processor ItemProcessor) {
+	spec.Traverse(processor, &ItemQueue{Items: spec.AllItems()})
+}
+
+// WalkSteps calls visit for every step in the contexts, scenarios and teardown of the spec. Steps of a concept are
+// visited right after the concept with depth increased by one. A concept which uses itself is not expanded again.
+func (spec *Specification) WalkSteps(visit func(step *Step, depth int)) {
+	var steps []*Step
+	steps = append(steps, spec.Contexts...)
+	for _, scenario := range spec.Scenarios {
+		steps = append(steps, scenario.Steps...)
+		steps = append(steps, scenario.TearDownSteps...)
+	}
+	steps = append(steps, spec.TearDownSteps...)
+	expanding := make(map[string]bool)
+	for _, step := range steps {
+		walkStep(step, 0, visit, expanding)
+	}
+}
+
+func walkStep(step *Step, depth int, visit func(step *Step, depth int), expanding map[string]bool) {
+	visit(step, depth)
+	if !step.IsConcept || expanding[step.Value] {
+		return
+	}
+	expanding[step.Value] = true
+	for _, conceptStep := range step.ConceptSteps {
+		walkStep(conceptStep, depth+1, visit, expanding)
+	}
+	delete(expanding, step.Value)
 }
 
 func (spec *Specification) AllItems() (items []Item) {
@@ -289,6 +420,44 @@ func (spec *Specification) Filter(filter SpecItemFilter) {
 	}
 }
 
+// Clone returns a deep copy of the spec. Its scenarios, steps, args and lookups are copies as well, so that the copy
+// can be filtered or refactored without changing the spec. Headings, comments and tags are shared with the spec.
+func (spec *Specification) Clone() *Specification {
+	clone := *spec
+	steps := make(map[*Step]*Step)
+	scenarios := make(map[*Scenario]*Scenario)
+	clone.Contexts = cloneSteps(spec.Contexts, nil, steps)
+	clone.TearDownSteps = cloneSteps(spec.TearDownSteps, nil, steps)
+	clone.Scenarios = make([]*Scenario, 0, len(spec.Scenarios))
+	for _, scenario := range spec.Scenarios {
+		scenarioClone := scenario.clone(steps)
+		scenarios[scenario] = scenarioClone
+		clone.Scenarios = append(clone.Scenarios, scenarioClone)
+	}
+	clone.Items = make([]Item, 0, len(spec.Items))
+	for _, item := range spec.Items {
+		if scenario, ok := item.(*Scenario); ok && scenarios[scenario] != nil {
+			item = scenarios[scenario]
+		}
+		clone.Items = append(clone.Items, clonedItem(item, steps))
+	}
+	clone.Comments = append([]*Comment(nil), spec.Comments...)
+	clone.Includes = append([]string(nil), spec.Includes...)
+	return &clone
+}
+
+// funcFilter adapts an ordinary function to a SpecItemFilter.
+type funcFilter func(Item) bool
+
+func (f funcFilter) Filter(item Item) bool {
+	return f(item)
+}
+
+// FilterFunc removes the items for which fn returns true, like Filter.
+func (spec *Specification) FilterFunc(fn func(Item) bool) {
+	spec.Filter(funcFilter(fn))
+}
+
 func getIndexFor(scenario *Scenario, scenarios []*Scenario) int {
 	for index, anItem := range scenarios {
 		if reflect.DeepEqual(scenario, anItem) {
@@ -302,6 +471,8 @@ type Heading struct {
 	Value       string
 	LineNo      int
 	HeadingType HeadingType
+	StartChar   int
+	EndChar     int
 }
 
 func (heading *Heading) Kind() TokenKind {
@@ -309,8 +480,10 @@ func (heading *Heading) Kind() TokenKind {
 }
 
 type Comment struct {
-	Value  string
-	LineNo int
+	Value     string
+	LineNo    int
+	StartChar int
+	EndChar   int
 }
 
 func (comment *Comment) Kind() TokenKind {
@@ -334,6 +507,22 @@ func (tags *Tags) Add(values []string) {
 	tags.RawValues = append(tags.RawValues, values)
 }
 
+// Remove deletes the first occurrence of the given tag. Returns true if the tag was found.
+func (tags *Tags) Remove(tag string) bool {
+	for i, values := range tags.RawValues {
+		for j, value := range values {
+			if value == tag {
+				tags.RawValues[i] = append(values[:j], values[j+1:]...)
+				if len(tags.RawValues[i]) == 0 {
+					tags.RawValues = append(tags.RawValues[:i], tags.RawValues[i+1:]...)
+				}
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (tags *Tags) Values() (val []string) {
 	for i, _ := range tags.RawValues {
 		val = append(val, tags.RawValues[i]...)
@@ -61,3 +61,287 @@ func (s *MySuite) TestDoesNotUseArgsInTeardown(c *C) {
 
 	c.Assert(spec.UsesArgsInContextTeardown("foo"), Equals, false)
 }
+
+func (s *MySuite) TestSetTagOnSpecification(c *C) {
+	spec := &Specification{}
+
+	spec.SetTag("foo")
+	spec.SetTag("bar")
+
+	c.Assert(spec.Tags.Values(), DeepEquals, []string{"foo", "bar"})
+}
+
+func (s *MySuite) TestRemoveTagFromSpecification(c *C) {
+	spec := &Specification{Tags: &Tags{RawValues: [][]string{{"foo"}, {"bar"}}}}
+
+	spec.RemoveTag("foo")
+
+	c.Assert(spec.Tags.RawValues, DeepEquals, [][]string{{"bar"}})
+}
+
+func (s *MySuite) TestFilterFuncRemovesMatchingItems(c *C) {
+	comment := &Comment{Value: "a comment"}
+	scenario := &Scenario{Heading: &Heading{Value: "scenario"}}
+	spec := &Specification{}
+	spec.AddComment(comment)
+	spec.AddScenario(scenario)
+
+	spec.FilterFunc(func(item Item) bool { return item.Kind() == CommentKind })
+
+	c.Assert(len(spec.Items), Equals, 1)
+	c.Assert(spec.Items[0], Equals, scenario)
+	c.Assert(len(spec.Scenarios), Equals, 1)
+}
+
+func (s *MySuite) TestFilterFuncRemovesScenarios(c *C) {
+	spec := &Specification{}
+	spec.AddScenario(&Scenario{Heading: &Heading{Value: "first"}})
+	spec.AddScenario(&Scenario{Heading: &Heading{Value: "second"}})
+
+	spec.FilterFunc(func(item Item) bool {
+		return item.Kind() == ScenarioKind && item.(*Scenario).Heading.Value == "first"
+	})
+
+	c.Assert(len(spec.Scenarios), Equals, 1)
+	c.Assert(spec.Scenarios[0].Heading.Value, Equals, "second")
+}
+
+func (s *MySuite) TestRemoveScenarioByHeading(c *C) {
+	spec := &Specification{}
+	comment := &Comment{Value: "comment"}
+	spec.AddComment(comment)
+	spec.AddScenario(&Scenario{Heading: &Heading{Value: "first"}})
+	second := &Scenario{Heading: &Heading{Value: "second"}}
+	spec.AddScenario(second)
+
+	removed := spec.RemoveScenarioByHeading("first")
+
+	c.Assert(removed, Equals, true)
+	c.Assert(spec.Scenarios, DeepEquals, []*Scenario{second})
+	c.Assert(spec.Items, DeepEquals, []Item{comment, second})
+}
+
+func (s *MySuite) TestRemoveScenarioByHeadingWhenAbsent(c *C) {
+	spec := &Specification{}
+	spec.AddScenario(&Scenario{Heading: &Heading{Value: "first"}})
+
+	removed := spec.RemoveScenarioByHeading("unknown")
+
+	c.Assert(removed, Equals, false)
+	c.Assert(len(spec.Scenarios), Equals, 1)
+	c.Assert(len(spec.Items), Equals, 1)
+}
+
+func (s *MySuite) TestRemoveScenarioByHeadingRemovesOnlyFirstDuplicate(c *C) {
+	spec := &Specification{}
+	first := &Scenario{Heading: &Heading{Value: "duplicate", LineNo: 1}}
+	second := &Scenario{Heading: &Heading{Value: "duplicate", LineNo: 5}}
+	spec.AddScenario(first)
+	spec.AddScenario(second)
+
+	removed := spec.RemoveScenarioByHeading("duplicate")
+
+	c.Assert(removed, Equals, true)
+	c.Assert(spec.Scenarios, DeepEquals, []*Scenario{second})
+	c.Assert(len(spec.Items), Equals, 1)
+	c.Assert(spec.Items[0].(*Scenario).Heading.LineNo, Equals, 5)
+}
+
+func (s *MySuite) TestRenameStepsReturnsResultForEveryRenamedStep(c *C) {
+	spec := &Specification{
+		Contexts:      []*Step{{Value: "old step", LineNo: 2}},
+		Scenarios:     []*Scenario{{Steps: []*Step{{Value: "old step", LineNo: 5}, {Value: "other step", LineNo: 6}}}},
+		TearDownSteps: []*Step{{Value: "old step", LineNo: 9}},
+	}
+
+	results := spec.RenameSteps(Step{Value: "old step"}, Step{Value: "new step"}, map[int]int{})
+
+	c.Assert(len(results), Equals, 3)
+	var lines []int
+	for _, r := range results {
+		c.Assert(r.OldText, Equals, "old step")
+		c.Assert(r.NewText, Equals, "new step")
+		lines = append(lines, r.LineNo)
+	}
+	c.Assert(lines, DeepEquals, []int{2, 5, 9})
+}
+
+func (s *MySuite) TestWalkStepsVisitsConceptSteps(c *C) {
+	concept := &Step{Value: "concept", IsConcept: true, ConceptSteps: []*Step{{Value: "first"}, {Value: "second"}}}
+	spec := &Specification{Scenarios: []*Scenario{{Steps: []*Step{concept}}}}
+
+	var visited []string
+	var depths []int
+	spec.WalkSteps(func(step *Step, depth int) {
+		visited = append(visited, step.Value)
+		depths = append(depths, depth)
+	})
+
+	c.Assert(visited, DeepEquals, []string{"concept", "first", "second"})
+	c.Assert(depths, DeepEquals, []int{0, 1, 1})
+}
+
+func (s *MySuite) TestWalkStepsVisitsContextsScenariosAndTeardown(c *C) {
+	spec := &Specification{
+		Contexts:      []*Step{{Value: "context"}},
+		Scenarios:     []*Scenario{{Steps: []*Step{{Value: "step"}}, TearDownSteps: []*Step{{Value: "scenario teardown"}}}},
+		TearDownSteps: []*Step{{Value: "teardown"}},
+	}
+
+	var visited []string
+	spec.WalkSteps(func(step *Step, depth int) {
+		visited = append(visited, step.Value)
+	})
+
+	c.Assert(visited, DeepEquals, []string{"context", "step", "scenario teardown", "teardown"})
+}
+
+func (s *MySuite) TestWalkStepsDoesNotExpandCyclicConcepts(c *C) {
+	concept := &Step{Value: "concept", IsConcept: true}
+	concept.ConceptSteps = []*Step{{Value: "step"}, concept}
+	spec := &Specification{Scenarios: []*Scenario{{Steps: []*Step{concept}}}}
+
+	var visited []string
+	var depths []int
+	spec.WalkSteps(func(step *Step, depth int) {
+		visited = append(visited, step.Value)
+		depths = append(depths, depth)
+	})
+
+	c.Assert(visited, DeepEquals, []string{"concept", "step", "concept"})
+	c.Assert(depths, DeepEquals, []int{0, 1, 1})
+}
+
+type recordingProcessor struct {
+	visited []string
+}
+
+func (p *recordingProcessor) Specification(spec *Specification) {
+	p.visited = append(p.visited, "spec")
+}
+func (p *recordingProcessor) Heading(heading *Heading)    { p.visited = append(p.visited, heading.Value) }
+func (p *recordingProcessor) Tags(tags *Tags)             { p.visited = append(p.visited, "tags") }
+func (p *recordingProcessor) Table(table *Table)          { p.visited = append(p.visited, "table") }
+func (p *recordingProcessor) DataTable(table *DataTable)  { p.visited = append(p.visited, "data table") }
+func (p *recordingProcessor) Scenario(scenario *Scenario) { p.visited = append(p.visited, "scenario") }
+func (p *recordingProcessor) Step(step *Step)             { p.visited = append(p.visited, step.Value) }
+func (p *recordingProcessor) TearDown(teardown *TearDown) { p.visited = append(p.visited, "teardown") }
+func (p *recordingProcessor) Comment(comment *Comment)    { p.visited = append(p.visited, comment.Value) }
+
+func (s *MySuite) TestTraverseDepthFirstVisitsItemsInDocumentOrder(c *C) {
+	spec := &Specification{}
+	spec.AddHeading(&Heading{Value: "Spec heading"})
+	spec.AddComment(&Comment{Value: "spec comment"})
+	spec.AddContext(&Step{Value: "context"})
+	scenario := &Scenario{}
+	scenario.AddHeading(&Heading{Value: "Scenario heading"})
+	scenario.AddStep(&Step{Value: "step"})
+	scenario.AddComment(&Comment{Value: "scenario comment"})
+	spec.AddScenario(scenario)
+	spec.AddItem(&TearDown{})
+	teardown := &Step{Value: "teardown step"}
+	spec.TearDownSteps = append(spec.TearDownSteps, teardown)
+	spec.AddItem(teardown)
+
+	processor := &recordingProcessor{}
+	spec.TraverseDepthFirst(processor)
+
+	c.Assert(processor.visited, DeepEquals, []string{"spec", "Spec heading", "spec comment", "context", "Scenario heading", "scenario", "step", "scenario comment", "teardown", "teardown step"})
+}
+
+func (s *MySuite) TestTraverseVisitsContextAndTeardownStepsNotInItems(c *C) {
+	spec := &Specification{}
+	spec.AddHeading(&Heading{Value: "Spec heading"})
+	spec.Contexts = append(spec.Contexts, &Step{Value: "included context", Included: true})
+	spec.AddContext(&Step{Value: "context"})
+	spec.Contexts = append(spec.Contexts, &Step{Value: "another included context", Included: true})
+	scenario := &Scenario{}
+	scenario.AddHeading(&Heading{Value: "Scenario heading"})
+	scenario.AddStep(&Step{Value: "step"})
+	spec.AddScenario(scenario)
+	spec.TearDownSteps = append(spec.TearDownSteps, &Step{Value: "teardown step"})
+
+	processor := &recordingProcessor{}
+	spec.TraverseDepthFirst(processor)
+
+	c.Assert(processor.visited, DeepEquals, []string{"spec", "Spec heading", "included context", "context", "another included context", "Scenario heading", "scenario", "step", "teardown step"})
+}
+
+func (s *MySuite) TestSummary(c *C) {
+	concept := &Step{Value: "concept", IsConcept: true, ConceptSteps: []*Step{{Value: "step in concept"}}}
+	scenario1 := &Scenario{Tags: &Tags{RawValues: [][]string{{"smoke", "login"}}}, Steps: []*Step{{Value: "step"}, concept}}
+	scenario2 := &Scenario{Steps: []*Step{{Value: "another step"}}, TearDownSteps: []*Step{{Value: "scenario teardown"}}}
+	spec := &Specification{
+		Tags:          &Tags{RawValues: [][]string{{"smoke"}}},
+		Contexts:      []*Step{{Value: "context"}, concept},
+		Scenarios:     []*Scenario{scenario1, scenario2},
+		TearDownSteps: []*Step{{Value: "teardown"}, {Value: "another teardown"}},
+	}
+
+	c.Assert(spec.Summary(), Equals, SpecSummary{
+		ScenarioCount:     2,
+		StepCount:         3,
+		TagCount:          2,
+		HasDataTable:      false,
+		ConceptCount:      2,
+		TearDownStepCount: 2,
+	})
+}
+
+func (s *MySuite) TestSummaryOfEmptySpec(c *C) {
+	spec := &Specification{}
+	spec.AddDataTable(&Table{headerIndexMap: map[string]int{"id": 0}})
+
+	summary := spec.Summary()
+
+	c.Assert(summary.ScenarioCount, Equals, 0)
+	c.Assert(summary.HasDataTable, Equals, true)
+}
+
+func (s *MySuite) TestCloneCopiesScenariosStepsAndLookups(c *C) {
+	conceptStep := &Step{Value: "nested step {}", Args: []*StepArg{{Value: "name", ArgType: Dynamic}}}
+	concept := &Step{Value: "concept {}", IsConcept: true, Args: []*StepArg{{Value: "foo", ArgType: Static}}, ConceptSteps: []*Step{conceptStep}}
+	concept.Lookup.AddArgName("name")
+	concept.Lookup.AddArgValue("name", &StepArg{Value: "foo", ArgType: Static})
+	conceptStep.Parent = concept
+	context := &Step{Value: "context"}
+	scenario := &Scenario{Heading: &Heading{Value: "scenario"}}
+	scenario.AddStep(concept)
+	spec := &Specification{}
+	spec.AddContext(context)
+	spec.AddScenario(scenario)
+
+	clone := spec.Clone()
+	clonedConcept := clone.Scenarios[0].Steps[0]
+	clonedConcept.Args[0].Value = "bar"
+	clonedConcept.Lookup.AddArgValue("name", &StepArg{Value: "bar", ArgType: Static})
+	clonedConcept.ConceptSteps[0].Value = "changed"
+	clone.Contexts[0].Value = "changed"
+
+	c.Assert(clone.Scenarios[0], Not(Equals), scenario)
+	c.Assert(clone.Items[1], Equals, clone.Scenarios[0])
+	c.Assert(clone.Scenarios[0].Items[0], Equals, clonedConcept)
+	c.Assert(clonedConcept.ConceptSteps[0].Parent, Equals, clonedConcept)
+	c.Assert(concept.Args[0].Value, Equals, "foo")
+	arg, _ := concept.Lookup.GetArg("name")
+	c.Assert(arg.Value, Equals, "foo")
+	c.Assert(conceptStep.Value, Equals, "nested step {}")
+	c.Assert(context.Value, Equals, "context")
+}
+
+func (s *MySuite) TestFilteringACloneLeavesTheSpecUntouched(c *C) {
+	spec := &Specification{}
+	scenarios := []*Scenario{{Heading: &Heading{Value: "first"}}, {Heading: &Heading{Value: "second"}}, {Heading: &Heading{Value: "third"}}}
+	for _, scenario := range scenarios {
+		spec.AddScenario(scenario)
+	}
+
+	clone := spec.Clone()
+	clone.FilterFunc(func(item Item) bool {
+		return item.Kind() == ScenarioKind && item.(*Scenario).Heading.Value != "third"
+	})
+
+	c.Assert(clone.Scenarios, DeepEquals, []*Scenario{scenarios[2]})
+	c.Assert(spec.Scenarios, DeepEquals, scenarios)
+	c.Assert(len(spec.Items), Equals, 3)
+}
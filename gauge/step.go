@@ -18,6 +18,7 @@
 package gauge
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"strings"
@@ -29,10 +30,14 @@ type StepValue struct {
 	Args                   []string
 	StepValue              string
 	ParameterizedStepValue string
+	// ArgTypes holds the type of each of the Args, in the same order.
+	ArgTypes []ArgType
 }
 
 type Step struct {
 	LineNo         int
+	StartChar      int
+	EndChar        int
 	FileName       string
 	Value          string
 	LineText       string
@@ -46,6 +51,8 @@ type Step struct {
 	Items          []Item
 	PreComments    []*Comment
 	Suffix         string
+	// Included is set for a context step pulled in from another spec by an include directive.
+	Included bool
 }
 
 func (step *Step) GetArg(name string) (*StepArg, error) {
@@ -74,17 +81,65 @@ func (step *Step) GetLineText() string {
 	return step.LineText
 }
 
-func (step *Step) Rename(oldStep Step, newStep Step, isRefactored bool, orderMap map[int]int, isConcept *bool) bool {
+// RenameResult describes the change made to a step by Rename.
+type RenameResult struct {
+	FileName string
+	LineNo   int
+	OldText  string
+	NewText  string
+	// ParameterMapping maps the position of each parameter of the new step to its position in the old step, or -1 if it is new.
+	ParameterMapping map[int]int
+	WasRefactored    bool
+}
+
+func (r *RenameResult) String() string {
+	return fmt.Sprintf("%s:%d %s => %s", r.FileName, r.LineNo, r.OldText, r.NewText)
+}
+
+// Rename changes the step to newStep if it is the same as oldStep, placing its arguments as given by orderMap.
+func (step *Step) Rename(oldStep Step, newStep Step, orderMap map[int]int, isConcept *bool) *RenameResult {
 	if strings.TrimSpace(step.Value) != strings.TrimSpace(oldStep.Value) {
-		return isRefactored
+		return &RenameResult{WasRefactored: false}
 	}
 	if step.IsConcept {
 		*isConcept = true
 	}
+	oldText := step.text()
 	step.Value = newStep.Value
 
 	step.Args = step.getArgsInOrder(newStep, orderMap)
-	return true
+	return &RenameResult{
+		FileName:         step.FileName,
+		LineNo:           step.LineNo,
+		OldText:          oldText,
+		NewText:          step.text(),
+		ParameterMapping: orderMap,
+		WasRefactored:    true,
+	}
+}
+
+// text gives the step as written, with its arguments in place of the parameter placeholders.
+func (step *Step) text() string {
+	parts := strings.Split(step.Value, ParameterPlaceholder)
+	var b bytes.Buffer
+	for i, part := range parts {
+		b.WriteString(part)
+		if i == len(parts)-1 || i >= len(step.Args) {
+			continue
+		}
+		arg := step.Args[i]
+		switch arg.ArgType {
+		case Static:
+			b.WriteString("\"" + arg.Value + "\"")
+		case Dynamic:
+			b.WriteString("<" + arg.Value + ">")
+		case SpecialString, SpecialTable:
+			b.WriteString("<" + arg.Name + ">")
+		default:
+			b.WriteString("<" + string(TableArg) + ">")
+		}
+	}
+	return strings.TrimSpace(b.String())
 }
 
 func (step *Step) UsesDynamicArgs(args ...string) bool {
@@ -211,14 +266,62 @@ func (step *Step) GetCopy() (*Step, error) {
 	copiedConceptStep := new(Step)
 	*copiedConceptStep = *step
 	copiedConceptStep.ConceptSteps = nestedStepsCopy
-	lookupCopy, err := step.Lookup.GetCopy()
-	if err != nil {
-		return nil, err
-	}
-	copiedConceptStep.Lookup = *lookupCopy
+	copiedConceptStep.Lookup = *step.Lookup.Clone()
 	return copiedConceptStep, nil
 }
 
+// clone returns a deep copy of the step, including its args, lookup and concept steps, with the given parent.
+// Unlike GetCopy, every step is copied and not only concepts. The copies are recorded in steps against the originals.
+func (step *Step) clone(parent *Step, steps map[*Step]*Step) *Step {
+	clone := *step
+	if parent != nil {
+		clone.Parent = parent
+	}
+	steps[step] = &clone
+	if step.Args != nil {
+		clone.Args = make([]*StepArg, 0, len(step.Args))
+		for _, arg := range step.Args {
+			clone.Args = append(clone.Args, arg.clone())
+		}
+	}
+	clone.Lookup = *step.Lookup.Clone()
+	clone.ConceptSteps = cloneSteps(step.ConceptSteps, &clone, steps)
+	clone.Items = cloneItems(step.Items, steps)
+	clone.Fragments = append([]*gauge_messages.Fragment(nil), step.Fragments...)
+	clone.PreComments = append([]*Comment(nil), step.PreComments...)
+	return &clone
+}
+
+func cloneSteps(steps []*Step, parent *Step, clones map[*Step]*Step) []*Step {
+	if steps == nil {
+		return nil
+	}
+	stepsCopy := make([]*Step, 0, len(steps))
+	for _, step := range steps {
+		stepsCopy = append(stepsCopy, step.clone(parent, clones))
+	}
+	return stepsCopy
+}
+
+// cloneItems returns a copy of the items in which the steps already cloned are replaced by their copies.
+func cloneItems(items []Item, steps map[*Step]*Step) []Item {
+	if items == nil {
+		return nil
+	}
+	itemsCopy := make([]Item, 0, len(items))
+	for _, item := range items {
+		itemsCopy = append(itemsCopy, clonedItem(item, steps))
+	}
+	return itemsCopy
+}
+
+func clonedItem(item Item, steps map[*Step]*Step) Item {
+	if step, ok := item.(*Step); ok && steps[step] != nil {
+		return steps[step]
+	}
+	return item
+}
+
 func (step *Step) CopyFrom(another *Step) {
 	step.IsConcept = another.IsConcept
 
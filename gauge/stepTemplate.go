@@ -0,0 +1,72 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PlaceholderStyle is the way parameters are rendered by FormatStepTemplate.
+type PlaceholderStyle int
+
+const (
+	// PlainPlaceholder renders every parameter as {}, e.g. "say {} to {}".
+	PlainPlaceholder PlaceholderStyle = iota
+	// NumberedPlaceholder renders parameters by position, e.g. "say {0} to {1}".
+	NumberedPlaceholder
+	// NamedPlaceholder renders parameters by name, e.g. "say <greeting> to <name>".
+	NamedPlaceholder
+)
+
+const tablePlaceholder = "<table>"
+
+// FormatStepTemplate renders the step value with its parameters in the given style.
+// Table parameters, inline or from a file, are rendered as <table> in every style.
+func FormatStepTemplate(sv *StepValue, style PlaceholderStyle) string {
+	parts := strings.Split(sv.StepValue, ParameterPlaceholder)
+	var b bytes.Buffer
+	for i, part := range parts {
+		b.WriteString(part)
+		if i == len(parts)-1 {
+			break
+		}
+		b.WriteString(placeholder(sv, i, style))
+	}
+	return b.String()
+}
+
+func placeholder(sv *StepValue, index int, style PlaceholderStyle) string {
+	if index < len(sv.Args) && isTableParam(sv.Args[index]) {
+		return tablePlaceholder
+	}
+	switch style {
+	case NumberedPlaceholder:
+		return fmt.Sprintf("{%d}", index)
+	case NamedPlaceholder:
+		if index < len(sv.Args) {
+			return "<" + sv.Args[index] + ">"
+		}
+	}
+	return ParameterPlaceholder
+}
+
+func isTableParam(name string) bool {
+	return name == string(TableArg) || strings.HasPrefix(name, string(TableArg)+":")
+}
@@ -0,0 +1,37 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package gauge
+
+import . "gopkg.in/check.v1"
+
+func (s *MySuite) TestFormatStepTemplate(c *C) {
+	sv := &StepValue{Args: []string{"greeting", "name"}, StepValue: "say {} to {}"}
+
+	c.Assert(FormatStepTemplate(sv, PlainPlaceholder), Equals, "say {} to {}")
+	c.Assert(FormatStepTemplate(sv, NumberedPlaceholder), Equals, "say {0} to {1}")
+	c.Assert(FormatStepTemplate(sv, NamedPlaceholder), Equals, "say <greeting> to <name>")
+}
+
+func (s *MySuite) TestFormatStepTemplateWithTableParameter(c *C) {
+	inline := &StepValue{Args: []string{"user", "table"}, StepValue: "create {} with {}"}
+	fromFile := &StepValue{Args: []string{"user", "table:users.csv"}, StepValue: "create {} with {}"}
+
+	c.Assert(FormatStepTemplate(inline, PlainPlaceholder), Equals, "create {} with <table>")
+	c.Assert(FormatStepTemplate(inline, NumberedPlaceholder), Equals, "create {0} with <table>")
+	c.Assert(FormatStepTemplate(fromFile, NamedPlaceholder), Equals, "create <user> with <table>")
+}
@@ -174,14 +174,47 @@ func (s *MySuite) TestRenameStep(c *C) {
 	orderMap[0] = 1
 	orderMap[1] = 0
 	IsConcept := false
-	isRefactored := originalStep.Rename(*originalStep, *newStep, false, orderMap, &IsConcept)
+	result := originalStep.Rename(*originalStep, *newStep, orderMap, &IsConcept)
 
-	c.Assert(isRefactored, Equals, true)
+	c.Assert(result.WasRefactored, Equals, true)
 	c.Assert(originalStep.Value, Equals, "step from {} {}")
 	c.Assert(originalStep.Args[0].Name, Equals, "arg2")
 	c.Assert(originalStep.Args[1].Name, Equals, "arg1")
 }
 
+func (s *MySuite) TestRenameStepReturnsWhatChanged(c *C) {
+	step := &Step{
+		FileName: "foo.spec",
+		LineNo:   3,
+		Value:    "say {} to {}",
+		Args:     []*StepArg{{Value: "hello", ArgType: Static}, {Value: "name", ArgType: Dynamic}},
+	}
+	newStep := Step{Value: "greet {} with {}", Args: []*StepArg{{Value: "name", ArgType: Dynamic}, {Value: "hello", ArgType: Static}}}
+	orderMap := map[int]int{0: 1, 1: 0}
+	isConcept := false
+
+	result := step.Rename(Step{Value: "say {} to {}"}, newStep, orderMap, &isConcept)
+
+	c.Assert(result, DeepEquals, &RenameResult{
+		FileName:         "foo.spec",
+		LineNo:           3,
+		OldText:          `say "hello" to <name>`,
+		NewText:          `greet <name> with "hello"`,
+		ParameterMapping: orderMap,
+		WasRefactored:    true,
+	})
+}
+
+func (s *MySuite) TestRenameStepWhenStepDoesNotMatch(c *C) {
+	step := &Step{Value: "some step"}
+	isConcept := false
+
+	result := step.Rename(Step{Value: "other step"}, Step{Value: "new step"}, map[int]int{}, &isConcept)
+
+	c.Assert(result.WasRefactored, Equals, false)
+	c.Assert(step.Value, Equals, "some step")
+}
+
 func (s *MySuite) TestGetLineTextForStep(c *C) {
 	step := &Step{LineText: "foo"}
 
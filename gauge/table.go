@@ -24,6 +24,9 @@ type Table struct {
 	Columns        [][]TableCell
 	Headers        []string
 	LineNo         int
+	EndLineNo      int
+	StartChar      int
+	EndChar        int
 }
 
 type DataTable struct {
@@ -52,6 +55,26 @@ func NewTable(headers []string, cols [][]TableCell, lineNo int) *Table {
 	}
 }
 
+func (table *Table) clone() Table {
+	tableCopy := Table{LineNo: table.LineNo, EndLineNo: table.EndLineNo, StartChar: table.StartChar, EndChar: table.EndChar}
+	if table.headerIndexMap != nil {
+		tableCopy.headerIndexMap = make(map[string]int, len(table.headerIndexMap))
+		for header, index := range table.headerIndexMap {
+			tableCopy.headerIndexMap[header] = index
+		}
+	}
+	if table.Headers != nil {
+		tableCopy.Headers = append([]string{}, table.Headers...)
+	}
+	if table.Columns != nil {
+		tableCopy.Columns = make([][]TableCell, len(table.Columns))
+		for i, column := range table.Columns {
+			tableCopy.Columns[i] = append([]TableCell{}, column...)
+		}
+	}
+	return tableCopy
+}
+
 func (table *Table) IsInitialized() bool {
 	return table.headerIndexMap != nil
 }
@@ -0,0 +1,48 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/op/go-logging"
+)
+
+type jsonLogEntry struct {
+	Level string `json:"level"`
+	Time  string `json:"time"`
+	Msg   string `json:"msg"`
+	File  string `json:"file,omitempty"`
+	Line  int    `json:"line,omitempty"`
+}
+
+// jsonFormatter formats each log record as a single line of JSON.
+type jsonFormatter struct{}
+
+func (f jsonFormatter) Format(calldepth int, r *logging.Record, w io.Writer) error {
+	entry := jsonLogEntry{Level: r.Level.String(), Time: r.Time.Format(time.RFC3339Nano), Msg: r.Message()}
+	if _, file, line, ok := runtime.Caller(calldepth + 1); ok {
+		entry.File = filepath.Base(file)
+		entry.Line = line
+	}
+	return json.NewEncoder(w).Encode(entry)
+}
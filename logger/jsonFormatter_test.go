@@ -0,0 +1,52 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/op/go-logging"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestJSONFormatter(c *C) {
+	buf := &bytes.Buffer{}
+	backend := logging.NewBackendFormatter(logging.NewLogBackend(buf, "", 0), jsonFormatter{})
+	l := logging.MustGetLogger("json-test")
+	l.SetBackend(logging.AddModuleLevel(backend))
+
+	l.Infof("hello %s", "world")
+
+	var entry jsonLogEntry
+	c.Assert(json.Unmarshal(buf.Bytes(), &entry), IsNil)
+	c.Assert(entry.Level, Equals, "INFO")
+	c.Assert(entry.Msg, Equals, "hello world")
+	c.Assert(entry.File, Equals, "jsonFormatter_test.go")
+	c.Assert(entry.Line > 0, Equals, true)
+	c.Assert(entry.Time, Not(Equals), "")
+}
+
+func (s *MySuite) TestSetLogFormat(c *C) {
+	defer SetLogFormat(TextFormat)
+
+	c.Assert(SetLogFormat("json"), IsNil)
+	c.Assert(logFormat, Equals, JSONFormat)
+	c.Assert(SetLogFormat("xml"), NotNil)
+	c.Assert(logFormat, Equals, JSONFormat)
+}
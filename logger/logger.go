@@ -37,11 +37,16 @@ const (
 	GaugeLogFileName = "gauge.log"
 	apiLogFileName   = "api.log"
 	lspLogFileName   = "lsp.log"
+	// TextFormat writes log messages as plain text
+	TextFormat = "text"
+	// JSONFormat writes each log message as a line of JSON
+	JSONFormat = "json"
 )
 
 var level logging.Level
 var isWindows bool
 var customLogger CustomLogger
+var logFormat = TextFormat
 
 type CustomLogger interface {
 	Log(logLevel logging.Level, msg string)
@@ -101,6 +106,20 @@ var LspLog = logging.MustGetLogger("gauge-lsp")
 
 var fileLogFormat = logging.MustStringFormatter("%{time:15:04:05.000} %{message}")
 
+// SetLogFormat sets the format of the log files, which is either TextFormat or JSONFormat.
+// It takes effect on the next call to Initialize.
+func SetLogFormat(format string) error {
+	switch strings.ToLower(format) {
+	case TextFormat, "":
+		logFormat = TextFormat
+	case JSONFormat:
+		logFormat = JSONFormat
+	default:
+		return fmt.Errorf("Invalid log format %s. Log format should be either %s or %s", format, TextFormat, JSONFormat)
+	}
+	return nil
+}
+
 // Initialize initializes the logger object
 func Initialize(logLevel string) {
 	level = loggingLevel(logLevel)
@@ -115,7 +134,11 @@ func Initialize(logLevel string) {
 func initFileLogger(logFileName string, fileLogger *logging.Logger) {
 	var backend logging.Backend
 	backend = createFileLogger(GetLogFile(logFileName), 10)
-	fileFormatter := logging.NewBackendFormatter(backend, fileLogFormat)
+	var formatter logging.Formatter = fileLogFormat
+	if logFormat == JSONFormat {
+		formatter = jsonFormatter{}
+	}
+	fileFormatter := logging.NewBackendFormatter(backend, formatter)
 	fileLoggerLeveled := logging.AddModuleLevel(fileFormatter)
 	fileLoggerLeveled.SetLevel(logging.DEBUG, "")
 
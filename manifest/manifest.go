@@ -22,7 +22,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/getgauge/common"
@@ -30,12 +32,29 @@ import (
 )
 
 type Manifest struct {
-	Language string
-	Plugins  []string
+	Language       string
+	Plugins        []string
+	PluginVersions map[string]string `json:",omitempty"`
 }
 
 func ProjectManifest() (*Manifest, error) {
-	contents, err := common.ReadFileContents(filepath.Join(config.ProjectRoot, common.ManifestFile))
+	return load(filepath.Join(config.ProjectRoot, common.ManifestFile))
+}
+
+// load reads the manifest at the given path. A temporary file left behind by a save which did not complete is removed
+// if the manifest exists. Otherwise the save was interrupted after the manifest was removed, so it is completed by
+// renaming the temporary file to the manifest.
+func load(path string) (*Manifest, error) {
+	if tmp := tempFile(path); common.FileExists(tmp) {
+		if common.FileExists(path) {
+			if err := os.Remove(tmp); err != nil {
+				return nil, fmt.Errorf("Failed to remove stale manifest file %s. %s\n", tmp, err.Error())
+			}
+		} else if err := os.Rename(tmp, path); err != nil {
+			return nil, fmt.Errorf("Failed to restore manifest from %s. %s\n", tmp, err.Error())
+		}
+	}
+	contents, err := common.ReadFileContents(path)
 	if err != nil {
 		return nil, err
 	}
@@ -53,10 +72,51 @@ func ProjectManifest() (*Manifest, error) {
 	return &m, nil
 }
 
+// VersionConstraint returns the version constraint recorded for the plugin, or an empty string if any installed version is acceptable.
+func (m *Manifest) VersionConstraint(pluginID string) string {
+	return m.PluginVersions[pluginID]
+}
+
+// SetVersionConstraint records the version constraint of the plugin. An empty constraint removes any earlier one.
+func (m *Manifest) SetVersionConstraint(pluginID, constraint string) {
+	if constraint == "" {
+		delete(m.PluginVersions, pluginID)
+		return
+	}
+	if m.PluginVersions == nil {
+		m.PluginVersions = make(map[string]string)
+	}
+	m.PluginVersions[pluginID] = constraint
+}
+
 func (m *Manifest) Save() error {
+	return m.save(common.ManifestFile)
+}
+
+// save writes the manifest to a temporary file first and then renames it over path,
+// so that a crash in the middle of writing never leaves a corrupt manifest behind.
+func (m *Manifest) save(path string) error {
 	b, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(common.ManifestFile, b, common.NewFilePermissions)
+	tmp := tempFile(path)
+	if err := ioutil.WriteFile(tmp, b, common.NewFilePermissions); err != nil {
+		return err
+	}
+	if runtime.GOOS == "windows" && common.FileExists(path) {
+		if err := os.Remove(path); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func tempFile(path string) string {
+	return path + ".tmp"
 }
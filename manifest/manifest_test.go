@@ -0,0 +1,90 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getgauge/common"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct {
+	dir string
+}
+
+var _ = Suite(&MySuite{})
+
+func (s *MySuite) SetUpTest(c *C) {
+	s.dir = c.MkDir()
+}
+
+func (s *MySuite) TestSaveWritesManifestWithoutLeavingTempFile(c *C) {
+	path := filepath.Join(s.dir, common.ManifestFile)
+	m := &Manifest{Language: "java", Plugins: []string{"html-report"}}
+
+	err := m.save(path)
+
+	c.Assert(err, IsNil)
+	c.Assert(common.FileExists(tempFile(path)), Equals, false)
+	saved, err := load(path)
+	c.Assert(err, IsNil)
+	c.Assert(saved.Language, Equals, "java")
+	c.Assert(saved.Plugins, DeepEquals, []string{"html-report"})
+}
+
+func (s *MySuite) TestSaveReplacesExistingManifest(c *C) {
+	path := filepath.Join(s.dir, common.ManifestFile)
+	c.Assert((&Manifest{Language: "java"}).save(path), IsNil)
+
+	err := (&Manifest{Language: "ruby"}).save(path)
+
+	c.Assert(err, IsNil)
+	saved, _ := load(path)
+	c.Assert(saved.Language, Equals, "ruby")
+}
+
+func (s *MySuite) TestLoadRemovesStaleTempFile(c *C) {
+	path := filepath.Join(s.dir, common.ManifestFile)
+	ioutil.WriteFile(path, []byte(`{"Language": "java"}`), common.NewFilePermissions)
+	ioutil.WriteFile(tempFile(path), []byte(`{"Langu`), common.NewFilePermissions)
+
+	m, err := load(path)
+
+	c.Assert(err, IsNil)
+	c.Assert(m.Language, Equals, "java")
+	_, err = os.Stat(tempFile(path))
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+func (s *MySuite) TestLoadCompletesInterruptedSaveWhenOnlyTempFileExists(c *C) {
+	path := filepath.Join(s.dir, common.ManifestFile)
+	ioutil.WriteFile(tempFile(path), []byte(`{"Language": "ruby"}`), common.NewFilePermissions)
+
+	m, err := load(path)
+
+	c.Assert(err, IsNil)
+	c.Assert(m.Language, Equals, "ruby")
+	c.Assert(common.FileExists(path), Equals, true)
+	c.Assert(common.FileExists(tempFile(path)), Equals, false)
+}
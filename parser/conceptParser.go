@@ -19,9 +19,11 @@ package parser
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"strings"
 
-	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/util"
@@ -40,16 +42,49 @@ func (parser *ConceptParser) Parse(text, fileName string) ([]*gauge.Step, *Parse
 	specParser := new(SpecParser)
 	tokens, errs := specParser.GenerateTokens(text, fileName)
 	concepts, res := parser.createConcepts(tokens, fileName)
-	return concepts, &ParseResult{ParseErrors: append(errs, res.ParseErrors...), Warnings: res.Warnings}
+	warnings := append(res.Warnings, unusedParamWarnings(concepts, fileName)...)
+	return concepts, &ParseResult{ParseErrors: append(errs, res.ParseErrors...), Warnings: warnings}
+}
+
+// unusedParamWarnings warns about each parameter of a concept heading which none of the concept's steps use.
+// Such a parameter is usually a typo, e.g. <username> in the heading and <user> in the steps.
+func unusedParamWarnings(concepts []*gauge.Step, fileName string) []*Warning {
+	var warnings []*Warning
+	for _, concept := range concepts {
+		for _, arg := range concept.Args {
+			if !gauge.UsesArgs(concept.ConceptSteps, arg.Value) {
+				warnings = append(warnings, &Warning{
+					FileName: fileName,
+					LineNo:   concept.LineNo,
+					Message:  fmt.Sprintf("Parameter <%s> of concept '%s' is not used in any of its steps", arg.Value, concept.LineText),
+				})
+			}
+		}
+	}
+	return warnings
 }
 
 // Reads file contents from a give file and parses the file.
 func (parser *ConceptParser) ParseFile(file string) ([]*gauge.Step, *ParseResult) {
-	fileText, fileReadErr := common.ReadFileContents(file)
-	if fileReadErr != nil {
-		return nil, &ParseResult{ParseErrors: []ParseError{{Message: fmt.Sprintf("failed to read concept file %s", file)}}}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, conceptReadFailure(file)
+	}
+	defer f.Close()
+	return parser.ParseReader(f, file)
+}
+
+// ParseReader parses the concepts read from r, using fileName in the parse result and errors.
+func (parser *ConceptParser) ParseReader(r io.Reader, fileName string) ([]*gauge.Step, *ParseResult) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, conceptReadFailure(fileName)
 	}
-	return parser.Parse(fileText, file)
+	return parser.Parse(decodeContent(content), fileName)
+}
+
+func conceptReadFailure(file string) *ParseResult {
+	return &ParseResult{ParseErrors: []ParseError{{Message: fmt.Sprintf("failed to read concept file %s", file)}}}
 }
 
 func (parser *ConceptParser) resetState() {
@@ -216,17 +251,15 @@ func (parser *ConceptParser) createConceptLookup(concept *gauge.Step) {
 
 // CreateConceptsDictionary generates a ConceptDictionary which is map of concept text to concept. ConceptDictionary is used to search for a concept.
 func CreateConceptsDictionary() (*gauge.ConceptDictionary, *ParseResult, error) {
-	cptFilesMap := make(map[string]bool, 0)
-	for _, cpt := range util.GetConceptFiles() {
-		cptFilesMap[cpt] = true
-	}
-	var conceptFiles []string
-	for cpt := range cptFilesMap {
-		conceptFiles = append(conceptFiles, cpt)
-	}
+	return CreateConceptsDictionaryWithProgress(nil)
+}
+
+// CreateConceptsDictionaryWithProgress is CreateConceptsDictionary calling onParsed, if not nil, after each concept file is parsed.
+func CreateConceptsDictionaryWithProgress(onParsed func(file string)) (*gauge.ConceptDictionary, *ParseResult, error) {
+	conceptFiles := ConceptFiles()
 	conceptsDictionary := gauge.NewConceptDictionary()
 	res := &ParseResult{Ok: true}
-	if _, errs, e := AddConcepts(conceptFiles, conceptsDictionary); len(errs) > 0 {
+	if _, errs, e := addConcepts(conceptFiles, conceptsDictionary, onParsed); len(errs) > 0 {
 		if e != nil {
 			return nil, nil, e
 		}
@@ -270,8 +303,25 @@ func AddConcept(concepts []*gauge.Step, file string, conceptDictionary *gauge.Co
 	return parseErrors, err
 }
 
+// ConceptFiles returns the concept files of the project without duplicates.
+func ConceptFiles() []string {
+	cptFilesMap := make(map[string]bool, 0)
+	for _, cpt := range util.GetConceptFiles() {
+		cptFilesMap[cpt] = true
+	}
+	var conceptFiles []string
+	for cpt := range cptFilesMap {
+		conceptFiles = append(conceptFiles, cpt)
+	}
+	return conceptFiles
+}
+
 // AddConcepts parses the given concept file and adds each concept to the concept dictionary.
 func AddConcepts(conceptFiles []string, conceptDictionary *gauge.ConceptDictionary) ([]*gauge.Step, []ParseError, error) {
+	return addConcepts(conceptFiles, conceptDictionary, nil)
+}
+
+func addConcepts(conceptFiles []string, conceptDictionary *gauge.ConceptDictionary, onParsed func(file string)) ([]*gauge.Step, []ParseError, error) {
 	var conceptSteps []*gauge.Step
 	var parseResults []*ParseResult
 	for _, conceptFile := range conceptFiles {
@@ -288,6 +338,9 @@ func AddConcepts(conceptFiles []string, conceptDictionary *gauge.ConceptDictiona
 		parseRes.ParseErrors = append(parseRes.ParseErrors, parseErrors...)
 		conceptSteps = append(conceptSteps, concepts...)
 		parseResults = append(parseResults, parseRes)
+		if onParsed != nil {
+			onParsed(conceptFile)
+		}
 	}
 	errs := collectAllParseErrors(parseResults)
 	return conceptSteps, errs, nil
@@ -255,6 +255,32 @@ func (s *MySuite) TestParsingSimpleConcept(c *C) {
 	c.Assert(concept.ConceptSteps[1].Value, Equals, "second step")
 }
 
+func (s *MySuite) TestParsingConceptFromReader(c *C) {
+	parser := new(ConceptParser)
+	concepts, parseRes := parser.ParseReader(strings.NewReader("# my concept <a>\n * first step <a>\n * second step\n"), "foo.cpt")
+
+	c.Assert(len(parseRes.ParseErrors), Equals, 0)
+	c.Assert(len(concepts), Equals, 1)
+	c.Assert(concepts[0].Value, Equals, "my concept {}")
+	c.Assert(concepts[0].FileName, Equals, "foo.cpt")
+	c.Assert(len(concepts[0].ConceptSteps), Equals, 2)
+}
+
+func (s *MySuite) TestParsingConceptFromFailingReader(c *C) {
+	parser := new(ConceptParser)
+	concepts, parseRes := parser.ParseReader(failingReader{}, "foo.cpt")
+
+	c.Assert(concepts, IsNil)
+	c.Assert(parseRes.ParseErrors[0].Message, Equals, "failed to read concept file foo.cpt")
+}
+
+func (s *MySuite) TestParsingMissingConceptFile(c *C) {
+	parser := new(ConceptParser)
+	_, parseRes := parser.ParseFile(filepath.Join("testdata", "missing.cpt"))
+
+	c.Assert(parseRes.ParseErrors[0].Message, Equals, "failed to read concept file "+filepath.Join("testdata", "missing.cpt"))
+}
+
 func (s *MySuite) TestParsingConceptRetainsStepSuffix(c *C) {
 	parser := new(ConceptParser)
 	concepts, parseRes := parser.Parse("# my concept \n * first step \n * second step \n\n", "")
@@ -613,6 +639,14 @@ func (s *MySuite) TestConceptHavingInvalidSpecialParameters(c *C) {
 	c.Assert(parseRes.ParseErrors[0].Message, Equals, "Dynamic parameter <table:name> could not be resolved")
 }
 
+func (s *MySuite) TestConceptStepHavingSpecialParameterWithMissingFile(c *C) {
+	conceptText := SpecBuilder().
+		specHeading("create user <id>").
+		step("a step <file:missing.txt>").String()
+	_, parseRes := new(ConceptParser).Parse(conceptText, "")
+	c.Assert(parseRes.ParseErrors[0].Message, Equals, "Special parameter <file:missing.txt> could not be resolved. File missing.txt doesn't exist.")
+}
+
 func (s *MySuite) TestConceptHavingStaticParameters(c *C) {
 	conceptText := SpecBuilder().
 		specHeading("create user <user:id> \"abc\" and <file>").
@@ -728,3 +762,23 @@ func containsAny(errs []ParseError, msg string) bool {
 	}
 	return false
 }
+
+func (s *MySuite) TestConceptParserWarnsAboutUnusedParams(c *C) {
+	parser := new(ConceptParser)
+
+	concepts, res := parser.Parse("# login as <username> with <password>\n* enter \"admin\"\n* type <password>\n", "login.cpt")
+
+	c.Assert(len(res.ParseErrors), Equals, 0)
+	c.Assert(len(concepts), Equals, 1)
+	c.Assert(len(res.Warnings), Equals, 1)
+	c.Assert(res.Warnings[0].String(), Equals, "login.cpt:1 Parameter <username> of concept 'login as <username> with <password>' is not used in any of its steps")
+}
+
+func (s *MySuite) TestConceptParserDoesNotWarnAboutParamsUsedInTables(c *C) {
+	parser := new(ConceptParser)
+
+	_, res := parser.Parse("# create <user>\n* create users\n   |name  |\n   |------|\n   |<user>|\n", "users.cpt")
+
+	c.Assert(len(res.ParseErrors), Equals, 0)
+	c.Assert(len(res.Warnings), Equals, 0)
+}
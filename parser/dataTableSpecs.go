@@ -43,6 +43,64 @@ func GetSpecsForDataTableRows(s []*gauge.Specification, errMap *gauge.BuildError
 			specs = append(specs, spec)
 		}
 	}
+	for i, spec := range specs {
+		specs[i] = expandScenarioDataTables(spec, errMap)
+	}
+	return
+}
+
+// expandScenarioDataTables replaces each scenario having a data table of its own with a copy of it for each row of the table.
+func expandScenarioDataTables(spec *gauge.Specification, errMap *gauge.BuildErrors) *gauge.Specification {
+	expandedScenarios := make(map[*gauge.Scenario][]*gauge.Scenario)
+	for _, scn := range spec.Scenarios {
+		if scn.HasDataTable() {
+			expandedScenarios[scn] = copyScenarioForTableRows(scn, errMap)
+		}
+	}
+	if len(expandedScenarios) == 0 {
+		return spec
+	}
+	expanded := *spec
+	expanded.Scenarios = nil
+	expanded.Items = nil
+	for _, scn := range spec.Scenarios {
+		if scns, ok := expandedScenarios[scn]; ok {
+			expanded.Scenarios = append(expanded.Scenarios, scns...)
+		} else {
+			expanded.Scenarios = append(expanded.Scenarios, scn)
+		}
+	}
+	for _, item := range spec.Items {
+		if scn, isScenario := item.(*gauge.Scenario); isScenario && expandedScenarios[scn] != nil {
+			for _, scn := range expandedScenarios[scn] {
+				expanded.Items = append(expanded.Items, scn)
+			}
+		} else {
+			expanded.Items = append(expanded.Items, item)
+		}
+	}
+	if len(errMap.SpecErrs[spec]) > 0 {
+		errMap.SpecErrs[&expanded] = errMap.SpecErrs[spec]
+	}
+	return &expanded
+}
+
+func copyScenarioForTableRows(scn *gauge.Scenario, errMap *gauge.BuildErrors) (scns []*gauge.Scenario) {
+	for i := range scn.DataTable.Table.Rows() {
+		newScn := *scn
+		newScn.DataTable.Table = *getTableWithOneRow(scn.DataTable.Table, i)
+		newScn.Items = nil
+		for _, item := range scn.Items {
+			if item.Kind() == gauge.DataTableKind {
+				item = &newScn.DataTable
+			}
+			newScn.Items = append(newScn.Items, item)
+		}
+		if len(errMap.ScenarioErrs[scn]) > 0 {
+			errMap.ScenarioErrs[&newScn] = errMap.ScenarioErrs[scn]
+		}
+		scns = append(scns, &newScn)
+	}
 	return
 }
 
@@ -88,6 +146,7 @@ func copyScenarios(scenarios []*gauge.Scenario, table gauge.Table, i int, errMap
 			Heading:           scn.Heading,
 			DataTableRow:      table,
 			DataTableRowIndex: i,
+			DataTable:         scn.DataTable,
 			Tags:              scn.Tags,
 			Comments:          scn.Comments,
 			Span:              scn.Span,
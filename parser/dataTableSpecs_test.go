@@ -193,3 +193,45 @@ func TestCreateSpecsForTableRows(t *testing.T) {
 		t.Errorf("Failed: Create specs for table row.\n\tWanted: %v\n\tGot: %v", string(wantJson), string(gotJson))
 	}
 }
+
+func TestGetSpecsForDataTableRowsExpandsScenarioDataTables(t *testing.T) {
+	step := &gauge.Step{Args: []*gauge.StepArg{{Value: "header", ArgType: gauge.Dynamic, Name: "header"}}}
+	scn1 := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario 1"}, Steps: []*gauge.Step{step}}
+	scn1.AddDataTable(gauge.NewTable([]string{"header"}, [][]gauge.TableCell{
+		{{Value: "row1", CellType: gauge.Static}, {Value: "row2", CellType: gauge.Static}},
+	}, 0))
+	scn2 := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario 2"}, Steps: []*gauge.Step{step}}
+	scn2.AddDataTable(gauge.NewTable([]string{"header"}, [][]gauge.TableCell{
+		{{Value: "a", CellType: gauge.Static}, {Value: "b", CellType: gauge.Static}, {Value: "c", CellType: gauge.Static}},
+	}, 0))
+	scn3 := &gauge.Scenario{Heading: &gauge.Heading{Value: "scenario 3"}}
+	spec := &gauge.Specification{Heading: &gauge.Heading{}, Scenarios: []*gauge.Scenario{scn1, scn2, scn3}, Items: []gauge.Item{scn1, scn2, scn3}}
+
+	got := GetSpecsForDataTableRows([]*gauge.Specification{spec}, gauge.NewBuildErrors())
+
+	if len(got) != 1 {
+		t.Fatalf("Failed: Wanted: 1 spec, Got: %d specs", len(got))
+	}
+	var rows []string
+	for _, scn := range got[0].Scenarios {
+		if !scn.HasDataTable() {
+			rows = append(rows, scn.Heading.Value)
+			continue
+		}
+		if scn.DataTable.Table.GetRowCount() != 1 {
+			t.Errorf("Failed: Wanted: 1 row in the data table of %s, Got: %d", scn.Heading.Value, scn.DataTable.Table.GetRowCount())
+		}
+		cells, _ := scn.DataTable.Table.Get("header")
+		rows = append(rows, scn.Heading.Value+":"+cells[0].Value)
+	}
+	want := []string{"scenario 1:row1", "scenario 1:row2", "scenario 2:a", "scenario 2:b", "scenario 2:c", "scenario 3"}
+	if !reflect.DeepEqual(want, rows) {
+		t.Errorf("Failed: Expand scenario data tables. Wanted: %v, Got: %v", want, rows)
+	}
+	if len(got[0].Items) != len(want) {
+		t.Errorf("Failed: Wanted: %d items, Got: %d items", len(want), len(got[0].Items))
+	}
+	if len(spec.Scenarios) != 3 {
+		t.Errorf("Failed: Original spec should not be modified. Got: %d scenarios", len(spec.Scenarios))
+	}
+}
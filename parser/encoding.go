@@ -0,0 +1,51 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"bytes"
+	"unicode/utf16"
+)
+
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+// decodeContent returns the content of a spec or concept file as UTF-8 text. A byte order mark is removed, and
+// content starting with a UTF-16 byte order mark, as saved by some Windows editors, is converted to UTF-8.
+func decodeContent(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return string(content[len(utf8BOM):])
+	case bytes.HasPrefix(content, utf16LEBOM):
+		return decodeUTF16(content[len(utf16LEBOM):], func(b []byte) uint16 { return uint16(b[0]) | uint16(b[1])<<8 })
+	case bytes.HasPrefix(content, utf16BEBOM):
+		return decodeUTF16(content[len(utf16BEBOM):], func(b []byte) uint16 { return uint16(b[0])<<8 | uint16(b[1]) })
+	}
+	return string(content)
+}
+
+func decodeUTF16(content []byte, codeUnit func([]byte) uint16) string {
+	units := make([]uint16, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		units = append(units, codeUnit(content[i:i+2]))
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,59 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestDecodeContent(c *C) {
+	c.Assert(decodeContent([]byte("\xef\xbb\xbf# Spec")), Equals, "# Spec")
+	c.Assert(decodeContent([]byte("\xff\xfe#\x00 \x00S\x00\xe9\x00")), Equals, "# Sé")
+	c.Assert(decodeContent([]byte("\xfe\xff\x00#\x00 \x00S\x00\xe9")), Equals, "# Sé")
+	c.Assert(decodeContent([]byte("# Spec")), Equals, "# Spec")
+}
+
+func (s *MySuite) TestParseSpecWithByteOrderMark(c *C) {
+	for _, file := range []string{"utf8_bom.spec", "utf16le.spec", "utf16be.spec"} {
+		f, err := os.Open(filepath.Join("testdata", "encoding", file))
+		c.Assert(err, IsNil)
+
+		spec, res, err := ParseSpecFromReader(f, file, gauge.NewConceptDictionary())
+		f.Close()
+
+		c.Assert(err, IsNil)
+		c.Assert(res.Ok, Equals, true, Commentf("%s: %v", file, res.ParseErrors))
+		c.Assert(spec.Heading.Value, Equals, "Spécification", Commentf(file))
+		c.Assert(len(spec.Scenarios), Equals, 1, Commentf(file))
+		c.Assert(spec.Scenarios[0].Steps[0].Value, Equals, "say {}", Commentf(file))
+		c.Assert(spec.Scenarios[0].Steps[0].Args[0].Value, Equals, "héllo", Commentf(file))
+	}
+}
+
+func (s *MySuite) TestParseUTF16ConceptFile(c *C) {
+	steps, res := new(ConceptParser).ParseFile(filepath.Join("testdata", "encoding", "utf16le.cpt"))
+
+	c.Assert(len(res.ParseErrors), Equals, 0, Commentf("%v", res.ParseErrors))
+	c.Assert(len(steps), Equals, 1)
+	c.Assert(steps[0].Value, Equals, "say hello")
+	c.Assert(steps[0].ConceptSteps[0].Args[0].Value, Equals, "héllo")
+}
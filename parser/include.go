@@ -0,0 +1,71 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/gauge"
+	"github.com/getgauge/gauge/util"
+)
+
+const includeDirective = "include:"
+
+// includeContexts adds the context steps of the spec referred by an include directive to the given spec.
+// The path of the included spec is relative to the directory of the spec including it and cannot point outside of it.
+func includeContexts(spec *gauge.Specification, token *Token) ParseResult {
+	includeErr := func(message string) ParseResult {
+		return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, message, token.LineText}}}
+	}
+	specDir := filepath.Dir(spec.FileName)
+	includedFile := filepath.Join(specDir, token.Value)
+	if filepath.IsAbs(token.Value) || !util.IsInsideSpecDir([]string{specDir}, includedFile) {
+		return includeErr(fmt.Sprintf("Could not include %s: only specs in the directory of the spec or its sub directories can be included", token.Value))
+	}
+	for _, file := range spec.Includes {
+		if file == includedFile {
+			return includeErr(fmt.Sprintf("%s is already included", token.Value))
+		}
+	}
+	content, err := common.ReadFileContents(includedFile)
+	if err != nil {
+		return includeErr(fmt.Sprintf("Could not include %s: %s", token.Value, err.Error()))
+	}
+	parser := new(SpecParser)
+	tokens, errs := parser.GenerateTokens(content, includedFile)
+	if len(errs) > 0 {
+		return ParseResult{Ok: false, ParseErrors: errs}
+	}
+	for _, t := range tokens {
+		if t.Kind == gauge.IncludeKind {
+			return includeErr(fmt.Sprintf("%s has an include directive, included specs cannot include other specs", token.Value))
+		}
+	}
+	included, res := parser.createSpecification(tokens, includedFile)
+	if !res.Ok && len(res.ParseErrors) > 0 {
+		return *res
+	}
+	spec.Includes = append(spec.Includes, includedFile)
+	for _, step := range included.Contexts {
+		step.Included = true
+		spec.Contexts = append(spec.Contexts, step)
+	}
+	return ParseResult{Ok: true}
+}
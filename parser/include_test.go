@@ -0,0 +1,148 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/getgauge/gauge/gauge"
+	. "gopkg.in/check.v1"
+)
+
+func writeSpecIn(c *C, dir, fileName, content string) string {
+	file := filepath.Join(dir, fileName)
+	c.Assert(ioutil.WriteFile(file, []byte(content), 0644), IsNil)
+	return file
+}
+
+func (s *MySuite) TestIncludeAddsContextsOfIncludedSpec(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeInclude")
+	defer os.RemoveAll(dir)
+	shared := writeSpecIn(c, dir, "shared.spec", `# Shared
+* login as "admin"
+* open dashboard
+`)
+	specText := `# Spec
+## include: shared.spec
+* spec context
+
+## Scenario
+* step
+`
+
+	spec, res, err := ParseSpecFromString(specText, filepath.Join(dir, "main.spec"), gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(len(spec.Scenarios), Equals, 1)
+	c.Assert(spec.Includes, DeepEquals, []string{shared})
+	c.Assert(len(spec.Contexts), Equals, 3)
+	c.Assert(spec.Contexts[0].Value, Equals, "login as {}")
+	c.Assert(spec.Contexts[1].Value, Equals, "open dashboard")
+	c.Assert(spec.Contexts[2].Value, Equals, "spec context")
+	c.Assert(spec.Contexts[0].Included, Equals, true)
+	c.Assert(spec.Contexts[2].Included, Equals, false)
+	c.Assert(spec.Items[0].(*gauge.Comment).Value, Equals, "## include: shared.spec")
+}
+
+func (s *MySuite) TestIncludedSpecCannotIncludeOtherSpecs(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeInclude")
+	defer os.RemoveAll(dir)
+	writeSpecIn(c, dir, "shared.spec", `# Shared
+## include: other.spec
+* login as "admin"
+`)
+	specText := `# Spec
+## include: shared.spec
+
+## Scenario
+* step
+`
+
+	spec, res, err := ParseSpecFromString(specText, filepath.Join(dir, "main.spec"), gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(res.ParseErrors[0].Message, Equals, "shared.spec has an include directive, included specs cannot include other specs")
+	c.Assert(res.ParseErrors[0].LineNo, Equals, 2)
+	c.Assert(len(spec.Contexts), Equals, 0)
+	c.Assert(len(spec.Includes), Equals, 0)
+}
+
+func (s *MySuite) TestIncludeOfMissingSpec(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeInclude")
+	defer os.RemoveAll(dir)
+	specText := `# Spec
+## include: missing.spec
+
+## Scenario
+* step
+`
+
+	_, res, err := ParseSpecFromString(specText, filepath.Join(dir, "main.spec"), gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(res.ParseErrors[0].LineNo, Equals, 2)
+}
+
+func (s *MySuite) TestIncludeOfSpecOutsideTheSpecDirectory(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeInclude")
+	defer os.RemoveAll(dir)
+	outside := writeSpecIn(c, dir, "outside.spec", `# Outside
+* login as "admin"
+`)
+	specsDir := filepath.Join(dir, "specs")
+	c.Assert(os.Mkdir(specsDir, 0755), IsNil)
+
+	for _, include := range []string{"../outside.spec", "nested/../../outside.spec", outside} {
+		specText := "# Spec\n## include: " + include + "\n\n## Scenario\n* step\n"
+
+		spec, res, err := ParseSpecFromString(specText, filepath.Join(specsDir, "main.spec"), gauge.NewConceptDictionary())
+
+		c.Assert(err, IsNil)
+		c.Assert(res.Ok, Equals, false)
+		c.Assert(res.ParseErrors[0].Message, Equals, "Could not include "+include+": only specs in the directory of the spec or its sub directories can be included")
+		c.Assert(len(spec.Contexts), Equals, 0)
+	}
+}
+
+func (s *MySuite) TestIncludeAfterScenarioIsAnError(c *C) {
+	tokens := []*Token{
+		&Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 2},
+		&Token{Kind: gauge.IncludeKind, Value: "shared.spec", LineNo: 3, LineText: "## include: shared.spec"},
+		&Token{Kind: gauge.StepKind, Value: "Step", LineNo: 4},
+	}
+
+	_, res := new(SpecParser).createSpecification(tokens, "foo.spec")
+
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(res.ParseErrors[0].Message, Equals, "Include should be defined after the spec heading and before the first scenario")
+}
+
+func (s *MySuite) TestIncludeDirectiveToken(c *C) {
+	tokens, errs := new(SpecParser).GenerateTokens("# Spec\n##  Include:  shared.spec \n## Scenario\n", "foo.spec")
+
+	c.Assert(len(errs), Equals, 0)
+	c.Assert(tokens[1].Kind, Equals, gauge.IncludeKind)
+	c.Assert(tokens[1].Value, Equals, "shared.spec")
+	c.Assert(tokens[2].Kind, Equals, gauge.ScenarioKind)
+}
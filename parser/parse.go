@@ -38,6 +38,10 @@
 package parser
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"strings"
 
 	"regexp"
@@ -55,6 +59,12 @@ import (
 // ParseSpecFiles - gets all the spec files and parse each spec file.
 // Generates specifications and parse results.
 func ParseSpecFiles(specFiles []string, conceptDictionary *gauge.ConceptDictionary, buildErrors *gauge.BuildErrors) ([]*gauge.Specification, []*ParseResult) {
+	return ParseSpecFilesWithProgress(specFiles, conceptDictionary, buildErrors, nil)
+}
+
+// ParseSpecFilesWithProgress is ParseSpecFiles calling onParsed, if not nil, as the result of each spec file is collected.
+// The spec files are parsed in parallel, but onParsed is only called from the calling goroutine, one file at a time.
+func ParseSpecFilesWithProgress(specFiles []string, conceptDictionary *gauge.ConceptDictionary, buildErrors *gauge.BuildErrors, onParsed func(file string)) ([]*gauge.Specification, []*ParseResult) {
 	parseResultsChan := make(chan *ParseResult, len(specFiles))
 	specsChan := make(chan *gauge.Specification, len(specFiles))
 	var parseResults []*ParseResult
@@ -77,6 +87,9 @@ func ParseSpecFiles(specFiles []string, conceptDictionary *gauge.ConceptDictiona
 			}
 		}
 		parseResults = append(parseResults, parseRes)
+		if onParsed != nil {
+			onParsed(parseRes.FileName)
+		}
 	}
 	return specs, parseResults
 }
@@ -99,23 +112,49 @@ func ParseConcepts() (*gauge.ConceptDictionary, *ParseResult, error) {
 }
 
 func parseSpec(specFile string, conceptDictionary *gauge.ConceptDictionary, specChannel chan *gauge.Specification, parseResultChan chan *ParseResult) {
-	specFileContent, err := common.ReadFileContents(specFile)
+	if !common.FileExists(specFile) {
+		specChannel <- nil
+		parseResultChan <- &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFile, Message: fmt.Sprintf("File %s doesn't exist.", specFile)}}, Ok: false}
+		return
+	}
+	f, err := os.Open(specFile)
 	if err != nil {
 		specChannel <- nil
 		parseResultChan <- &ParseResult{ParseErrors: []ParseError{ParseError{FileName: specFile, Message: err.Error()}}, Ok: false}
 		return
 	}
-	spec, parseResult, err := new(SpecParser).Parse(specFileContent, conceptDictionary, specFile)
+	defer f.Close()
+	spec, parseResult, err := ParseSpecFromReader(f, specFile, conceptDictionary)
 	if err != nil {
-		logger.Fatalf(err.Error())
+		logger.Fatalf("%s", err.Error())
 	}
 	specChannel <- spec
 	parseResultChan <- parseResult
 }
 
+// ParseSpecFromReader parses the spec read from r, using filename in the parse result and errors.
+// A failure to read is reported as a parse error, the returned error is only for critical errors like circular concepts.
+func ParseSpecFromReader(r io.Reader, filename string, conceptDictionary *gauge.ConceptDictionary) (*gauge.Specification, *ParseResult, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: filename, Message: err.Error()}}, Ok: false}, nil
+	}
+	return new(SpecParser).Parse(decodeContent(content), conceptDictionary, filename)
+}
+
+// ParseSpecFromString parses spec content held in memory, like an unsaved editor buffer, using filename in the parse result and errors.
+func ParseSpecFromString(content, filename string, conceptDictionary *gauge.ConceptDictionary) (*gauge.Specification, *ParseResult, error) {
+	return ParseSpecFromReader(strings.NewReader(content), filename, conceptDictionary)
+}
+
 type specFile struct {
-	filePath string
-	indices  []int
+	filePath   string
+	indices    []int
+	lineRanges []filter.LineRange
+}
+
+func (f *specFile) isFiltered() bool {
+	return len(f.indices) > 0 || len(f.lineRanges) > 0
 }
 
 // parseSpecsInDirs parses all the specs in list of dirs given.
@@ -131,8 +170,8 @@ func parseSpecsInDirs(conceptDictionary *gauge.ConceptDictionary, specDirs []str
 	for _, spec := range specs {
 		i, _ := getIndexFor(specFiles, spec.FileName)
 		specFile := specFiles[i]
-		if len(specFile.indices) > 0 {
-			spec.Filter(filter.NewScenarioFilterBasedOnSpan(specFile.indices))
+		if specFile.isFiltered() {
+			spec.Filter(filter.NewScenarioFilterBasedOnLines(specFile.indices, specFile.lineRanges))
 		}
 		allSpecs[i] = spec
 	}
@@ -142,15 +181,18 @@ func parseSpecsInDirs(conceptDictionary *gauge.ConceptDictionary, specDirs []str
 func getAllSpecFiles(specDirs []string) (givenSpecs []string, specFiles []*specFile) {
 	for _, specSource := range specDirs {
 		if isIndexedSpec(specSource) {
-			var specName string
-			specName, index := getIndexedSpecName(specSource)
+			specName, lineRange := getLineRangeOfSpec(specSource)
 			files := util.GetSpecFiles(specName)
 			if len(files) < 1 {
 				continue
 			}
 			specificationFile, created := addSpecFile(&specFiles, files[0])
-			if created || len(specificationFile.indices) > 0 {
-				specificationFile.indices = append(specificationFile.indices, index)
+			if created || specificationFile.isFiltered() {
+				if lineRange.Start == lineRange.End {
+					specificationFile.indices = append(specificationFile.indices, lineRange.Start)
+				} else {
+					specificationFile.lineRanges = append(specificationFile.lineRanges, lineRange)
+				}
 			}
 			givenSpecs = append(givenSpecs, files[0])
 		} else {
@@ -158,6 +200,7 @@ func getAllSpecFiles(specDirs []string) (givenSpecs []string, specFiles []*specF
 			for _, file := range files {
 				specificationFile, _ := addSpecFile(&specFiles, file)
 				specificationFile.indices = specificationFile.indices[0:0]
+				specificationFile.lineRanges = nil
 			}
 			givenSpecs = append(givenSpecs, files...)
 		}
@@ -188,16 +231,25 @@ func isIndexedSpec(specSource string) bool {
 	return getIndex(specSource) != 0
 }
 
-func getIndexedSpecName(indexedSpec string) (string, int) {
+// getLineRangeOfSpec splits a spec source like specs/foo.spec:10-20 into the spec and the range of lines.
+// A single line number, as in specs/foo.spec:15, gives a range of just that line.
+func getLineRangeOfSpec(indexedSpec string) (string, filter.LineRange) {
 	index := getIndex(indexedSpec)
 	specName := indexedSpec[:index]
-	scenarioNum := indexedSpec[index+1:]
-	scenarioNumber, _ := strconv.Atoi(scenarioNum)
-	return specName, scenarioNumber
+	lines := strings.SplitN(indexedSpec[index+1:], "-", 2)
+	start, _ := strconv.Atoi(lines[0])
+	end := start
+	if len(lines) == 2 {
+		end, _ = strconv.Atoi(lines[1])
+	}
+	if end < start {
+		start, end = end, start
+	}
+	return specName, filter.LineRange{Start: start, End: end}
 }
 
 func getIndex(specSource string) int {
-	re, _ := regexp.Compile(":[0-9]+$")
+	re, _ := regexp.Compile(":[0-9]+(-[0-9]+)?$")
 	index := re.FindStringSubmatchIndex(specSource)
 	if index != nil {
 		return index[0]
@@ -211,14 +263,19 @@ func ExtractStepValueAndParams(stepText string, hasInlineTable bool) (*gauge.Ste
 		return nil, err
 	}
 
-	extractedStepValue, _ := extractStepValueAndParameterTypes(stepValueWithPlaceHolders)
+	extractedStepValue, argTypes := extractStepValueAndParameterTypes(stepValueWithPlaceHolders)
+	var types []gauge.ArgType
+	for i, argType := range argTypes {
+		types = append(types, stepArgType(argType, args[i]))
+	}
 	if hasInlineTable {
 		extractedStepValue += " " + gauge.ParameterPlaceholder
 		args = append(args, string(gauge.TableArg))
+		types = append(types, gauge.TableArg)
 	}
 	parameterizedStepValue := getParameterizeStepValue(extractedStepValue, args)
 
-	return &gauge.StepValue{args, extractedStepValue, parameterizedStepValue}, nil
+	return &gauge.StepValue{Args: args, StepValue: extractedStepValue, ParameterizedStepValue: parameterizedStepValue, ArgTypes: types}, nil
 
 }
 
@@ -227,6 +284,7 @@ func CreateStepValue(step *gauge.Step) gauge.StepValue {
 	args := make([]string, 0)
 	for _, arg := range step.Args {
 		args = append(args, arg.ArgValue())
+		stepValue.ArgTypes = append(stepValue.ArgTypes, arg.ArgType)
 	}
 	stepValue.Args = args
 	stepValue.ParameterizedStepValue = getParameterizeStepValue(stepValue.StepValue, args)
@@ -18,10 +18,12 @@
 package parser
 
 import (
+	"errors"
 	"path/filepath"
 
 	"strings"
 
+	"github.com/getgauge/gauge/filter"
 	"github.com/getgauge/gauge/gauge"
 	. "gopkg.in/check.v1"
 )
@@ -124,6 +126,21 @@ func (s *MySuite) TestCreateStepValueFromStepWithSpecialParams(c *C) {
 	c.Assert(stepValue.ParameterizedStepValue, Equals, "a step with <hello>, <file:user.txt> and <table>")
 }
 
+func (s *MySuite) TestStepValueExtractionKeepsTheTypeOfEachParam(c *C) {
+	stepText := "a \"static\" step with <dynamic>, <file:data.txt>, <table:data.csv> and <unknown:foo>"
+	stepValue, err := ExtractStepValueAndParams(stepText, true)
+
+	c.Assert(err, Equals, nil)
+	c.Assert(stepValue.ArgTypes, DeepEquals, []gauge.ArgType{gauge.Static, gauge.Dynamic, gauge.SpecialString, gauge.SpecialTable, gauge.Dynamic, gauge.TableArg})
+}
+
+func (s *MySuite) TestCreateStepValueKeepsTheTypeOfEachParam(c *C) {
+	step := &gauge.Step{Value: "a step with {}, {}, {}, {} and {}", Args: []*gauge.StepArg{staticArg("hello"), dynamicArg("desc"), specialStringArg("file:user.txt"), specialTableArg("table:users.csv"), tableArgument()}}
+	stepValue := CreateStepValue(step)
+
+	c.Assert(stepValue.ArgTypes, DeepEquals, []gauge.ArgType{gauge.Static, gauge.Dynamic, gauge.SpecialString, gauge.SpecialTable, gauge.TableArg})
+}
+
 func (s *MySuite) TestSpecsFromArgsForMultipleIndexedArgsForOneSpec(c *C) {
 	specs, _ := parseSpecsInDirs(gauge.NewConceptDictionary(), []string{filepath.Join("testdata", "sample.spec:3"), filepath.Join("testdata", "sample.spec:6")}, gauge.NewBuildErrors())
 
@@ -191,6 +208,28 @@ func (s *MySuite) TestGetAllSpecsAddIndicesForIndexedSpecs(c *C) {
 	c.Assert(indexedSpecs[0].indices[1], Equals, 5)
 }
 
+func (s *MySuite) TestGetAllSpecsAddLineRangesForSpecsWithARange(c *C) {
+	file := filepath.Join("testdata", "sample.spec")
+	_, indexedSpecs := getAllSpecFiles([]string{file + ":3", file + ":10-20"})
+
+	c.Assert(len(indexedSpecs), Equals, 1)
+	c.Assert(indexedSpecs[0].indices, DeepEquals, []int{3})
+	c.Assert(indexedSpecs[0].lineRanges, DeepEquals, []filter.LineRange{{Start: 10, End: 20}})
+}
+
+func (s *MySuite) TestGetLineRangeOfSpec(c *C) {
+	specName, lineRange := getLineRangeOfSpec("specs/hello_world.spec:10-20")
+	c.Assert(specName, Equals, "specs/hello_world.spec")
+	c.Assert(lineRange, Equals, filter.LineRange{Start: 10, End: 20})
+
+	specName, lineRange = getLineRangeOfSpec("specs/hello_world.spec:15")
+	c.Assert(specName, Equals, "specs/hello_world.spec")
+	c.Assert(lineRange, Equals, filter.LineRange{Start: 15, End: 15})
+
+	_, lineRange = getLineRangeOfSpec("specs/hello_world.spec:20-10")
+	c.Assert(lineRange, Equals, filter.LineRange{Start: 10, End: 20})
+}
+
 func (s *MySuite) TestGetAllSpecsShouldDeDuplicateSpecs(c *C) {
 	sampleSpec := filepath.Join("testdata", "sample.spec")
 	sample2Spec := filepath.Join("testdata", "sample2.spec")
@@ -241,17 +280,8 @@ func (s *MySuite) TestToCheckIfItsIndexedSpec(c *C) {
 	c.Assert(isIndexedSpec("specs/hello_world.spec"), Equals, false)
 	c.Assert(isIndexedSpec("specs/hello_world.spec:"), Equals, false)
 	c.Assert(isIndexedSpec("specs/hello_world.md"), Equals, false)
-}
-
-func (s *MySuite) TestToObtainIndexedSpecName(c *C) {
-	specName, scenarioNum := getIndexedSpecName("specs/hello_world.spec:67")
-	c.Assert(specName, Equals, "specs/hello_world.spec")
-	c.Assert(scenarioNum, Equals, 67)
-}
-func (s *MySuite) TestToObtainIndexedSpecName1(c *C) {
-	specName, scenarioNum := getIndexedSpecName("hello_world.spec:67342")
-	c.Assert(specName, Equals, "hello_world.spec")
-	c.Assert(scenarioNum, Equals, 67342)
+	c.Assert(isIndexedSpec("specs/hello_world.spec:10-20"), Equals, true)
+	c.Assert(isIndexedSpec("specs/hello_world.spec:10-"), Equals, false)
 }
 
 func (s *MySuite) TestGetIndex(c *C) {
@@ -265,6 +295,77 @@ func (s *MySuite) TestGetIndex(c *C) {
 	c.Assert(getIndex("f:7a.spec:9"), Equals, 9)
 }
 
+func (s *MySuite) TestParseSpecFromReader(c *C) {
+	specText := `Specification Heading
+=====================
+Scenario Heading
+----------------
+* say "hello" to me
+`
+	spec, res, err := ParseSpecFromReader(strings.NewReader(specText), "foo.spec", gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(res.FileName, Equals, "foo.spec")
+	c.Assert(spec.FileName, Equals, "foo.spec")
+	c.Assert(spec.Heading.Value, Equals, "Specification Heading")
+	c.Assert(spec.Scenarios[0].Steps[0].Value, Equals, "say {} to me")
+}
+
+func (s *MySuite) TestParseSpecFromReaderIgnoresByteOrderMark(c *C) {
+	specText := "\xef\xbb\xbfSpecification Heading\n=====================\nScenario Heading\n----------------\n* step\n"
+
+	spec, res, err := ParseSpecFromReader(strings.NewReader(specText), "foo.spec", gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(spec.Heading.Value, Equals, "Specification Heading")
+}
+
+func (s *MySuite) TestParseSpecFromReaderWithParseErrors(c *C) {
+	specText := `Specification Heading
+=====================
+* step before scenario
+`
+	_, res, err := ParseSpecFromReader(strings.NewReader(specText), "foo.spec", gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(res.ParseErrors[0].FileName, Equals, "foo.spec")
+}
+
+func (s *MySuite) TestParseSpecFromString(c *C) {
+	dictionary := gauge.NewConceptDictionary()
+	dictionary.ConceptsMap["my concept"] = &gauge.Concept{ConceptStep: &gauge.Step{Value: "my concept", IsConcept: true, ConceptSteps: []*gauge.Step{{Value: "inner step"}}}}
+	specText := `Specification Heading
+=====================
+Scenario Heading
+----------------
+* my concept
+`
+	spec, res, err := ParseSpecFromString(specText, "foo.spec", dictionary)
+
+	c.Assert(err, IsNil)
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(spec.FileName, Equals, "foo.spec")
+	c.Assert(spec.Scenarios[0].Steps[0].IsConcept, Equals, true)
+}
+
+type failingReader struct{}
+
+func (r failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func (s *MySuite) TestParseSpecFromReaderWhenReadFails(c *C) {
+	spec, res, err := ParseSpecFromReader(failingReader{}, "foo.spec", gauge.NewConceptDictionary())
+
+	c.Assert(err, IsNil)
+	c.Assert(spec, IsNil)
+	c.Assert(res.Ok, Equals, false)
+	c.Assert(res.ParseErrors, DeepEquals, []ParseError{ParseError{FileName: "foo.spec", Message: "read failed"}})
+}
+
 func staticArg(val string) *gauge.StepArg {
 	return &gauge.StepArg{ArgType: gauge.Static, Value: val}
 }
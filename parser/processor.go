@@ -43,6 +43,14 @@ func processDataTable(parser *SpecParser, token *Token) ([]error, bool) {
 	return []error{}, false
 }
 
+func processInclude(parser *SpecParser, token *Token) ([]error, bool) {
+	if len(token.Value) == 0 {
+		return []error{fmt.Errorf("Include file not specified")}, true
+	}
+	parser.clearState()
+	return []error{}, false
+}
+
 func processScenario(parser *SpecParser, token *Token) ([]error, bool) {
 	if len(strings.TrimSpace(token.Value)) < 1 {
 		return []error{fmt.Errorf("Scenario heading should have at least one character")}, true
@@ -44,6 +44,15 @@ func (invalidSpecialParamError invalidSpecialParamError) Error() string {
 	return invalidSpecialParamError.message
 }
 
+// missingSpecialParamFileError is returned when the file of a special param, like <file:data.txt>, does not exist.
+type missingSpecialParamFileError struct {
+	file string
+}
+
+func (e missingSpecialParamFileError) Error() string {
+	return fmt.Sprintf("File %s doesn't exist.", e.file)
+}
+
 // GetResolvedParams based on the arg type(static, dynamic, table, special_string, special_table) resolves the parameter of a step.
 func (paramResolver *ParamResolver) GetResolvedParams(step *gauge.Step, parent *gauge.Step, lookup *gauge.ArgLookup) ([]*gauge_messages.Parameter, error) {
 	parameters := make([]*gauge_messages.Parameter, 0)
@@ -139,6 +148,9 @@ func newSpecialTypeResolver() *specialTypeResolver {
 func initializePredefinedResolvers() map[string]resolverFn {
 	return map[string]resolverFn{
 		"file": func(filePath string) (*gauge.StepArg, error) {
+			if !common.FileExists(util.GetPathToFile(filePath)) {
+				return nil, missingSpecialParamFileError{file: filePath}
+			}
 			fileContent, err := common.ReadFileContents(util.GetPathToFile(filePath))
 			if err != nil {
 				return nil, err
@@ -146,6 +158,9 @@ func initializePredefinedResolvers() map[string]resolverFn {
 			return &gauge.StepArg{Value: fileContent, ArgType: gauge.SpecialString}, nil
 		},
 		"table": func(filePath string) (*gauge.StepArg, error) {
+			if !common.FileExists(util.GetPathToFile(filePath)) {
+				return nil, missingSpecialParamFileError{file: filePath}
+			}
 			csv, err := common.ReadFileContents(util.GetPathToFile(filePath))
 			if err != nil {
 				return nil, err
@@ -159,6 +174,25 @@ func initializePredefinedResolvers() map[string]resolverFn {
 	}
 }
 
+// stepArgType gives the type of an arg of a step text, where typeOfArg is static, dynamic or special. Special args are
+// told apart by their prefix, a special arg which no resolver handles being dynamic.
+func stepArgType(typeOfArg string, arg string) gauge.ArgType {
+	switch typeOfArg {
+	case "static":
+		return gauge.Static
+	case "special":
+		if i := strings.Index(arg, ":"); i >= 0 {
+			switch strings.TrimSpace(arg[:i]) {
+			case "file":
+				return gauge.SpecialString
+			case "table":
+				return gauge.SpecialTable
+			}
+		}
+	}
+	return gauge.Dynamic
+}
+
 func (resolver *specialTypeResolver) resolve(arg string) (*gauge.StepArg, error) {
 	if util.IsWindows() {
 		arg = GetUnescapedString(arg)
@@ -185,10 +219,7 @@ func (resolver *specialTypeResolver) getStepArg(specialType string, value string
 // Creating a copy of the lookup and populating table values
 func PopulateConceptDynamicParams(concept *gauge.Step, dataTableLookup *gauge.ArgLookup) error {
 	//If it is a top level concept
-	lookup, err := concept.Lookup.GetCopy()
-	if err != nil {
-		return err
-	}
+	lookup := concept.Lookup.Clone()
 	for key := range lookup.ParamIndexMap {
 		conceptLookupArg, err := lookup.GetArg(key)
 		if err != nil {
@@ -20,10 +20,14 @@ package parser
 import (
 	"bufio"
 	"fmt"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge"
 	"github.com/getgauge/gauge/gauge_messages"
 )
@@ -64,6 +68,7 @@ func (parser *SpecParser) initialize() {
 	parser.processors[gauge.TableRow] = processTable
 	parser.processors[gauge.DataTableKind] = processDataTable
 	parser.processors[gauge.TearDownKind] = processTearDown
+	parser.processors[gauge.IncludeKind] = processInclude
 }
 
 // Parse generates tokens for the given spec text and creates the specification.
@@ -112,6 +117,8 @@ func (parser *SpecParser) GenerateTokens(specText, fileName string) ([]*Token, [
 				continue
 			}
 			newToken = &Token{Kind: gauge.CommentKind, LineNo: parser.lineNo, LineText: line, Value: "\n"}
+		} else if value, found := parser.isInclude(trimmedLine); found {
+			newToken = &Token{Kind: gauge.IncludeKind, LineNo: parser.lineNo, LineText: line, Value: value}
 		} else if parser.isScenarioHeading(trimmedLine) {
 			newToken = &Token{Kind: gauge.ScenarioKind, LineNo: parser.lineNo, LineText: line, Value: strings.TrimSpace(trimmedLine[2:])}
 		} else if parser.isSpecHeading(trimmedLine) {
@@ -146,11 +153,20 @@ func (parser *SpecParser) GenerateTokens(specText, fileName string) ([]*Token, [
 		} else {
 			newToken = &Token{Kind: gauge.CommentKind, LineNo: parser.lineNo, LineText: line, Value: common.TrimTrailingSpace(line)}
 		}
+		if newToken.LineNo == parser.lineNo {
+			newToken.StartChar, newToken.EndChar = lineSpan(line)
+		}
 		errors = append(errors, parser.accept(newToken, fileName)...)
 	}
 	return parser.tokens, errors
 }
 
+// lineSpan returns the offsets of the first non space character of the line and of the character after the last one.
+func lineSpan(line string) (int, int) {
+	trimmed := strings.TrimRightFunc(line, unicode.IsSpace)
+	return len(trimmed) - len(strings.TrimLeftFunc(trimmed, unicode.IsSpace)), len(trimmed)
+}
+
 func (parser *SpecParser) tokenKindBasedOnCurrentState(state int, matchingToken gauge.TokenKind, alternateToken gauge.TokenKind) gauge.TokenKind {
 	if isInState(parser.currentState, state) {
 		return matchingToken
@@ -192,6 +208,17 @@ func (parser *SpecParser) isScenarioHeading(text string) bool {
 	return false
 }
 
+func (parser *SpecParser) isInclude(text string) (string, bool) {
+	if !parser.isScenarioHeading(text) {
+		return "", false
+	}
+	directive := strings.TrimSpace(text[2:])
+	if !strings.HasPrefix(strings.ToLower(directive), includeDirective) {
+		return "", false
+	}
+	return strings.TrimSpace(directive[len(includeDirective):]), true
+}
+
 func (parser *SpecParser) isStep(text string) bool {
 	if len(text) > 1 {
 		return text[0] == '*' && text[1] != '*'
@@ -311,7 +338,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, "Multiple spec headings found in same file", token.LineText}}}
 		}
 
-		spec.AddHeading(&gauge.Heading{LineNo: token.LineNo, Value: token.Value})
+		spec.AddHeading(&gauge.Heading{LineNo: token.LineNo, Value: token.Value, StartChar: token.StartChar, EndChar: token.EndChar})
 		addStates(state, specScope)
 		return ParseResult{Ok: true}
 	})
@@ -331,7 +358,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		if len(spec.Scenarios) > 0 {
 			spec.LatestScenario().Span.End = token.LineNo - 1
 		}
-		scenario.AddHeading(&gauge.Heading{Value: token.Value, LineNo: token.LineNo})
+		scenario.AddHeading(&gauge.Heading{Value: token.Value, LineNo: token.LineNo, StartChar: token.StartChar, EndChar: token.EndChar})
 		spec.AddScenario(scenario)
 
 		retainStates(state, specScope)
@@ -343,7 +370,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		return token.Kind == gauge.StepKind && isInState(*state, scenarioScope)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
 		latestScenario := spec.LatestScenario()
-		stepToAdd, parseDetails := createStep(spec, token)
+		stepToAdd, parseDetails := createStep(spec, latestScenario, token)
 		if stepToAdd == nil {
 			return ParseResult{ParseErrors: parseDetails.ParseErrors, Ok: false, Warnings: parseDetails.Warnings}
 		}
@@ -362,7 +389,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	contextConverter := converterFn(func(token *Token, state *int) bool {
 		return token.Kind == gauge.StepKind && !isInState(*state, scenarioScope) && isInState(*state, specScope) && !isInState(*state, tearDownScope)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
-		stepToAdd, parseDetails := createStep(spec, token)
+		stepToAdd, parseDetails := createStep(spec, nil, token)
 		if stepToAdd == nil {
 			return ParseResult{ParseErrors: parseDetails.ParseErrors, Ok: false, Warnings: parseDetails.Warnings}
 		}
@@ -391,7 +418,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	tearDownStepConverter := converterFn(func(token *Token, state *int) bool {
 		return token.Kind == gauge.StepKind && isInState(*state, tearDownScope)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
-		stepToAdd, parseDetails := createStep(spec, token)
+		stepToAdd, parseDetails := createStep(spec, nil, token)
 		if stepToAdd == nil {
 			return ParseResult{ParseErrors: parseDetails.ParseErrors, Ok: false, Warnings: parseDetails.Warnings}
 		}
@@ -411,7 +438,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	commentConverter := converterFn(func(token *Token, state *int) bool {
 		return token.Kind == gauge.CommentKind
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
-		comment := &gauge.Comment{token.Value, token.LineNo}
+		comment := newComment(token.Value, token)
 		if isInState(*state, scenarioScope) {
 			spec.LatestScenario().AddComment(comment)
 		} else {
@@ -439,11 +466,11 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 			spec.AddExternalDataTable(externalTable)
 		} else if isInState(*state, specScope) && spec.DataTable.IsInitialized() {
 			value := "Multiple data table present, ignoring table"
-			spec.AddComment(&gauge.Comment{token.LineText, token.LineNo})
+			spec.AddComment(newComment(token.LineText, token))
 			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName, token.LineNo, value}}}
 		} else {
 			value := "Data table not associated with spec"
-			spec.AddComment(&gauge.Comment{token.LineText, token.LineNo})
+			spec.AddComment(newComment(token.LineText, token))
 			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName, token.LineNo, value}}}
 		}
 		retainStates(state, specScope)
@@ -466,22 +493,27 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 				latestTeardown := spec.LatestTeardown()
 				addInlineTableHeader(latestTeardown, token)
 			} else {
-				spec.AddComment(&gauge.Comment{token.LineText, token.LineNo})
+				spec.AddComment(newComment(token.LineText, token))
 			}
 		} else if !isInState(*state, scenarioScope) {
 			if !spec.DataTable.Table.IsInitialized() {
 				dataTable := &gauge.Table{}
-				dataTable.LineNo = token.LineNo
+				startTable(dataTable, token)
 				dataTable.AddHeaders(token.Args)
 				spec.AddDataTable(dataTable)
 			} else {
 				value := "Multiple data table present, ignoring table"
-				spec.AddComment(&gauge.Comment{token.LineText, token.LineNo})
+				spec.AddComment(newComment(token.LineText, token))
 				return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName, token.LineNo, value}}}
 			}
+		} else if latestScenario := spec.LatestScenario(); scenarioDataTablesAllowed() && len(latestScenario.Steps) == 0 && !latestScenario.HasDataTable() {
+			dataTable := &gauge.Table{}
+			startTable(dataTable, token)
+			dataTable.AddHeaders(token.Args)
+			latestScenario.AddDataTable(dataTable)
 		} else {
 			value := "Table not associated with a step, ignoring table"
-			spec.LatestScenario().AddComment(&gauge.Comment{token.LineText, token.LineNo})
+			spec.LatestScenario().AddComment(newComment(token.LineText, token))
 			return ParseResult{Ok: false, Warnings: []*Warning{&Warning{spec.FileName, token.LineNo, value}}}
 		}
 		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope)
@@ -496,9 +528,9 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		//When table is to be treated as a comment
 		if !isInState(*state, tableScope) {
 			if isInState(*state, scenarioScope) {
-				spec.LatestScenario().AddComment(&gauge.Comment{token.LineText, token.LineNo})
+				spec.LatestScenario().AddComment(newComment(token.LineText, token))
 			} else {
-				spec.AddComment(&gauge.Comment{token.LineText, token.LineNo})
+				spec.AddComment(newComment(token.LineText, token))
 			}
 		} else if areUnderlined(token.Args) && !isInState(*state, tableSeparatorScope) {
 			retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope, tableScope)
@@ -508,7 +540,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 		} else if isInState(*state, stepScope) {
 			latestScenario := spec.LatestScenario()
 			latestStep := latestScenario.LatestStep()
-			result = addInlineTableRow(latestStep, token, new(gauge.ArgLookup).FromDataTable(&spec.DataTable.Table), spec.FileName)
+			result = addInlineTableRow(latestStep, token, new(gauge.ArgLookup).FromDataTable(spec.DataTableFor(latestScenario)), spec.FileName)
 		} else if isInState(*state, contextScope) {
 			latestContext := spec.LatestContext()
 			result = addInlineTableRow(latestContext, token, new(gauge.ArgLookup).FromDataTable(&spec.DataTable.Table), spec.FileName)
@@ -517,17 +549,34 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 				latestTeardown := spec.LatestTeardown()
 				result = addInlineTableRow(latestTeardown, token, new(gauge.ArgLookup).FromDataTable(&spec.DataTable.Table), spec.FileName)
 			} else {
-				spec.AddComment(&gauge.Comment{token.LineText, token.LineNo})
+				spec.AddComment(newComment(token.LineText, token))
 			}
+		} else if isInState(*state, scenarioScope) {
+			scenarioTable := &spec.LatestScenario().DataTable.Table
+			scenarioTable.AddRowValues(token.Args)
+			extendTable(scenarioTable, token)
+			result = ParseResult{Ok: true}
 		} else {
 			//todo validate datatable rows also
 			spec.DataTable.Table.AddRowValues(token.Args)
+			extendTable(&spec.DataTable.Table, token)
 			result = ParseResult{Ok: true}
 		}
 		retainStates(state, specScope, scenarioScope, stepScope, contextScope, tearDownScope, tableScope, tableSeparatorScope)
 		return result
 	})
 
+	includeConverter := converterFn(func(token *Token, state *int) bool {
+		return token.Kind == gauge.IncludeKind
+	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
+		if !isInState(*state, specScope) || isInState(*state, scenarioScope) || isInState(*state, tearDownScope) {
+			return ParseResult{Ok: false, ParseErrors: []ParseError{ParseError{spec.FileName, token.LineNo, "Include should be defined after the spec heading and before the first scenario", token.LineText}}}
+		}
+		spec.AddComment(newComment(token.LineText, token))
+		retainStates(state, specScope)
+		return includeContexts(spec, token)
+	})
+
 	tagConverter := converterFn(func(token *Token, state *int) bool {
 		return (token.Kind == gauge.TagKind)
 	}, func(token *Token, spec *gauge.Specification, state *int) ParseResult {
@@ -556,7 +605,7 @@ func (parser *SpecParser) initializeConverters() []func(*Token, *int, *gauge.Spe
 	})
 
 	converter := []func(*Token, *int, *gauge.Specification) ParseResult{
-		specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, keywordConverter, tearDownConverter, tearDownStepConverter,
+		specConverter, scenarioConverter, stepConverter, contextConverter, commentConverter, tableHeaderConverter, tableRowConverter, tagConverter, keywordConverter, tearDownConverter, tearDownStepConverter, includeConverter,
 	}
 
 	return converter
@@ -586,6 +635,9 @@ func (parser *SpecParser) validateSpec(specification *gauge.Specification) error
 		if len(sce.Steps) == 0 {
 			return ParseError{FileName: specification.FileName, LineNo: sce.Heading.LineNo, Message: "Scenario should have atleast one step"}
 		}
+		if sce.HasDataTable() && sce.DataTable.Table.GetRowCount() == 0 {
+			return ParseError{FileName: specification.FileName, LineNo: sce.DataTable.Table.LineNo, Message: "Scenario data table should have at least 1 data row"}
+		}
 	}
 	return nil
 }
@@ -599,8 +651,8 @@ func converterFn(predicate func(token *Token, state *int) bool, apply func(token
 	}
 }
 
-func createStep(spec *gauge.Specification, stepToken *Token) (*gauge.Step, *ParseResult) {
-	dataTableLookup := new(gauge.ArgLookup).FromDataTable(&spec.DataTable.Table)
+func createStep(spec *gauge.Specification, scenario *gauge.Scenario, stepToken *Token) (*gauge.Step, *ParseResult) {
+	dataTableLookup := new(gauge.ArgLookup).FromDataTable(spec.DataTableFor(scenario))
 	stepToAdd, parseDetails := CreateStepUsingLookup(stepToken, dataTableLookup, spec.FileName)
 	if stepToAdd != nil {
 		stepToAdd.Suffix = stepToken.Suffix
@@ -614,7 +666,7 @@ func CreateStepUsingLookup(stepToken *Token, lookup *gauge.ArgLookup, specFileNa
 	if argsType != nil && len(argsType) != len(stepToken.Args) {
 		return nil, &ParseResult{ParseErrors: []ParseError{ParseError{specFileName, stepToken.LineNo, "Step text should not have '{static}' or '{dynamic}' or '{special}'", stepToken.LineText}}, Warnings: nil}
 	}
-	step := &gauge.Step{FileName: specFileName, LineNo: stepToken.LineNo, Value: stepValue, LineText: strings.TrimSpace(stepToken.LineText)}
+	step := &gauge.Step{FileName: specFileName, LineNo: stepToken.LineNo, StartChar: stepToken.StartChar, EndChar: stepToken.EndChar, Value: stepValue, LineText: strings.TrimSpace(stepToken.LineText)}
 	arguments := make([]*gauge.StepArg, 0)
 	var errors []ParseError
 	var warnings []*Warning
@@ -678,6 +730,10 @@ func createStepArg(argValue string, typeOfArg string, token *Token, lookup *gaug
 	if typeOfArg == "special" {
 		resolvedArgValue, err := newSpecialTypeResolver().resolve(argValue)
 		if err != nil {
+			// A missing file is reported only for steps. A concept heading cannot have special params even if the file exists.
+			if _, ok := err.(missingSpecialParamFileError); ok && token.Kind == gauge.StepKind {
+				return &gauge.StepArg{ArgType: gauge.Dynamic, Value: argValue, Name: argValue}, &ParseResult{ParseErrors: []ParseError{ParseError{FileName: fileName, LineNo: token.LineNo, Message: fmt.Sprintf("Special parameter <%s> could not be resolved. %s", argValue, err.Error()), LineText: token.LineText}}}
+			}
 			switch err.(type) {
 			case invalidSpecialParamError:
 				return treatArgAsDynamic(argValue, token, lookup, fileName)
@@ -724,6 +780,27 @@ func addInlineTableHeader(step *gauge.Step, token *Token) {
 	step.Value = fmt.Sprintf("%s %s", step.Value, gauge.ParameterPlaceholder)
 	step.HasInlineTable = true
 	step.AddInlineTableHeaders(token.Args)
+	startTable(&step.Args[len(step.Args)-1].Table, token)
+}
+
+func scenarioDataTablesAllowed() bool {
+	allowed, err := strconv.ParseBool(os.Getenv(env.AllowScenarioDatatable))
+	return err == nil && allowed
+}
+
+func startTable(table *gauge.Table, header *Token) {
+	table.LineNo = header.LineNo
+	table.StartChar = header.StartChar
+	extendTable(table, header)
+}
+
+func extendTable(table *gauge.Table, row *Token) {
+	table.EndLineNo = row.LineNo
+	table.EndChar = row.EndChar
+}
+
+func newComment(value string, token *Token) *gauge.Comment {
+	return &gauge.Comment{Value: value, LineNo: token.LineNo, StartChar: token.StartChar, EndChar: token.EndChar}
 }
 
 func addInlineTableRow(step *gauge.Step, token *Token, argLookup *gauge.ArgLookup, fileName string) ParseResult {
@@ -745,6 +822,7 @@ func addInlineTableRow(step *gauge.Step, token *Token, argLookup *gauge.ArgLooku
 		}
 	}
 	step.AddInlineTableRow(tableValues)
+	extendTable(&step.Args[len(step.Args)-1].Table, token)
 	return ParseResult{Ok: true, Warnings: warnings}
 }
 
@@ -781,6 +859,9 @@ type Token struct {
 	Suffix   string
 	Args     []string
 	Value    string
+	// StartChar and EndChar are the byte offsets in the line where the token's text starts and ends, without the surrounding spaces.
+	StartChar int
+	EndChar   int
 }
 
 type ParseError struct {
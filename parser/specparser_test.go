@@ -18,10 +18,13 @@
 package parser
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
 
 	. "gopkg.in/check.v1"
 )
@@ -1160,6 +1163,48 @@ func (s *MySuite) TestWarningWhenParsingTableOccursWithoutStep(c *C) {
 
 }
 
+func (s *MySuite) TestParsingScenarioDataTable(c *C) {
+	os.Setenv(env.AllowScenarioDatatable, "true")
+	defer os.Unsetenv(env.AllowScenarioDatatable)
+	tokens := []*Token{
+		&Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&Token{Kind: gauge.TableHeader, Args: []string{"id"}, LineNo: 2},
+		&Token{Kind: gauge.TableRow, Args: []string{"1"}, LineNo: 3},
+		&Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 4},
+		&Token{Kind: gauge.TableHeader, Args: []string{"id", "name"}, LineNo: 5},
+		&Token{Kind: gauge.TableRow, Args: []string{"1", "foo"}, LineNo: 6},
+		&Token{Kind: gauge.TableRow, Args: []string{"2", "bar"}, LineNo: 7},
+		&Token{Kind: gauge.StepKind, Value: "Step with {dynamic}", Args: []string{"name"}, LineNo: 8},
+	}
+
+	spec, result, err := new(SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "foo.spec")
+	c.Assert(err, IsNil)
+	c.Assert(result.Ok, Equals, true)
+	c.Assert(len(result.Warnings), Equals, 0)
+	scenario := spec.Scenarios[0]
+	c.Assert(scenario.HasDataTable(), Equals, true)
+	c.Assert(scenario.DataTable.Table.Headers, DeepEquals, []string{"id", "name"})
+	c.Assert(scenario.DataTable.Table.GetRowCount(), Equals, 2)
+	c.Assert(scenario.Items[0].Kind(), Equals, gauge.DataTableKind)
+	c.Assert(scenario.Steps[0].Args[0].ArgType, Equals, gauge.Dynamic)
+	c.Assert(spec.DataTable.Table.GetRowCount(), Equals, 1)
+}
+
+func (s *MySuite) TestScenarioDataTableWithoutRows(c *C) {
+	os.Setenv(env.AllowScenarioDatatable, "true")
+	defer os.Unsetenv(env.AllowScenarioDatatable)
+	tokens := []*Token{
+		&Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 2},
+		&Token{Kind: gauge.TableHeader, Args: []string{"id"}, LineNo: 3},
+		&Token{Kind: gauge.StepKind, Value: "Step", LineNo: 4},
+	}
+
+	_, result, _ := new(SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "foo.spec")
+	c.Assert(result.Ok, Equals, false)
+	c.Assert(result.ParseErrors[0].Message, Equals, "Scenario data table should have at least 1 data row")
+}
+
 func (s *MySuite) TestAddSpecTags(c *C) {
 	tokens := []*Token{
 		&Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
@@ -1682,3 +1727,61 @@ func (s *MySuite) TestExtractStepArgsFromToken(c *C) {
 	c.Assert(args[1].Value, Equals, "Dynamic")
 	c.Assert(args[1].ArgType, Equals, gauge.Dynamic)
 }
+
+func (s *MySuite) TestTokensHaveCharacterOffsets(c *C) {
+	specText := "# Spec Heading  \n  ## Scenario Heading\n   * a step \n"
+
+	tokens, errs := new(SpecParser).GenerateTokens(specText, "")
+
+	c.Assert(errs, HasLen, 0)
+	c.Assert(tokens, HasLen, 3)
+	c.Assert([]int{tokens[0].StartChar, tokens[0].EndChar}, DeepEquals, []int{0, 14})
+	c.Assert([]int{tokens[1].StartChar, tokens[1].EndChar}, DeepEquals, []int{2, 21})
+	c.Assert([]int{tokens[2].StartChar, tokens[2].EndChar}, DeepEquals, []int{3, 11})
+}
+
+func (s *MySuite) TestUnderlinedHeadingTokenKeepsHeadingLineOffsets(c *C) {
+	tokens, _ := new(SpecParser).GenerateTokens("  Spec Heading\n==========\n", "")
+
+	c.Assert(tokens, HasLen, 1)
+	c.Assert(tokens[0].LineNo, Equals, 1)
+	c.Assert([]int{tokens[0].StartChar, tokens[0].EndChar}, DeepEquals, []int{2, 14})
+}
+
+func (s *MySuite) TestSpecItemPositions(c *C) {
+	specText := `# Spec Heading
+A comment
+## Scenario Heading
+  * step with table
+     |id|name|
+     |--|----|
+     |1 |foo |
+`
+	spec, res := new(SpecParser).ParseSpecText(specText, "")
+
+	c.Assert(res.Ok, Equals, true)
+	c.Assert(spec.Heading.StartPosition(), Equals, lsp.Position{Line: 0, Character: 0})
+	c.Assert(spec.Heading.EndPosition(), Equals, lsp.Position{Line: 0, Character: 14})
+	c.Assert(spec.Comments[0].StartPosition(), Equals, lsp.Position{Line: 1, Character: 0})
+	c.Assert(spec.Comments[0].EndPosition(), Equals, lsp.Position{Line: 1, Character: 9})
+	c.Assert(spec.Scenarios[0].Heading.EndPosition(), Equals, lsp.Position{Line: 2, Character: 19})
+	step := spec.Scenarios[0].Steps[0]
+	c.Assert(step.StartPosition(), Equals, lsp.Position{Line: 3, Character: 2})
+	c.Assert(step.EndPosition(), Equals, lsp.Position{Line: 3, Character: 19})
+	table := step.Args[0].Table
+	c.Assert(table.StartPosition(), Equals, lsp.Position{Line: 4, Character: 5})
+	c.Assert(table.EndPosition(), Equals, lsp.Position{Line: 6, Character: 14})
+}
+
+func (s *MySuite) TestSpecialParamWithFileWhichDoesNotExist(c *C) {
+	tokens := []*Token{
+		&Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 2},
+		&Token{Kind: gauge.StepKind, Value: "Example {special} step", LineNo: 3, Args: []string{"file:missing.txt"}, LineText: "Example <file:missing.txt> step"},
+	}
+
+	_, result, _ := new(SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "foo.spec")
+
+	c.Assert(result.Ok, Equals, false)
+	c.Assert(result.ParseErrors[0].Message, Equals, "Special parameter <file:missing.txt> could not be resolved. File missing.txt doesn't exist.")
+}
@@ -0,0 +1,5 @@
+﻿# Spécification
+
+## Scenario
+
+* say "héllo"
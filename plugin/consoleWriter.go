@@ -0,0 +1,125 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/getgauge/gauge/logger"
+)
+
+// CaptureMode selects the form in which plugin output is written to a capture writer.
+type CaptureMode int
+
+const (
+	// CapturePrefixed captures the lines as they are written to the console, prefixed with the plugin name.
+	CapturePrefixed CaptureMode = iota
+	// CaptureRaw captures the lines as the plugin printed them, without the prefix and the output transform.
+	CaptureRaw
+)
+
+// OutputOptions changes how the output of a plugin is written to the console.
+type OutputOptions struct {
+	// Transform is applied to every line of plugin output before it is written to the console.
+	// It can return an empty string to drop the line.
+	Transform func(line string) string
+	// Capture, when set, receives a copy of the output in the form selected by CaptureMode.
+	Capture     io.Writer
+	CaptureMode CaptureMode
+}
+
+// pluginConsoleWriter prefixes every line written by a plugin with the plugin name.
+// Partial lines are buffered until a newline arrives so that the prefix is only added at the start of a line.
+type pluginConsoleWriter struct {
+	mutex      sync.Mutex
+	pluginName string
+	writer     io.Writer
+	buffer     bytes.Buffer
+	output     OutputOptions
+}
+
+func newPluginConsoleWriter(pluginName string, w io.Writer, output OutputOptions) *pluginConsoleWriter {
+	return &pluginConsoleWriter{pluginName: pluginName, writer: w, output: output}
+}
+
+func (w *pluginConsoleWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.buffer.Write(p)
+	data := w.buffer.Bytes()
+	lastNewLine := bytes.LastIndexByte(data, '\n')
+	if lastNewLine < 0 {
+		return len(p), nil
+	}
+	lines := string(data[:lastNewLine+1])
+	w.buffer.Next(lastNewLine + 1)
+	if err := w.writeLines(lines); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes out any buffered partial line.
+func (w *pluginConsoleWriter) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	line := w.buffer.String() + "\n"
+	w.buffer.Reset()
+	return w.writeLines(line)
+}
+
+func (w *pluginConsoleWriter) writeLines(lines string) error {
+	prefixed := w.addPrefixToEachLine(lines)
+	if _, err := io.WriteString(w.writer, prefixed); err != nil {
+		return err
+	}
+	if w.output.Capture == nil {
+		return nil
+	}
+	captured := prefixed
+	if w.output.CaptureMode == CaptureRaw {
+		captured = lines
+	}
+	// The plugin should not fail to write its output because the capture did.
+	if _, err := io.WriteString(w.output.Capture, captured); err != nil {
+		logger.Warningf("Failed to capture the output of plugin %s. %s", w.pluginName, err.Error())
+	}
+	return nil
+}
+
+func (w *pluginConsoleWriter) addPrefixToEachLine(text string) string {
+	var result []string
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if w.output.Transform != nil {
+			transformed := w.output.Transform(line)
+			if transformed == "" && line != "" {
+				continue
+			}
+			line = transformed
+		}
+		result = append(result, fmt.Sprintf("[%s Plugin] : %s\n", w.pluginName, line))
+	}
+	return strings.Join(result, "")
+}
@@ -0,0 +1,180 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestPluginConsoleWriterPrefixesEachLine(c *C) {
+	b := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{})
+
+	w.Write([]byte("first line\nsecond line\n"))
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : first line\n[html-report Plugin] : second line\n")
+}
+
+func (s *MySuite) TestPluginConsoleWriterBuffersLineSplitAcrossWrites(c *C) {
+	b := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{})
+
+	w.Write([]byte("Generating "))
+	c.Assert(b.String(), Equals, "")
+	w.Write([]byte("report"))
+	w.Write([]byte(" done\nnext"))
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : Generating report done\n")
+}
+
+func (s *MySuite) TestPluginConsoleWriterFlushesPartialLine(c *C) {
+	b := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{})
+
+	w.Write([]byte("done\npartial"))
+	w.Flush()
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : done\n[html-report Plugin] : partial\n")
+}
+
+func (s *MySuite) TestPluginConsoleWriterTransformRedactsLines(c *C) {
+	b := &bytes.Buffer{}
+	token := regexp.MustCompile(`token=\w+`)
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{
+		Transform: func(line string) string { return token.ReplaceAllString(line, "token=****") },
+	})
+
+	w.Write([]byte("connecting with token=s3cr3t\n"))
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : connecting with token=****\n")
+}
+
+func (s *MySuite) TestPluginConsoleWriterTransformDropsLines(c *C) {
+	b := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{
+		Transform: func(line string) string {
+			if strings.HasPrefix(line, "DEBUG") {
+				return ""
+			}
+			return line
+		},
+	})
+
+	w.Write([]byte("DEBUG noisy line\nuseful line\n"))
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : useful line\n")
+}
+
+func (s *MySuite) TestPluginConsoleWriterKeepsEmptyLines(c *C) {
+	b := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{})
+
+	w.Write([]byte("first\n\nsecond\n"))
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : first\n[html-report Plugin] : \n[html-report Plugin] : second\n")
+}
+
+func (s *MySuite) TestPluginConsoleWriterCapturesPrefixedOutput(c *C) {
+	b := &bytes.Buffer{}
+	captured := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{Capture: captured})
+
+	w.Write([]byte("first line\nsecond"))
+	w.Flush()
+
+	c.Assert(captured.String(), Equals, b.String())
+}
+
+func (s *MySuite) TestPluginConsoleWriterCapturesRawOutput(c *C) {
+	b := &bytes.Buffer{}
+	captured := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{
+		Transform:   func(line string) string { return strings.ToUpper(line) },
+		Capture:     captured,
+		CaptureMode: CaptureRaw,
+	})
+
+	w.Write([]byte("first line\n"))
+
+	c.Assert(b.String(), Equals, "[html-report Plugin] : FIRST LINE\n")
+	c.Assert(captured.String(), Equals, "first line\n")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func (s *MySuite) TestPluginConsoleWriterDoesNotFailWhenCaptureFails(c *C) {
+	b := &bytes.Buffer{}
+	w := newPluginConsoleWriter("html-report", b, OutputOptions{Capture: failingWriter{}})
+
+	n, err := w.Write([]byte("first line\n"))
+
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, len("first line\n"))
+	c.Assert(b.String(), Equals, "[html-report Plugin] : first line\n")
+}
+
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+func (s *MySuite) TestCaptureOutputOfStartedPlugin(c *C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("fake plugin is a shell command")
+	}
+	sh, err := exec.LookPath("sh")
+	c.Assert(err, IsNil)
+	captured := &syncBuffer{}
+	pd := &pluginDescriptor{Name: "fake"}
+	pd.Command.Linux = []string{sh, "-c", "echo starting; echo done"}
+	pd.Command.Darwin = pd.Command.Linux
+
+	_, err = StartPluginWithOutput(pd, "test", OutputOptions{Capture: captured})
+	c.Assert(err, IsNil)
+
+	want := "[fake Plugin] : starting\n[fake Plugin] : done\n"
+	for deadline := time.Now().Add(5 * time.Second); captured.String() != want && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(captured.String(), Equals, want)
+}
@@ -0,0 +1,122 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/getgauge/gauge/logger"
+	"github.com/getgauge/gauge/util"
+)
+
+// pluginDevModeEnv enables restarting plugins whenever their files change. Meant for plugin authors only.
+const pluginDevModeEnv = "GAUGE_PLUGIN_DEV_MODE"
+
+// restartDelay is how long a plugin's files should stay unchanged before it is restarted,
+// so that a rebuild writing many files causes a single restart.
+var restartDelay = time.Second
+
+func isPluginDevMode() bool {
+	devMode, err := strconv.ParseBool(os.Getenv(pluginDevModeEnv))
+	return err == nil && devMode
+}
+
+// watchPlugin restarts the plugin using start whenever a file in its directory changes.
+func (gp *GaugePlugins) watchPlugin(pluginID, dir string, start func() (*plugin, error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	dirs, err := util.FindAllNestedDirs(dir, 0)
+	if err != nil {
+		logger.Warningf("%s", err.Error())
+	}
+	for _, d := range append([]string{dir}, dirs...) {
+		if err := watcher.Add(d); err != nil {
+			watcher.Close()
+			return err
+		}
+	}
+	gp.addWatcher(watcher)
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					if timer != nil {
+						timer.Stop()
+					}
+					return
+				}
+				logger.Debugf("Plugin %s changed: %s", pluginID, event.String())
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(restartDelay, func() { gp.restartPlugin(pluginID, start) })
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warningf("Error watching plugin %s: %s", pluginID, err.Error())
+			}
+		}
+	}()
+	return nil
+}
+
+func (gp *GaugePlugins) addWatcher(watcher *fsnotify.Watcher) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	gp.watchers = append(gp.watchers, watcher)
+}
+
+func (gp *GaugePlugins) stopWatching() {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	for _, watcher := range gp.watchers {
+		watcher.Close()
+	}
+	gp.watchers = nil
+}
+
+// restartPlugin gracefully kills the running plugin and replaces it with the one returned by start.
+func (gp *GaugePlugins) restartPlugin(pluginID string, start func() (*plugin, error)) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	if gp.watchers == nil {
+		return
+	}
+	if old, ok := gp.pluginsMap[pluginID]; ok {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		old.kill(&wg)
+		delete(gp.pluginsMap, pluginID)
+	}
+	p, err := start()
+	if err != nil {
+		logger.Errorf("Failed to restart plugin %s. %s", pluginID, err.Error())
+		return
+	}
+	gp.addPlugin(pluginID, p)
+	logger.Infof("Restarted plugin %s", pluginID)
+}
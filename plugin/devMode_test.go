@@ -0,0 +1,77 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func exitedPlugin(c *C) *plugin {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	c.Assert(cmd.Run(), IsNil)
+	return &plugin{pluginCmd: cmd, descriptor: &pluginDescriptor{Name: "dev-plugin"}, mutex: &sync.Mutex{}, exited: true}
+}
+
+func (s *MySuite) TestTouchingPluginBinaryRestartsPluginInDevMode(c *C) {
+	defer func(d time.Duration) { restartDelay = d }(restartDelay)
+	restartDelay = 10 * time.Millisecond
+	dir := c.MkDir()
+	binary := filepath.Join(dir, "plugin-binary")
+	ioutil.WriteFile(binary, []byte("v1"), 0755)
+	gp := &GaugePlugins{}
+	gp.addPlugin("dev-plugin", exitedPlugin(c))
+	restarted := make(chan *plugin, 1)
+	newPlugin := exitedPlugin(c)
+
+	err := gp.watchPlugin("dev-plugin", dir, func() (*plugin, error) {
+		restarted <- newPlugin
+		return newPlugin, nil
+	})
+	c.Assert(err, IsNil)
+	defer gp.stopWatching()
+
+	now := time.Now()
+	c.Assert(os.Chtimes(binary, now, now), IsNil)
+
+	select {
+	case <-restarted:
+	case <-time.After(5 * time.Second):
+		c.Fatal("plugin was not restarted after its binary changed")
+	}
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
+	c.Assert(gp.pluginsMap["dev-plugin"], Equals, newPlugin)
+}
+
+func (s *MySuite) TestPluginDevModeIsOffByDefault(c *C) {
+	old := os.Getenv(pluginDevModeEnv)
+	defer os.Setenv(pluginDevModeEnv, old)
+
+	os.Setenv(pluginDevModeEnv, "")
+	c.Assert(isPluginDevMode(), Equals, false)
+
+	os.Setenv(pluginDevModeEnv, "true")
+	c.Assert(isPluginDevMode(), Equals, true)
+}
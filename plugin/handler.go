@@ -20,6 +20,7 @@ package plugin
 import (
 	"sync"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 )
@@ -30,7 +31,9 @@ type Handler interface {
 }
 
 type GaugePlugins struct {
+	mutex      sync.Mutex
 	pluginsMap map[string]*plugin
+	watchers   []*fsnotify.Watcher
 }
 
 func (gp *GaugePlugins) addPlugin(pluginID string, pluginToAdd *plugin) {
@@ -45,6 +48,8 @@ func (gp *GaugePlugins) removePlugin(pluginID string) {
 }
 
 func (gp *GaugePlugins) NotifyPlugins(message *gauge_messages.Message) {
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
 	for id, plugin := range gp.pluginsMap {
 		err := plugin.sendMessage(message)
 		if err != nil {
@@ -65,6 +70,9 @@ func (gp *GaugePlugins) killPlugin(pluginID string) {
 }
 
 func (gp *GaugePlugins) GracefullyKillPlugins() {
+	gp.stopWatching()
+	gp.mutex.Lock()
+	defer gp.mutex.Unlock()
 	var wg sync.WaitGroup
 	for _, plugin := range gp.pluginsMap {
 		wg.Add(1)
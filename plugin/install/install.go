@@ -593,7 +593,8 @@ func (a byDecreasingVersion) Less(i, j int) bool {
 }
 
 // AddPluginToProject adds the given plugin to current Gauge project.
-func AddPluginToProject(pluginName string) error {
+// A non empty versionConstraint (e.g. ^4) is recorded in the manifest and limits the plugin versions used by the project.
+func AddPluginToProject(pluginName, versionConstraint string) error {
 	m, err := manifest.ProjectManifest()
 	if err != nil {
 		return nil
@@ -606,10 +607,18 @@ func AddPluginToProject(pluginName string) error {
 		return err
 	}
 	if plugin.IsPluginAdded(m, pd) {
-		logger.Debugf("Plugin %s is already added.", pd.Name)
-		return nil
+		if versionConstraint == "" || m.VersionConstraint(pd.ID) == versionConstraint {
+			logger.Debugf("Plugin %s is already added.", pd.Name)
+			return nil
+		}
+		m.SetVersionConstraint(pd.ID, versionConstraint)
+		return m.Save()
+	}
+	if err := pd.CheckGaugeVersion(); err != nil {
+		return err
 	}
 	m.Plugins = append(m.Plugins, pd.ID)
+	m.SetVersionConstraint(pd.ID, versionConstraint)
 	if err = m.Save(); err != nil {
 		return err
 	}
@@ -35,6 +35,7 @@ import (
 	"github.com/getgauge/common"
 	"github.com/getgauge/gauge/config"
 	"github.com/getgauge/gauge/conn"
+	"github.com/getgauge/gauge/env"
 	"github.com/getgauge/gauge/gauge_messages"
 	"github.com/getgauge/gauge/logger"
 	"github.com/getgauge/gauge/manifest"
@@ -62,7 +63,11 @@ type pluginDescriptor struct {
 	}
 	Scope               []string
 	GaugeVersionSupport version.VersionSupport
-	pluginPath          string
+	ResourceLimits      struct {
+		// MaxMemory is the memory in MB the plugin may use before it is killed.
+		MaxMemory int
+	}
+	pluginPath string
 }
 
 type plugin struct {
@@ -70,13 +75,13 @@ type plugin struct {
 	connection net.Conn
 	pluginCmd  *exec.Cmd
 	descriptor *pluginDescriptor
+	exited     bool
 }
 
 func (p *plugin) IsProcessRunning() bool {
 	p.mutex.Lock()
-	ps := p.pluginCmd.ProcessState
-	p.mutex.Unlock()
-	return ps == nil || !ps.Exited()
+	defer p.mutex.Unlock()
+	return !p.exited
 }
 
 func (p *plugin) kill(wg *sync.WaitGroup) error {
@@ -169,36 +174,62 @@ func GetPluginDescriptorFromJSON(pluginJSON string) (*pluginDescriptor, error) {
 	return &pd, nil
 }
 
-func StartPlugin(pd *pluginDescriptor, action string) (*plugin, error) {
-	command := []string{}
-	switch runtime.GOOS {
+// commandFor returns the command which starts the plugin on goos.
+func (pd *pluginDescriptor) commandFor(goos string) []string {
+	switch goos {
 	case "windows":
-		command = pd.Command.Windows
-		break
+		return pd.Command.Windows
 	case "darwin":
-		command = pd.Command.Darwin
-		break
+		return pd.Command.Darwin
 	default:
-		command = pd.Command.Linux
-		break
+		return pd.Command.Linux
 	}
+}
+
+func StartPlugin(pd *pluginDescriptor, action string) (*plugin, error) {
+	return startPlugin(pd, action, nil, OutputOptions{})
+}
+
+// StartPluginWithOutput starts the plugin with its console output transformed or captured as the options say.
+func StartPluginWithOutput(pd *pluginDescriptor, action string, output OutputOptions) (*plugin, error) {
+	return startPlugin(pd, action, nil, output)
+}
+
+// startPlugin starts the plugin with the given properties added to the environment of its process only.
+func startPlugin(pd *pluginDescriptor, action string, properties map[string]string, output OutputOptions) (*plugin, error) {
+	command := pd.commandFor(runtime.GOOS)
 	if len(command) == 0 {
 		return nil, fmt.Errorf("Platform specific command not specified: %s.", runtime.GOOS)
 	}
+	if err := pd.checkCommand(command, runtime.GOOS); err != nil {
+		return nil, err
+	}
 
-	cmd, err := common.ExecuteCommand(command, pd.pluginPath, reporter.Current(), reporter.Current())
+	writer := newPluginConsoleWriter(pd.Name, reporter.Current(), output)
+	var cmd *exec.Cmd
+	var err error
+	if len(properties) > 0 {
+		cmd, err = common.ExecuteCommandWithEnv(command, pd.pluginPath, writer, writer, pluginProcessEnv(properties))
+	} else {
+		cmd, err = common.ExecuteCommand(command, pd.pluginPath, writer, writer)
+	}
 
 	if err != nil {
 		return nil, err
 	}
-	var mutex = &sync.Mutex{}
+	logger.Debugf("Plugin [%s] started with pid [%d] for %s", pd.Name, cmd.Process.Pid, action)
+	plugin := &plugin{pluginCmd: cmd, descriptor: pd, mutex: &sync.Mutex{}}
 	go func() {
-		pState, _ := cmd.Process.Wait()
-		mutex.Lock()
-		cmd.ProcessState = pState
-		mutex.Unlock()
+		// cmd.Wait returns only after the output of the plugin is copied to the writer, so nothing is left to flush later.
+		cmd.Wait()
+		writer.Flush()
+		plugin.mutex.Lock()
+		plugin.exited = true
+		plugin.mutex.Unlock()
 	}()
-	plugin := &plugin{pluginCmd: cmd, descriptor: pd, mutex: mutex}
+	if limit := pd.maxMemory(); limit > 0 {
+		go plugin.watchMemory(limit)
+	}
 	return plugin, nil
 }
 
@@ -229,61 +260,104 @@ func IsPluginAdded(manifest *manifest.Manifest, descriptor *pluginDescriptor) bo
 	return false
 }
 
-func startPluginsForExecution(manifest *manifest.Manifest) (Handler, []string) {
+func startPluginsForExecution(manifest *manifest.Manifest, envName string) (Handler, []string) {
 	var warnings []string
 	handler := &GaugePlugins{}
-	envProperties := make(map[string]string)
 
 	for _, pluginID := range manifest.Plugins {
-		pd, err := GetPluginDescriptor(pluginID, "")
+		pluginVersion, err := versionResolver.Resolve(pluginID, manifest.VersionConstraint(pluginID))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Unable to start plugin %s. %s.", pluginID, err.Error()))
+			continue
+		}
+		pd, err := GetPluginDescriptor(pluginID, pluginVersion)
 		if err != nil {
 			warnings = append(warnings, fmt.Sprintf("Unable to start plugin %s. %s. To install, run `gauge install %s`.", pluginID, err.Error(), pluginID))
 			continue
 		}
-		compatibilityErr := version.CheckCompatibility(version.CurrentGaugeVersion, &pd.GaugeVersionSupport)
-		if compatibilityErr != nil {
-			warnings = append(warnings, fmt.Sprintf("Compatible %s plugin version to current Gauge version %s not found", pd.Name, version.CurrentGaugeVersion))
+		if err := pd.CheckGaugeVersion(); err != nil {
+			warnings = append(warnings, err.Error())
 			continue
 		}
 		if isPluginValidFor(pd, executionScope) {
-			gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, nil)
+			p, err := startExecutionPlugin(pd, manifest, envName)
 			if err != nil {
 				warnings = append(warnings, err.Error())
 				continue
 			}
-			envProperties[pluginConnectionPortEnv] = strconv.Itoa(gaugeConnectionHandler.ConnectionPortNumber())
-			err = SetEnvForPlugin(executionScope, pd, manifest, envProperties)
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error()))
-				continue
-			}
-
-			plugin, err := StartPlugin(pd, executionScope)
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Error starting plugin %s %s. %s", pd.Name, pd.Version, err.Error()))
-				continue
-			}
-			pluginConnection, err := gaugeConnectionHandler.AcceptConnection(config.PluginConnectionTimeout(), make(chan error))
-			if err != nil {
-				warnings = append(warnings, fmt.Sprintf("Error starting plugin %s %s. Failed to connect to plugin. %s", pd.Name, pd.Version, err.Error()))
-				plugin.pluginCmd.Process.Kill()
-				continue
+			handler.addPlugin(pluginID, p)
+			if isPluginDevMode() {
+				restart := func() (*plugin, error) { return startExecutionPlugin(pd, manifest, envName) }
+				if err := handler.watchPlugin(pluginID, pd.pluginPath, restart); err != nil {
+					warnings = append(warnings, fmt.Sprintf("Unable to watch plugin %s for changes. %s", pd.Name, err.Error()))
+				}
 			}
-			plugin.connection = pluginConnection
-			handler.addPlugin(pluginID, plugin)
 		}
 
 	}
 	return handler, warnings
 }
 
+// startExecutionPlugin starts the plugin for execution and waits for it to connect.
+func startExecutionPlugin(pd *pluginDescriptor, manifest *manifest.Manifest, envName string) (*plugin, error) {
+	gaugeConnectionHandler, err := conn.NewGaugeConnectionHandler(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	pluginArgs := map[string]string{pluginConnectionPortEnv: strconv.Itoa(gaugeConnectionHandler.ConnectionPortNumber())}
+	envProperties, err := pluginEnvProperties(pd, envName, pluginArgs)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+	err = SetEnvForPlugin(executionScope, pd, manifest, pluginArgs)
+	if err != nil {
+		return nil, fmt.Errorf("Error setting environment for plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+
+	plugin, err := startPlugin(pd, executionScope, envProperties, OutputOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Error starting plugin %s %s. %s", pd.Name, pd.Version, err.Error())
+	}
+	pluginConnection, err := gaugeConnectionHandler.AcceptConnection(config.PluginConnectionTimeout(), make(chan error))
+	if err != nil {
+		plugin.pluginCmd.Process.Kill()
+		return nil, fmt.Errorf("Error starting plugin %s %s. Failed to connect to plugin. %s", pd.Name, pd.Version, err.Error())
+	}
+	logger.Debugf("Connected to plugin [%s]", pd.Name)
+	plugin.connection = pluginConnection
+	return plugin, nil
+}
+
+// pluginEnvProperties returns the properties of the gauge environment meant for the plugin, leaving out the ones
+// set by the given plugin args, which take precedence.
+func pluginEnvProperties(pd *pluginDescriptor, envName string, pluginArgs map[string]string) (map[string]string, error) {
+	properties, err := env.PluginProperties(envName, pd.ID)
+	if err != nil {
+		return nil, err
+	}
+	for k := range pluginArgs {
+		delete(properties, k)
+	}
+	return properties, nil
+}
+
+// pluginProcessEnv returns the environment of the gauge process with the given properties added, so that they
+// reach a single plugin without being set for gauge and the other plugins.
+func pluginProcessEnv(properties map[string]string) []string {
+	processEnv := os.Environ()
+	for k, v := range properties {
+		processEnv = append(processEnv, fmt.Sprintf("%s=%s", k, v))
+	}
+	return processEnv
+}
+
 func GenerateDoc(pluginName string, specDirs []string, port int) {
 	pd, err := GetPluginDescriptor(pluginName, "")
 	if err != nil {
 		logger.Fatalf("Error starting plugin %s. Failed to get plugin.json. %s. To install, run `gauge install %s`.", pluginName, err.Error(), pluginName)
 	}
-	if err := version.CheckCompatibility(version.CurrentGaugeVersion, &pd.GaugeVersionSupport); err != nil {
-		logger.Fatalf("Compatible %s plugin version to current Gauge version %s not found", pd.Name, version.CurrentGaugeVersion)
+	if err := pd.CheckGaugeVersion(); err != nil {
+		logger.Fatalf("%s", err.Error())
 	}
 	if !isPluginValidFor(pd, docScope) {
 		logger.Fatalf("Invalid plugin name: %s, this plugin cannot generate documentation.", pd.Name)
@@ -306,7 +380,7 @@ func GenerateDoc(pluginName string, specDirs []string, port int) {
 
 func isPluginValidFor(pd *pluginDescriptor, scope string) bool {
 	for _, s := range pd.Scope {
-		if strings.ToLower(s) == scope {
+		if strings.EqualFold(s, scope) {
 			return true
 		}
 	}
@@ -320,7 +394,7 @@ func (p *plugin) sendMessage(message *gauge_messages.Message) error {
 	if err != nil {
 		return err
 	}
-	err = conn.Write(p.connection, messageBytes)
+	err = conn.WriteWithTimeout(p.connection, messageBytes, config.PluginWriteTimeout())
 	if err != nil {
 		return fmt.Errorf("[Warning] Failed to send message to plugin: %s  %s", p.descriptor.ID, err.Error())
 	}
@@ -328,12 +402,28 @@ func (p *plugin) sendMessage(message *gauge_messages.Message) error {
 }
 
 func StartPlugins(manifest *manifest.Manifest) Handler {
-	pluginHandler, warnings := startPluginsForExecution(manifest)
+	pluginHandler, warnings := startPluginsForExecution(manifest, env.CurrentEnv())
 	logger.HandleWarningMessages(warnings)
 	return pluginHandler
 }
 
 func getLatestInstalledPlugin(pluginDir string) (*PluginInfo, error) {
+	versionToPlugins, err := installedPluginVersions(pluginDir)
+	if err != nil {
+		return nil, err
+	}
+	var availableVersions []*version.Version
+	for k := range versionToPlugins {
+		vp, _ := version.ParseVersion(k)
+		availableVersions = append(availableVersions, vp)
+	}
+	latestVersion := version.GetLatestVersion(availableVersions)
+	latestBuild := getLatestOf(versionToPlugins[latestVersion.String()], latestVersion)
+	return &latestBuild, nil
+}
+
+// installedPluginVersions groups the builds of a plugin installed in pluginDir by their version.
+func installedPluginVersions(pluginDir string) (map[string][]PluginInfo, error) {
 	files, err := ioutil.ReadDir(pluginDir)
 	if err != nil {
 		return nil, fmt.Errorf("Error listing files in plugin directory %s: %s", pluginDir, err.Error())
@@ -358,15 +448,7 @@ func getLatestInstalledPlugin(pluginDir string) (*PluginInfo, error) {
 	if len(versionToPlugins) < 1 {
 		return nil, fmt.Errorf("No valid versions of plugin %s found in %s", pluginName, pluginDir)
 	}
-
-	var availableVersions []*version.Version
-	for k := range versionToPlugins {
-		vp, _ := version.ParseVersion(k)
-		availableVersions = append(availableVersions, vp)
-	}
-	latestVersion := version.GetLatestVersion(availableVersions)
-	latestBuild := getLatestOf(versionToPlugins[latestVersion.String()], latestVersion)
-	return &latestBuild, nil
+	return versionToPlugins, nil
 }
 
 func getLatestOf(plugins []PluginInfo, latestVersion *version.Version) PluginInfo {
@@ -421,6 +503,23 @@ func PluginsWithoutScope() (infos []PluginInfo) {
 	return
 }
 
+// InstalledPluginsWithScope returns the latest installed version of each plugin which declares the given scope.
+// Scopes are matched ignoring case.
+func InstalledPluginsWithScope(scope string) ([]*pluginDescriptor, error) {
+	plugins, err := GetAllInstalledPluginsWithVersion()
+	if err != nil {
+		return nil, err
+	}
+	var pds []*pluginDescriptor
+	for _, p := range plugins {
+		pd, err := GetPluginDescriptor(p.Name, p.Version.String())
+		if err == nil && isPluginValidFor(pd, scope) {
+			pds = append(pds, pd)
+		}
+	}
+	return pds, nil
+}
+
 type PluginInfo struct {
 	Name    string
 	Version *version.Version
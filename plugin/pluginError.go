@@ -0,0 +1,96 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getgauge/gauge/version"
+)
+
+// PluginErrorKind classifies the errors returned while loading or using a plugin.
+type PluginErrorKind int
+
+const (
+	// IncompatibleGaugeVersion is returned when the running Gauge version is outside the plugin's gaugeVersionSupport range.
+	IncompatibleGaugeVersion PluginErrorKind = iota
+	// MissingCommand is returned when the executable of the plugin's command does not exist.
+	MissingCommand
+	// CommandNotExecutable is returned when the executable of the plugin's command cannot be executed.
+	CommandNotExecutable
+)
+
+// PluginError is an error related to a specific plugin.
+type PluginError struct {
+	Kind     PluginErrorKind
+	PluginID string
+	Err      error
+}
+
+func (e *PluginError) Error() string {
+	switch e.Kind {
+	case IncompatibleGaugeVersion:
+		return fmt.Sprintf("Plugin %s is not compatible with Gauge version %s. %s", e.PluginID, version.CurrentGaugeVersion, e.Err.Error())
+	case MissingCommand, CommandNotExecutable:
+		return fmt.Sprintf("Plugin %s cannot be started. %s. Try reinstalling it with `gauge install %s`.", e.PluginID, e.Err.Error(), e.PluginID)
+	}
+	return fmt.Sprintf("Plugin %s: %s", e.PluginID, e.Err.Error())
+}
+
+// CheckGaugeVersion returns a PluginError if the running Gauge version is not supported by the plugin.
+func (pd *pluginDescriptor) CheckGaugeVersion() error {
+	if err := version.CheckCompatibility(version.CurrentGaugeVersion, &pd.GaugeVersionSupport); err != nil {
+		return &PluginError{Kind: IncompatibleGaugeVersion, PluginID: pd.ID, Err: err}
+	}
+	return nil
+}
+
+var windowsExecutableExtensions = []string{".exe", ".bat", ".cmd", ".com"}
+
+// checkCommand returns a PluginError if the executable of the given plugin command is missing or cannot be executed on goos.
+// A relative executable path is resolved against the plugin's install directory, as it is when the plugin is started.
+func (pd *pluginDescriptor) checkCommand(command []string, goos string) error {
+	executable := command[0]
+	if !filepath.IsAbs(executable) {
+		executable = filepath.Join(pd.pluginPath, executable)
+	}
+	info, err := os.Stat(executable)
+	if err != nil {
+		return &PluginError{Kind: MissingCommand, PluginID: pd.ID, Err: fmt.Errorf("Command %s does not exist", executable)}
+	}
+	if info.IsDir() || !isExecutable(executable, info.Mode(), goos) {
+		return &PluginError{Kind: CommandNotExecutable, PluginID: pd.ID, Err: fmt.Errorf("Command %s is not executable", executable)}
+	}
+	return nil
+}
+
+func isExecutable(file string, mode os.FileMode, goos string) bool {
+	if goos != "windows" {
+		return mode&0111 != 0
+	}
+	extension := strings.ToLower(filepath.Ext(file))
+	for _, e := range windowsExecutableExtensions {
+		if extension == e {
+			return true
+		}
+	}
+	return false
+}
@@ -19,9 +19,12 @@ package plugin
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/getgauge/common"
@@ -245,3 +248,165 @@ func TestGetPluginsWithoutScope(t *testing.T) {
 		t.Errorf("Failed GetPluginWithoutScope.\n\tWant: %v\n\tGot: %v", want, got)
 	}
 }
+
+func (s *MySuite) TestCheckGaugeVersion(c *C) {
+	currentVersion := version.CurrentGaugeVersion
+	defer func() { version.CurrentGaugeVersion = currentVersion }()
+	version.CurrentGaugeVersion = &version.Version{Major: 0, Minor: 9, Patch: 8}
+	pd := &pluginDescriptor{ID: "html-report", GaugeVersionSupport: version.VersionSupport{Minimum: "0.9.0", Maximum: "1.0.0"}}
+
+	c.Assert(pd.CheckGaugeVersion(), IsNil)
+
+	pd.GaugeVersionSupport = version.VersionSupport{Minimum: "0.9.9"}
+	err := pd.CheckGaugeVersion()
+	c.Assert(err, NotNil)
+	c.Assert(err.(*PluginError).Kind, Equals, IncompatibleGaugeVersion)
+
+	pd.GaugeVersionSupport = version.VersionSupport{Minimum: "0.8.0", Maximum: "0.9.7"}
+	err = pd.CheckGaugeVersion()
+	c.Assert(err, NotNil)
+	c.Assert(err.(*PluginError).PluginID, Equals, "html-report")
+}
+
+func (s *MySuite) TestCheckCommandWhenExecutableIsMissing(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugePlugin")
+	defer os.RemoveAll(dir)
+	pd := &pluginDescriptor{ID: "html-report", pluginPath: dir}
+
+	err := pd.checkCommand([]string{filepath.Join("bin", "html-report")}, "linux")
+
+	c.Assert(err, NotNil)
+	c.Assert(err.(*PluginError).Kind, Equals, MissingCommand)
+	c.Assert(err.Error(), Equals, fmt.Sprintf("Plugin html-report cannot be started. Command %s does not exist. Try reinstalling it with `gauge install html-report`.", filepath.Join(dir, "bin", "html-report")))
+}
+
+func (s *MySuite) TestCheckCommandWhenExecutableIsNotExecutable(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugePlugin")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "html-report"), []byte(""), 0644)
+	pd := &pluginDescriptor{ID: "html-report", pluginPath: dir}
+
+	err := pd.checkCommand([]string{"html-report"}, "linux")
+
+	c.Assert(err, NotNil)
+	c.Assert(err.(*PluginError).Kind, Equals, CommandNotExecutable)
+	c.Assert(pd.checkCommand([]string{"html-report"}, "windows"), NotNil)
+}
+
+func (s *MySuite) TestCheckCommandWhenExecutableExists(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugePlugin")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "html-report"), []byte(""), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "html-report.exe"), []byte(""), 0644)
+	pd := &pluginDescriptor{ID: "html-report", pluginPath: dir}
+
+	c.Assert(pd.checkCommand([]string{"html-report"}, "linux"), IsNil)
+	c.Assert(pd.checkCommand([]string{filepath.Join(dir, "html-report")}, "darwin"), IsNil)
+	c.Assert(pd.checkCommand([]string{"html-report.exe"}, "windows"), IsNil)
+}
+
+func (s *MySuite) TestCommandFor(c *C) {
+	pd := &pluginDescriptor{}
+	pd.Command.Windows = []string{"bin/html-report.exe"}
+	pd.Command.Darwin = []string{"bin/html-report-darwin"}
+	pd.Command.Linux = []string{"bin/html-report"}
+
+	c.Assert(pd.commandFor("windows"), DeepEquals, []string{"bin/html-report.exe"})
+	c.Assert(pd.commandFor("darwin"), DeepEquals, []string{"bin/html-report-darwin"})
+	c.Assert(pd.commandFor("linux"), DeepEquals, []string{"bin/html-report"})
+}
+
+func (s *MySuite) TestHighestSatisfyingVersion(c *C) {
+	path, _ := filepath.Abs(filepath.Join("_testdata", "java"))
+
+	v, err := highestSatisfyingVersion(path, "~1.0")
+
+	c.Assert(err, Equals, nil)
+	c.Assert(v, Equals, "1.0.3")
+}
+
+func (s *MySuite) TestHighestSatisfyingVersionWhenNoneMatch(c *C) {
+	path, _ := filepath.Abs(filepath.Join("_testdata", "java"))
+
+	_, err := highestSatisfyingVersion(path, "^2")
+
+	c.Assert(err.Error(), Equals, "No installed version of plugin java satisfies ^2")
+}
+
+func (s *MySuite) TestPluginEnvPropertiesFromEnvironment(c *C) {
+	projectRoot, _ := ioutil.TempDir("", "gaugePluginEnv")
+	defer os.RemoveAll(projectRoot)
+	oldProjectRoot := config.ProjectRoot
+	config.ProjectRoot = projectRoot
+	defer func() { config.ProjectRoot = oldProjectRoot }()
+	for env, properties := range map[string]string{
+		"default": "html-report.output_dir = reports/html\nhtml-report.theme = dark\n",
+		"staging": "html-report.output_dir = reports/staging\nhtml-report.theme = light\n",
+	} {
+		dir := filepath.Join(projectRoot, common.EnvDirectoryName, env)
+		os.MkdirAll(dir, common.NewDirectoryPermissions)
+		ioutil.WriteFile(filepath.Join(dir, "plugins.properties"), []byte(properties), common.NewFilePermissions)
+	}
+	pd := &pluginDescriptor{ID: "html-report"}
+
+	properties, err := pluginEnvProperties(pd, "staging", map[string]string{"theme": "plain", "plugin_connection_port": "1234"})
+
+	c.Assert(err, IsNil)
+	c.Assert(properties, DeepEquals, map[string]string{"output_dir": "reports/staging"})
+
+	properties, err = pluginEnvProperties(pd, "default", map[string]string{})
+
+	c.Assert(err, IsNil)
+	c.Assert(properties, DeepEquals, map[string]string{"output_dir": "reports/html", "theme": "dark"})
+}
+
+func TestInstalledPluginsWithScope(t *testing.T) {
+	path, _ := filepath.Abs(filepath.Join("_testdata"))
+	os.Setenv(common.GaugeHome, path)
+
+	tests := []struct {
+		scope string
+		want  []string
+	}{
+		{"execution", []string{"listener", "reporter"}},
+		{"DOCUMENTATION", []string{"docs", "listener"}},
+		{"unknown", nil},
+	}
+	for _, test := range tests {
+		pds, err := InstalledPluginsWithScope(test.scope)
+		if err != nil {
+			t.Fatalf("Failed InstalledPluginsWithScope(%s). %s", test.scope, err.Error())
+		}
+		var got []string
+		for _, pd := range pds {
+			got = append(got, pd.ID)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Failed InstalledPluginsWithScope(%s).\n\tWant: %v\n\tGot: %v", test.scope, test.want, got)
+		}
+	}
+}
+
+func (s *MySuite) TestEnvPropertiesAreSetOnlyForThePluginTheyAreMeantFor(c *C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("fake plugin is a shell command")
+	}
+	sh, err := exec.LookPath("sh")
+	c.Assert(err, IsNil)
+	htmlReport := &pluginDescriptor{ID: "html-report", Name: "html-report"}
+	htmlReport.Command.Linux = []string{sh, "-c", "exit 0"}
+	htmlReport.Command.Darwin = htmlReport.Command.Linux
+	xmlReport := &pluginDescriptor{ID: "xml-report", Name: "xml-report"}
+	xmlReport.Command.Linux = htmlReport.Command.Linux
+	xmlReport.Command.Darwin = htmlReport.Command.Linux
+
+	first, err := startPlugin(htmlReport, "test", map[string]string{"gauge_test_theme": "dark"}, OutputOptions{})
+	c.Assert(err, IsNil)
+	second, err := startPlugin(xmlReport, "test", nil, OutputOptions{})
+	c.Assert(err, IsNil)
+
+	c.Assert(first.pluginCmd.Env, Not(IsNil))
+	c.Assert(first.pluginCmd.Env[len(first.pluginCmd.Env)-1], Equals, "gauge_test_theme=dark")
+	c.Assert(second.pluginCmd.Env, IsNil)
+	c.Assert(os.Getenv("gauge_test_theme"), Equals, "")
+}
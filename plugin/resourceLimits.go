@@ -0,0 +1,105 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getgauge/gauge/config"
+	"github.com/getgauge/gauge/logger"
+)
+
+// memoryCheckInterval is how often the memory used by a plugin with a memory limit is checked.
+var memoryCheckInterval = 2 * time.Second
+
+// maxMemory gives the memory in MB the plugin may use. The limit in plugin.json takes precedence over Gauge's configuration.
+func (pd *pluginDescriptor) maxMemory() int {
+	if pd.ResourceLimits.MaxMemory > 0 {
+		return pd.ResourceLimits.MaxMemory
+	}
+	return config.PluginMaxMemory()
+}
+
+// watchMemory kills the plugin if its resident memory goes beyond limit MB. It returns once the plugin has exited.
+func (p *plugin) watchMemory(limit int) {
+	pid := p.pluginCmd.Process.Pid
+	for p.IsProcessRunning() {
+		time.Sleep(memoryCheckInterval)
+		rss, err := processMemory(pid)
+		if err != nil {
+			if p.IsProcessRunning() {
+				logger.Debugf("Unable to check the memory used by plugin [%s] with pid [%d]. %s", p.descriptor.Name, pid, err.Error())
+			}
+			return
+		}
+		if rss > limit {
+			logger.Warningf("Plugin [%s] with pid [%d] is using %d MB of memory, more than its limit of %d MB. Killing it.", p.descriptor.Name, pid, rss, limit)
+			if err := p.pluginCmd.Process.Kill(); err != nil {
+				logger.Warningf("Error while killing plugin %s : %s ", p.descriptor.Name, err.Error())
+			}
+			return
+		}
+	}
+}
+
+// processMemory gives the resident memory of the process in MB.
+func processMemory(pid int) (int, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return procStatusMemory(fmt.Sprintf("/proc/%d/status", pid))
+	case "windows":
+		return 0, fmt.Errorf("Memory of a process can not be checked on %s.", runtime.GOOS)
+	default:
+		out, err := exec.Command("ps", "-o", "rss=", "-p", strconv.Itoa(pid)).Output()
+		if err != nil {
+			return 0, err
+		}
+		kb, err := strconv.Atoi(strings.TrimSpace(string(out)))
+		if err != nil {
+			return 0, err
+		}
+		return kb / 1024, nil
+	}
+}
+
+func procStatusMemory(statusFile string) (int, error) {
+	f, err := os.Open(statusFile)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "VmRSS:" {
+			kb, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return 0, err
+			}
+			return kb / 1024, nil
+		}
+	}
+	return 0, fmt.Errorf("VmRSS not found in %s", statusFile)
+}
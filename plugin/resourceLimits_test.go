@@ -0,0 +1,107 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+const allocatingPluginEnv = "GAUGE_TEST_ALLOCATING_PLUGIN"
+
+// TestAllocatingPlugin is not a test, it is run as a fake plugin which allocates more memory than its limit.
+func TestAllocatingPlugin(t *testing.T) {
+	if os.Getenv(allocatingPluginEnv) != "true" {
+		return
+	}
+	memory := make([]byte, 200*1024*1024)
+	for i := range memory {
+		memory[i] = 1
+	}
+	time.Sleep(30 * time.Second)
+	os.Exit(int(memory[0]))
+}
+
+func (s *MySuite) TestWatchMemoryKillsPluginAboveLimit(c *C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("memory of a process can not be checked on windows")
+	}
+	oldInterval := memoryCheckInterval
+	memoryCheckInterval = 50 * time.Millisecond
+	defer func() { memoryCheckInterval = oldInterval }()
+	cmd := exec.Command(os.Args[0], "-test.run=TestAllocatingPlugin")
+	cmd.Env = append(os.Environ(), allocatingPluginEnv+"=true")
+	c.Assert(cmd.Start(), IsNil)
+	defer cmd.Process.Kill()
+	p := &plugin{pluginCmd: cmd, descriptor: &pluginDescriptor{Name: "allocating"}, mutex: &sync.Mutex{}}
+	go func() {
+		cmd.Wait()
+		p.mutex.Lock()
+		p.exited = true
+		p.mutex.Unlock()
+	}()
+
+	watched := make(chan bool)
+	go func() {
+		p.watchMemory(50)
+		watched <- true
+	}()
+
+	select {
+	case <-watched:
+	case <-time.After(20 * time.Second):
+		c.Fatal("plugin using more memory than its limit was not killed")
+	}
+	for i := 0; i < 50 && !hasExited(p); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Assert(hasExited(p), Equals, true)
+}
+
+func hasExited(p *plugin) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.exited
+}
+
+func (s *MySuite) TestMaxMemoryFromPluginJSON(c *C) {
+	pd := &pluginDescriptor{}
+	pd.ResourceLimits.MaxMemory = 256
+
+	c.Assert(pd.maxMemory(), Equals, 256)
+}
+
+func (s *MySuite) TestProcStatusMemory(c *C) {
+	dir, _ := ioutil.TempDir("", "gaugeProcStatus")
+	defer os.RemoveAll(dir)
+	status := filepath.Join(dir, "status")
+	ioutil.WriteFile(status, []byte("Name:\tfake\nVmPeak:\t  409600 kB\nVmRSS:\t  204800 kB\n"), 0644)
+
+	rss, err := procStatusMemory(status)
+
+	c.Assert(err, IsNil)
+	c.Assert(rss, Equals, 200)
+}
@@ -0,0 +1,73 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package plugin
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/version"
+)
+
+// PluginVersionResolver resolves the version constraint of a plugin to the version that should be used.
+type PluginVersionResolver interface {
+	// Resolve returns the version directory of the plugin satisfying the constraint.
+	// An empty result means the latest installed version.
+	Resolve(id, constraint string) (string, error)
+}
+
+// installedVersionResolver picks the highest installed version of a plugin that satisfies the constraint.
+type installedVersionResolver struct{}
+
+var versionResolver PluginVersionResolver = installedVersionResolver{}
+
+func (installedVersionResolver) Resolve(id, constraint string) (string, error) {
+	if constraint == "" {
+		return "", nil
+	}
+	allPluginsInstallDir, err := common.GetPluginsInstallDir(id)
+	if err != nil {
+		return "", err
+	}
+	return highestSatisfyingVersion(filepath.Join(allPluginsInstallDir, id), constraint)
+}
+
+func highestSatisfyingVersion(pluginDir, constraint string) (string, error) {
+	versionToPlugins, err := installedPluginVersions(pluginDir)
+	if err != nil {
+		return "", err
+	}
+	var matching []*version.Version
+	for k := range versionToPlugins {
+		vp, _ := version.ParseVersion(k)
+		ok, err := vp.Satisfies(constraint)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			matching = append(matching, vp)
+		}
+	}
+	if len(matching) < 1 {
+		return "", fmt.Errorf("No installed version of plugin %s satisfies %s", filepath.Base(pluginDir), constraint)
+	}
+	latestVersion := version.GetLatestVersion(matching)
+	latestBuild := getLatestOf(versionToPlugins[latestVersion.String()], latestVersion)
+	return filepath.Base(latestBuild.Path), nil
+}
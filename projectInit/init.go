@@ -144,7 +144,12 @@ func showMessage(action, filename string) {
 }
 
 func createProjectTemplate(language string) error {
-	if err := runner.ExecuteInitHookForRunner(language); err != nil {
+	if templatesDir, ok := languageTemplatesDir(language); ok {
+		data := templateData{ProjectName: filepath.Base(config.ProjectRoot), Language: language}
+		if err := copyLanguageTemplates(templatesDir, config.ProjectRoot, data); err != nil {
+			return fmt.Errorf("Failed to copy templates of %s. %s", language, err.Error())
+		}
+	} else if err := runner.ExecuteInitHookForRunner(language); err != nil {
 		return err
 	}
 	if err := createManifestFile(language); err != nil {
@@ -0,0 +1,92 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package projectInit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	textTemplate "text/template"
+
+	"github.com/getgauge/common"
+	"github.com/getgauge/gauge/plugin"
+)
+
+// languageTemplatesDirName is the directory in a language plugin's install directory
+// which holds the files to be copied into a new project.
+const languageTemplatesDirName = "templates"
+
+// templateData holds the values which can be used in the files of a language template,
+// e.g. {{.ProjectName}} and {{.Language}}.
+type templateData struct {
+	ProjectName string
+	Language    string
+}
+
+func languageTemplatesDir(language string) (string, bool) {
+	installDir, err := plugin.GetInstallDir(language, "")
+	if err != nil {
+		return "", false
+	}
+	templatesDir := filepath.Join(installDir, languageTemplatesDirName)
+	return templatesDir, common.DirExists(templatesDir)
+}
+
+// copyLanguageTemplates renders each file in srcDir as a text/template and writes it to the same
+// relative path in destDir. Files which already exist in destDir are skipped.
+func copyLanguageTemplates(srcDir, destDir string, data templateData) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(destDir, relPath)
+		if info.IsDir() {
+			return os.MkdirAll(destPath, common.NewDirectoryPermissions)
+		}
+		if common.FileExists(destPath) {
+			showMessage("skip", relPath)
+			return nil
+		}
+		showMessage("create", relPath)
+		return renderTemplate(path, destPath, data)
+	})
+}
+
+func renderTemplate(src, dest string, data templateData) error {
+	contents, err := common.ReadFileContents(src)
+	if err != nil {
+		return err
+	}
+	t, err := textTemplate.New(filepath.Base(src)).Parse(contents)
+	if err != nil {
+		return fmt.Errorf("Failed to parse template %s. %s", src, err.Error())
+	}
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, common.NewFilePermissions)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := t.Execute(f, data); err != nil {
+		return fmt.Errorf("Failed to render template %s. %s", src, err.Error())
+	}
+	return nil
+}
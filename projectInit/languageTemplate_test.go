@@ -0,0 +1,57 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package projectInit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestCopyLanguageTemplates(c *C) {
+	srcDir, _ := ioutil.TempDir("", "gaugeTemplate")
+	defer os.RemoveAll(srcDir)
+	destDir, _ := ioutil.TempDir("", "gaugeProject")
+	defer os.RemoveAll(destDir)
+	os.MkdirAll(filepath.Join(srcDir, "specs"), 0755)
+	ioutil.WriteFile(filepath.Join(srcDir, "specs", "example.spec"), []byte("# {{.ProjectName}} in {{.Language}}\n"), 0644)
+	ioutil.WriteFile(filepath.Join(srcDir, "existing.txt"), []byte("template"), 0644)
+	ioutil.WriteFile(filepath.Join(destDir, "existing.txt"), []byte("original"), 0644)
+
+	err := copyLanguageTemplates(srcDir, destDir, templateData{ProjectName: "foo", Language: "java"})
+
+	c.Assert(err, IsNil)
+	spec, _ := ioutil.ReadFile(filepath.Join(destDir, "specs", "example.spec"))
+	c.Assert(string(spec), Equals, "# foo in java\n")
+	existing, _ := ioutil.ReadFile(filepath.Join(destDir, "existing.txt"))
+	c.Assert(string(existing), Equals, "original")
+}
+
+func (s *MySuite) TestCopyLanguageTemplatesWithInvalidTemplate(c *C) {
+	srcDir, _ := ioutil.TempDir("", "gaugeTemplate")
+	defer os.RemoveAll(srcDir)
+	destDir, _ := ioutil.TempDir("", "gaugeProject")
+	defer os.RemoveAll(destDir)
+	ioutil.WriteFile(filepath.Join(srcDir, "example.spec"), []byte("# {{.ProjectName"), 0644)
+
+	err := copyLanguageTemplates(srcDir, destDir, templateData{ProjectName: "foo", Language: "java"})
+
+	c.Assert(err, NotNil)
+}
@@ -50,6 +50,7 @@ type rephraseRefactorer struct {
 	newStep   *gauge.Step
 	isConcept bool
 	runner    runner.Runner
+	renames   []*gauge.RenameResult
 }
 
 type refactoringResult struct {
@@ -59,6 +60,7 @@ type refactoringResult struct {
 	RunnerFilesChanged map[string]string
 	Errors             []string
 	Warnings           []string
+	Renames            []*gauge.RenameResult
 }
 
 func (refactoringResult *refactoringResult) String() string {
@@ -225,6 +227,7 @@ func (agent *rephraseRefactorer) performRefactoringOn(specs []*gauge.Specificati
 		return result
 	}
 	result.SpecsChanged, result.ConceptsChanged = getFileChanges(specs, conceptDictionary, specsRefactored, conceptFilesRefactored)
+	result.Renames = agent.renames
 	writeFileChangesToDisk(result)
 	return result
 }
@@ -245,6 +248,7 @@ func (agent *rephraseRefactorer) getRefactoringChangesFor(specs []*gauge.Specifi
 		return result
 	}
 	result.SpecsChanged, result.ConceptsChanged = getFileChanges(specs, conceptDictionary, specsRefactored, conceptFilesRefactored)
+	result.Renames = agent.renames
 	return result
 }
 
@@ -276,9 +280,12 @@ func (agent *rephraseRefactorer) rephraseInSpecsAndConcepts(specs *[]*gauge.Spec
 	conceptFilesRefactored := make(map[string]bool, 0)
 	orderMap := agent.createOrderOfArgs()
 	refactoredSteps := make([]*gauge.Step, 0)
+	agent.renames = nil
 	for _, spec := range *specs {
 		rSteps := make([]*gauge.Step, 0)
-		specsRefactored[spec] = spec.RenameSteps(*agent.oldStep, *agent.newStep, orderMap)
+		renames := spec.RenameSteps(*agent.oldStep, *agent.newStep, orderMap)
+		specsRefactored[spec] = len(renames) > 0
+		agent.renames = append(agent.renames, renames...)
 		refactoredSteps = append(refactoredSteps, rSteps...)
 	}
 	isConcept := false
@@ -286,11 +293,11 @@ func (agent *rephraseRefactorer) rephraseInSpecsAndConcepts(specs *[]*gauge.Spec
 		_, ok := conceptFilesRefactored[concept.FileName]
 		conceptFilesRefactored[concept.FileName] = !ok && false || conceptFilesRefactored[concept.FileName]
 		for _, item := range concept.ConceptStep.Items {
-			isRefactored := conceptFilesRefactored[concept.FileName]
 			if item.Kind() == gauge.StepKind {
-				if item.(*gauge.Step).Rename(*agent.oldStep, *agent.newStep, isRefactored, orderMap, &isConcept) {
+				if r := item.(*gauge.Step).Rename(*agent.oldStep, *agent.newStep, orderMap, &isConcept); r.WasRefactored {
 					conceptFilesRefactored[concept.FileName] = true
 					refactoredSteps = append(refactoredSteps, item.(*gauge.Step))
+					agent.renames = append(agent.renames, r)
 				}
 			}
 		}
@@ -455,6 +462,9 @@ func printRefactoringSummary(refactoringResult *refactoringResult) {
 	for _, warning := range refactoringResult.Warnings {
 		logger.Warningf("%s \n", warning)
 	}
+	for _, r := range refactoringResult.Renames {
+		logger.Infof("%s", r.String())
+	}
 	logger.Infof("%d specifications changed.\n", len(refactoringResult.specFilesChanged()))
 	logger.Infof("%d concepts changed.\n", len(refactoringResult.conceptFilesChanged()))
 	logger.Infof("%d files in code changed.\n", len(refactoringResult.RunnerFilesChanged))
@@ -49,6 +49,43 @@ func (s *MySuite) TestRefactoringOfStepsWithNoArgs(c *C) {
 	c.Assert(specs[0].Scenarios[0].Steps[0].Value, Equals, newStep)
 }
 
+func (s *MySuite) TestRefactoringCollectsRenamedSteps(c *C) {
+	oldStep := "first step"
+	newStep := "second step"
+	tokens := []*parser.Token{
+		&parser.Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&parser.Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 2},
+		&parser.Token{Kind: gauge.StepKind, Value: oldStep, LineNo: 3},
+	}
+	spec, _, _ := new(parser.SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "foo.spec")
+	agent, _ := getRefactorAgent(oldStep, newStep, nil)
+	specs := append(make([]*gauge.Specification, 0), spec)
+	agent.rephraseInSpecsAndConcepts(&specs, gauge.NewConceptDictionary())
+
+	c.Assert(len(agent.renames), Equals, 1)
+	c.Assert(agent.renames[0].String(), Equals, "foo.spec:3 first step => second step")
+}
+
+func (s *MySuite) TestRefactoringOfStepsInScenarioTearDown(c *C) {
+	oldStep := "first step"
+	newStep := "second step"
+	tokens := []*parser.Token{
+		&parser.Token{Kind: gauge.SpecKind, Value: "Spec Heading", LineNo: 1},
+		&parser.Token{Kind: gauge.ScenarioKind, Value: "Scenario Heading", LineNo: 2},
+		&parser.Token{Kind: gauge.StepKind, Value: "unchanged", LineNo: 3},
+	}
+	spec, _, _ := new(parser.SpecParser).CreateSpecification(tokens, gauge.NewConceptDictionary(), "")
+	spec.Scenarios[0].AddTearDownStep(&gauge.Step{Value: oldStep, LineText: oldStep, LineNo: 5})
+	agent, errs := getRefactorAgent(oldStep, newStep, nil)
+	specs := append(make([]*gauge.Specification, 0), spec)
+	agent.rephraseInSpecsAndConcepts(&specs, gauge.NewConceptDictionary())
+
+	c.Assert(len(errs), Equals, 0)
+	c.Assert(specs[0].Scenarios[0].Steps[0].Value, Equals, "unchanged")
+	c.Assert(len(specs[0].Scenarios[0].TearDownSteps), Equals, 1)
+	c.Assert(specs[0].Scenarios[0].TearDownSteps[0].Value, Equals, newStep)
+}
+
 func (s *MySuite) TestRefactoringOfStepsWithNoArgsAndWithMoreThanOneScenario(c *C) {
 	oldStep := "first step"
 	newStep := "second step"
@@ -227,7 +264,7 @@ func (s *MySuite) TestCreateOrderGivesMapOfOldArgsAndNewArgs(c *C) {
 	step1 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "a"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "d"}}}
 	step2 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "d"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "a"}}}
 
-	agent := &rephraseRefactorer{step1, step2, false, nil}
+	agent := &rephraseRefactorer{oldStep: step1, newStep: step2}
 	orderMap := agent.createOrderOfArgs()
 
 	c.Assert(orderMap[0], Equals, 3)
@@ -239,7 +276,7 @@ func (s *MySuite) TestCreateOrderGivesMapOfOldArgsAndNewWhenArgsAreAdded(c *C) {
 	step1 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "a"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "d"}}}
 	step2 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "d"}, &gauge.StepArg{Name: "e"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "a"}}}
 
-	agent := &rephraseRefactorer{step1, step2, false, nil}
+	agent := &rephraseRefactorer{oldStep: step1, newStep: step2}
 	orderMap := agent.createOrderOfArgs()
 
 	c.Assert(orderMap[0], Equals, 3)
@@ -253,7 +290,7 @@ func (s *MySuite) TestCreateOrderGivesMapOfOldArgsAndNewWhenArgsAreRemoved(c *C)
 	step1 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "a"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}, &gauge.StepArg{Name: "d"}}}
 	step2 := &gauge.Step{Args: []*gauge.StepArg{&gauge.StepArg{Name: "d"}, &gauge.StepArg{Name: "b"}, &gauge.StepArg{Name: "c"}}}
 
-	agent := &rephraseRefactorer{step1, step2, false, nil}
+	agent := &rephraseRefactorer{oldStep: step1, newStep: step2}
 	orderMap := agent.createOrderOfArgs()
 
 	c.Assert(orderMap[0], Equals, 3)
@@ -18,8 +18,10 @@
 package util
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/getgauge/common"
@@ -84,11 +86,17 @@ func findFilesIn(dirRoot string, isValidFile func(path string) bool, shouldSkip
 	return files
 }
 
-// FindSpecFilesIn Finds spec files in the given directory
+// FindSpecFilesIn Finds spec files in the given directory, skipping the paths excluded by the .gaugeignore file.
+// The files are sorted by their absolute path, so the order does not depend on the file system.
 func FindSpecFilesIn(dir string) []string {
-	return findFilesIn(dir, IsValidSpecExtension, func(path string, f os.FileInfo) bool {
-		return false
+	ignore := loadGaugeIgnore(config.ProjectRoot)
+	files := findFilesIn(dir, func(path string) bool {
+		return IsValidSpecExtension(path) && !ignore.isIgnored(path, false)
+	}, func(path string, f os.FileInfo) bool {
+		return f != nil && f.IsDir() && ignore.isIgnored(path, true)
 	})
+	sort.Strings(files)
+	return files
 }
 
 // IsValidSpecExtension Checks if the path has a spec file extension
@@ -128,16 +136,67 @@ func IsGaugeFile(path string) bool {
 	return IsConcept(path) || IsSpec(path)
 }
 
-// FindAllNestedDirs returns list of all nested directories in given path
-func FindAllNestedDirs(dir string) []string {
+// DefaultMaxNestedDirDepth is the depth up to which FindAllNestedDirs looks for nested directories by default.
+const DefaultMaxNestedDirDepth = 10
+
+// FindAllNestedDirs returns list of all nested directories in given path, up to maxDepth levels below it.
+// A non-positive maxDepth means DefaultMaxNestedDirDepth. If there are directories deeper than maxDepth,
+// they are skipped and an error is returned along with the directories found.
+func FindAllNestedDirs(dir string, maxDepth int) ([]string, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxNestedDirDepth
+	}
 	var nestedDirs []string
+	limitReached := false
 	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err == nil && info.IsDir() && !(path == dir) {
-			nestedDirs = append(nestedDirs, path)
+		if err != nil || !info.IsDir() || path == dir {
+			return nil
+		}
+		if depth(dir, path) > maxDepth {
+			limitReached = true
+			return filepath.SkipDir
 		}
+		nestedDirs = append(nestedDirs, path)
 		return nil
 	})
-	return nestedDirs
+	if limitReached {
+		return nestedDirs, fmt.Errorf("Directories nested more than %d levels deep in %s are skipped", maxDepth, dir)
+	}
+	return nestedDirs, nil
+}
+
+func depth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 0
+	}
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
+// IsInsideSpecDir reports whether the path is one of the spec directories or is inside one of them.
+// Relative paths are resolved against the working directory. Paths which only share a prefix with a
+// spec directory, like specs-old for specs, or which climb out of it with .., are not inside.
+func IsInsideSpecDir(specDirs []string, path string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, dir := range specDirs {
+		absDir, err := filepath.Abs(dir)
+		if err == nil && isInside(absDir, absPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInside reports whether path is dir itself or is under dir. Both paths should be absolute.
+func isInside(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
 
 // IsDir reports whether path describes a directory.
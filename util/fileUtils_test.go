@@ -66,6 +66,26 @@ Scenario 1
 	c.Assert(len(FindSpecFilesIn(dir)), Equals, 2)
 }
 
+func (s *MySuite) TestFindSpecFilesInIsSortedByPath(c *C) {
+	data := []byte("# Spec\n## Scenario\n* say hello\n")
+	for _, name := range []string{"c.spec", "a.spec", filepath.Join("a", "b.spec"), "b.md"} {
+		os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		_, err := createFileIn(dir, name, data)
+		c.Assert(err, Equals, nil)
+	}
+	absDir, _ := filepath.Abs(dir)
+
+	got := FindSpecFilesIn(dir)
+
+	want := []string{
+		filepath.Join(absDir, "a.spec"),
+		filepath.Join(absDir, "a", "b.spec"),
+		filepath.Join(absDir, "b.md"),
+		filepath.Join(absDir, "c.spec"),
+	}
+	c.Assert(got, DeepEquals, want)
+}
+
 func (s *MySuite) TestFindAllConceptFiles(c *C) {
 	data := []byte(`#Concept Heading`)
 	_, err := createFileIn(dir, "concept1.cpt", data)
@@ -140,7 +160,8 @@ func (s *MySuite) TestFindAllNestedDirs(c *C) {
 	os.Mkdir(nested3, 0755)
 	os.Mkdir(nested4, 0755)
 
-	nestedDirs := FindAllNestedDirs(dir)
+	nestedDirs, err := FindAllNestedDirs(dir, DefaultMaxNestedDirDepth)
+	c.Assert(err, IsNil)
 	c.Assert(len(nestedDirs), Equals, 4)
 	c.Assert(stringInSlice(nested1, nestedDirs), Equals, true)
 	c.Assert(stringInSlice(nested2, nestedDirs), Equals, true)
@@ -149,10 +170,23 @@ func (s *MySuite) TestFindAllNestedDirs(c *C) {
 }
 
 func (s *MySuite) TestFindAllNestedDirsWhenDirDoesNotExist(c *C) {
-	nestedDirs := FindAllNestedDirs("unknown-dir")
+	nestedDirs, err := FindAllNestedDirs("unknown-dir", DefaultMaxNestedDirDepth)
+	c.Assert(err, IsNil)
 	c.Assert(len(nestedDirs), Equals, 0)
 }
 
+func (s *MySuite) TestFindAllNestedDirsStopsAtMaxDepth(c *C) {
+	nested1 := filepath.Join(dir, "nested")
+	nested2 := filepath.Join(nested1, "deep")
+	nested3 := filepath.Join(nested2, "deeper")
+	os.MkdirAll(nested3, 0755)
+
+	nestedDirs, err := FindAllNestedDirs(dir, 2)
+
+	c.Assert(err, NotNil)
+	c.Assert(nestedDirs, DeepEquals, []string{nested1, nested2})
+}
+
 func (s *MySuite) TestIsDir(c *C) {
 	c.Assert(IsDir(dir), Equals, true)
 	c.Assert(IsDir(filepath.Join(dir, "foo.txt")), Equals, false)
@@ -192,3 +226,25 @@ func createDirIn(dir string, dirName string) (string, error) {
 	err = os.Rename(tempDir, fullDirName)
 	return fullDirName, err
 }
+
+func (s *MySuite) TestIsInsideSpecDir(c *C) {
+	specsDir := filepath.Join(dir, "specs")
+	specDirs := []string{specsDir, filepath.Join(dir, "other")}
+
+	c.Assert(IsInsideSpecDir(specDirs, filepath.Join(specsDir, "foo.spec")), Equals, true)
+	c.Assert(IsInsideSpecDir(specDirs, filepath.Join(specsDir, "nested", "foo.spec")), Equals, true)
+	c.Assert(IsInsideSpecDir(specDirs, specsDir), Equals, true)
+	c.Assert(IsInsideSpecDir(specDirs, filepath.Join(dir, "other", "..foo.spec")), Equals, true)
+	c.Assert(IsInsideSpecDir(specDirs, filepath.Join(dir, "specs-old", "foo.spec")), Equals, false)
+	c.Assert(IsInsideSpecDir(specDirs, specsDir+string(filepath.Separator)+filepath.Join("..", "..", "secrets.spec")), Equals, false)
+	c.Assert(IsInsideSpecDir(nil, filepath.Join(specsDir, "foo.spec")), Equals, false)
+}
+
+func (s *MySuite) TestIsInsideSpecDirWithRelativePaths(c *C) {
+	wd, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(wd)
+
+	c.Assert(IsInsideSpecDir([]string{"specs"}, filepath.Join("specs", "foo.spec")), Equals, true)
+	c.Assert(IsInsideSpecDir([]string{"specs"}, filepath.Join("specs", "..", "foo.spec")), Equals, false)
+}
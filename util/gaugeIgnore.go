@@ -0,0 +1,153 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// GaugeIgnoreFile lists the paths, relative to the project root, which are excluded from spec discovery.
+// It follows the .gitignore syntax.
+const GaugeIgnoreFile = ".gaugeignore"
+
+type ignorePattern struct {
+	regex   *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+type gaugeIgnore struct {
+	root     string
+	patterns []ignorePattern
+}
+
+// loadGaugeIgnore reads the .gaugeignore file in the given project root. Returns nil if there is no such file.
+func loadGaugeIgnore(root string) *gaugeIgnore {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(filepath.Join(absRoot, GaugeIgnoreFile))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	ignore := &gaugeIgnore{root: absRoot}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p, ok := parseIgnorePattern(scanner.Text()); ok {
+			ignore.patterns = append(ignore.patterns, p)
+		}
+	}
+	return ignore
+}
+
+func parseIgnorePattern(line string) (ignorePattern, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false
+	}
+	var p ignorePattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return ignorePattern{}, false
+	}
+	expr := "^"
+	if !anchored {
+		expr += "(.*/)?"
+	}
+	expr += globToRegex(line) + "$"
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return ignorePattern{}, false
+	}
+	p.regex = regex
+	return p, true
+}
+
+func globToRegex(glob string) string {
+	var expr strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			_, size := utf8.DecodeRuneInString(glob[i:])
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+size]))
+			i += size - 1
+		}
+	}
+	return expr.String()
+}
+
+// isIgnored reports whether the given absolute path is excluded by the .gaugeignore file.
+// A path is also excluded when any of its parent directories is excluded.
+func (g *gaugeIgnore) isIgnored(path string, isDir bool) bool {
+	if g == nil || len(g.patterns) == 0 {
+		return false
+	}
+	if !isInside(g.root, path) {
+		return false
+	}
+	rel, err := filepath.Rel(g.root, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i := 1; i < len(parts); i++ {
+		if g.matches(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return g.matches(strings.Join(parts, "/"), isDir)
+}
+
+func (g *gaugeIgnore) matches(path string, isDir bool) bool {
+	ignored := false
+	for _, p := range g.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.regex.MatchString(path) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
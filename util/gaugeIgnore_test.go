@@ -0,0 +1,67 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"path/filepath"
+
+	"github.com/getgauge/gauge/config"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MySuite) TestFindSpecFilesInSkipsFilesListedInGaugeIgnore(c *C) {
+	config.ProjectRoot = dir
+	data := []byte("Specification Heading\n=====================\n")
+	createFileIn(dir, "first.spec", data)
+	createFileIn(dir, "wip.spec", data)
+	createFileIn(filepath.Join(dir, "drafts"), "draft.spec", data)
+	createFileIn(filepath.Join(dir, "nested", "drafts"), "nested.spec", data)
+	createFileIn(dir, GaugeIgnoreFile, []byte("# ignored specs\nwip.spec\ndrafts/\n"))
+
+	c.Assert(FindSpecFilesIn(dir), DeepEquals, []string{filepath.Join(dir, "first.spec")})
+}
+
+func (s *MySuite) TestFindSpecFilesInWithoutGaugeIgnore(c *C) {
+	config.ProjectRoot = dir
+	data := []byte("Specification Heading\n=====================\n")
+	createFileIn(dir, "first.spec", data)
+	createFileIn(filepath.Join(dir, "drafts"), "draft.spec", data)
+
+	c.Assert(len(FindSpecFilesIn(dir)), Equals, 2)
+}
+
+func (s *MySuite) TestGaugeIgnorePatterns(c *C) {
+	createFileIn(dir, GaugeIgnoreFile, []byte("*.tmp.spec\n/top.spec\nspecs/**/old\n!keep.tmp.spec\n"))
+	ignore := loadGaugeIgnore(dir)
+
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "a", "b.tmp.spec"), false), Equals, true)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "keep.tmp.spec"), false), Equals, false)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "top.spec"), false), Equals, true)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "a", "top.spec"), false), Equals, false)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "specs", "x", "old", "s.spec"), false), Equals, true)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "specs", "old"), true), Equals, true)
+}
+
+func (s *MySuite) TestGaugeIgnorePatternsWithNonASCIICharacters(c *C) {
+	createFileIn(dir, GaugeIgnoreFile, []byte("résumé.spec\nnaïve?.spec\n"))
+	ignore := loadGaugeIgnore(dir)
+
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "résumé.spec"), false), Equals, true)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "resume.spec"), false), Equals, false)
+	c.Assert(ignore.isIgnored(filepath.Join(dir, "naïve1.spec"), false), Equals, true)
+}
@@ -71,3 +71,12 @@ func convertUnixPathToURI(path string) string {
 	encodedPath := url.URL{Path: path}
 	return uriPrefix + encodedPath.String()
 }
+
+// NormalizeExecutionIdentifier - converts an execution identifier given with a file uri (eg: file://example.spec:15)
+// to the file path form accepted by gauge run (eg: example.spec:15). Identifiers without the uri prefix are returned as is.
+func NormalizeExecutionIdentifier(id string) string {
+	if !strings.HasPrefix(id, uriPrefix) {
+		return id
+	}
+	return string(ConvertURItoFilePath(lsp.DocumentURI(id)))
+}
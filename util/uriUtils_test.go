@@ -56,3 +56,23 @@ func TestConvertUnixFilePathToURI(t *testing.T) {
 		t.Errorf("got : %s, want : %s", got, want)
 	}
 }
+
+func TestNormalizeExecutionIdentifierWithURI(t *testing.T) {
+	if IsWindows() {
+		t.Skip("unix file uri")
+	}
+	id := `file:///Users/gauge/project/specs/example.spec:15`
+	want := `/Users/gauge/project/specs/example.spec:15`
+	got := NormalizeExecutionIdentifier(id)
+	if want != got {
+		t.Errorf("got : %s, want : %s", got, want)
+	}
+}
+
+func TestNormalizeExecutionIdentifierWithFilePath(t *testing.T) {
+	id := `specs/example.spec:15`
+	got := NormalizeExecutionIdentifier(id)
+	if id != got {
+		t.Errorf("got : %s, want : %s", got, id)
+	}
+}
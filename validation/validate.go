@@ -291,8 +291,7 @@ func (v *validator) validate() validationErrors {
 }
 
 func (v *SpecValidator) Validate() []error {
-	queue := &gauge.ItemQueue{Items: v.specification.AllItems()}
-	v.specification.Traverse(v, queue)
+	v.specification.TraverseDepthFirst(v)
 	return v.validationErrors
 }
 
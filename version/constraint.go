@@ -0,0 +1,94 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either Version 3 of the License, or
+// (at your option) any later Version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Satisfies tells whether the version satisfies the given constraint. A constraint is one of
+//   - an exact version, e.g. 1.2.3
+//   - a caret range, e.g. ^1 or ^1.2, allowing any later version with the same major version
+//   - a tilde range, e.g. ~1.2, allowing any later version with the same major and minor version
+//   - a minimum version, e.g. >=1.2.3
+//
+// An empty constraint or * is satisfied by every version.
+func (v *Version) Satisfies(constraint string) (bool, error) {
+	constraint = strings.TrimSpace(constraint)
+	switch {
+	case constraint == "" || constraint == "*":
+		return true, nil
+	case strings.HasPrefix(constraint, ">="):
+		min, err := ParseVersion(strings.TrimSpace(constraint[2:]))
+		if err != nil {
+			return false, constraintError(constraint, err)
+		}
+		return v.IsGreaterThanEqualTo(min), nil
+	case strings.HasPrefix(constraint, "^"):
+		min, parts, err := parsePartialVersion(constraint[1:])
+		if err != nil {
+			return false, constraintError(constraint, err)
+		}
+		if !v.IsGreaterThanEqualTo(min) || v.Major != min.Major {
+			return false, nil
+		}
+		if min.Major == 0 && parts > 1 {
+			return v.Minor == min.Minor, nil
+		}
+		return true, nil
+	case strings.HasPrefix(constraint, "~"):
+		min, parts, err := parsePartialVersion(constraint[1:])
+		if err != nil {
+			return false, constraintError(constraint, err)
+		}
+		if !v.IsGreaterThanEqualTo(min) || v.Major != min.Major {
+			return false, nil
+		}
+		return parts == 1 || v.Minor == min.Minor, nil
+	}
+	exact, err := ParseVersion(constraint)
+	if err != nil {
+		return false, constraintError(constraint, err)
+	}
+	return v.IsEqualTo(exact), nil
+}
+
+// parsePartialVersion parses versions like 1, 1.2 or 1.2.3, filling missing parts with zero.
+// It also returns the number of parts present in the text.
+func parsePartialVersion(versionText string) (*Version, int, error) {
+	splits := strings.Split(strings.TrimSpace(versionText), ".")
+	if len(splits) > 3 {
+		return nil, 0, fmt.Errorf("Incorrect Version format. Version should be in the form 1, 1.5 or 1.5.7")
+	}
+	levels := []string{"major", "minor", "patch"}
+	numbers := make([]int, 3)
+	for i, split := range splits {
+		n, err := strconv.Atoi(split)
+		if err != nil {
+			return nil, 0, VersionError(levels[i], split, err)
+		}
+		numbers[i] = n
+	}
+	return &Version{numbers[0], numbers[1], numbers[2]}, len(splits), nil
+}
+
+func constraintError(constraint string, err error) error {
+	return fmt.Errorf("Invalid version constraint %s. %s", constraint, err.Error())
+}
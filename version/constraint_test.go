@@ -0,0 +1,57 @@
+// Copyright 2015 ThoughtWorks, Inc.
+
+// This file is part of Gauge.
+
+// Gauge is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either Version 3 of the License, or
+// (at your option) any later Version.
+
+// Gauge is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+
+// You should have received a copy of the GNU General Public License
+// along with Gauge.  If not, see <http://www.gnu.org/licenses/>.
+
+package version
+
+import . "gopkg.in/check.v1"
+
+func (s *MySuite) TestSatisfiesConstraint(c *C) {
+	v := &Version{1, 4, 2}
+	cases := map[string]bool{
+		"":        true,
+		"*":       true,
+		"1.4.2":   true,
+		"1.4.3":   false,
+		"^1":      true,
+		"^1.5":    false,
+		"^2":      false,
+		"~1.4":    true,
+		"~1.3":    false,
+		"~1.4.3":  false,
+		">=1.0.0": true,
+		">=1.4.3": false,
+	}
+	for constraint, expected := range cases {
+		ok, err := v.Satisfies(constraint)
+		c.Assert(err, IsNil)
+		c.Assert(ok, Equals, expected, Commentf("constraint %s", constraint))
+	}
+}
+
+func (s *MySuite) TestSatisfiesCaretConstraintBelowOne(c *C) {
+	ok, _ := (&Version{0, 9, 8}).Satisfies("^0.9")
+	c.Assert(ok, Equals, true)
+
+	ok, _ = (&Version{0, 10, 0}).Satisfies("^0.9")
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestSatisfiesWithInvalidConstraint(c *C) {
+	_, err := (&Version{1, 0, 0}).Satisfies("^a.b")
+
+	c.Assert(err, NotNil)
+}